@@ -1,10 +1,11 @@
 package accountant
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/heimdalr/dag"
 
 	"github.com/bartossh/Computantis/src/logger"
+	"github.com/bartossh/Computantis/src/providers"
 	"github.com/bartossh/Computantis/src/spice"
 	"github.com/bartossh/Computantis/src/transaction"
 )
@@ -23,10 +25,6 @@ const (
 	truncateDiff       uint64 = 1_000
 )
 
-const repeaterTick = time.Second * 2
-
-const backupName = "vertex_db_backup_"
-
 func checkCanTruncate(current, desired uint64) bool {
 	return current > desired && current > truncateDiff && current-truncateDiff > desired
 }
@@ -57,6 +55,16 @@ var (
 	ErrEntityNotFound                        = errors.New("entity not fund")
 	ErrBreak                                 = errors.New("just break")
 	ErrParentDoesNotExists                   = errors.New("parent doesn't exists")
+	ErrWrongPaginationParameters             = errors.New("wrong pagination parameters, offset cannot be negative and limit must be positive")
+	ErrRestoreOnLoadedDAG                    = errors.New("cannot restore from backups when DAG is loaded")
+	ErrStreamingProcessStopped               = errors.New("streaming vertices process stopped")
+	ErrVertexCorrupted                       = errors.New("vertex is corrupted")
+	ErrBackupCorrupted                       = errors.New("backup is corrupted")
+	ErrBackupVertexRejected                  = errors.New("backup vertex rejected, signature verification failed")
+	ErrInclusionProofUnavailable             = errors.New("inclusion proof unavailable, vertex has been truncated from the DAG")
+	ErrInclusionProofProcessStopped          = errors.New("inclusion proof process stopped")
+	ErrUnknownTipSelection                   = errors.New("unknown tip selection strategy")
+	ErrConflictLost                          = errors.New("vertex rejected, it approves vertex that lost double spending conflict")
 )
 
 type signatureVerifier interface {
@@ -72,7 +80,8 @@ type Signer interface {
 // AccountingBook is an entity that represents the accounting process of all received transactions.
 type AccountingBook struct {
 	truncateSignal       chan uint64
-	repeater             *buffer
+	orphans              *orphanPool
+	reattached           chan *Vertex
 	verifier             signatureVerifier
 	signer               Signer
 	log                  logger.Logger
@@ -80,25 +89,40 @@ type AccountingBook struct {
 	trustedNodesDB       *badger.DB
 	trxsToVertxDB        *badger.DB
 	verticesDB           *badger.DB
+	addressesIndexDB     *badger.DB
+	journalDB            *badger.DB
+	ledger               *balanceLedger
+	tipSelector          TipSelector
+	approvals            *approvals
+	conflicts            map[string]*conflictSet
+	spenders             spenders
 	genesisPublicAddress string
 	mux                  sync.RWMutex
+	auditMux             sync.Mutex
+	auditSequence        uint64
 	weight               atomic.Uint64
 	throughput           atomic.Uint64
 	lastBackup           uint64
+	incrementalBackups   []string
+	backupMergeEvery     uint64
+	backupDir            string
+	backupRetention      int
+	backupRetentionAge   time.Duration
+	tele                 providers.HistogramGaugeProvider
 	nextWeightTruncate   uint64
+	truncateEvery        uint64
 	dagLoaded            bool
+	checkBalance         bool
+	fees                 FeeSchedule
+	nonceActivation      time.Time
+	nonceLegacy          bool
 }
 
 // New creates new AccountingBook.
 // New AccountingBook will start internally the garbage collection loop, to stop it from running cancel the context.
 func NewAccountingBook(
-	ctx context.Context, cfg Config, verifier signatureVerifier, signer Signer, l logger.Logger,
+	ctx context.Context, cfg Config, verifier signatureVerifier, signer Signer, tele providers.HistogramGaugeProvider, l logger.Logger,
 ) (*AccountingBook, error) {
-	repeater, err := newReplierBuffer(ctx, repeaterTick)
-	if err != nil {
-		return nil, err
-	}
-
 	trustedNodesDB, err := createBadgerDB(ctx, cfg.TrustedNodesDBPath, l, true)
 	if err != nil {
 		return nil, err
@@ -111,6 +135,19 @@ func NewAccountingBook(
 	if err != nil {
 		return nil, err
 	}
+	addressesIndexDB, err := createBadgerDB(ctx, cfg.AddressesIndexDBPath, l, true)
+	if err != nil {
+		return nil, err
+	}
+	journalDB, err := createBadgerDB(ctx, cfg.JournalDBPath, l, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tipSelector, err := NewTipSelector(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	if cfg.Truncate < truncateDiff*2 {
 		cfg.Truncate = truncateVrxTopMark
@@ -118,39 +155,78 @@ func NewAccountingBook(
 
 	ab := &AccountingBook{
 		truncateSignal:     make(chan uint64, initialThroughput),
-		repeater:           repeater,
+		orphans:            newOrphanPool(cfg.OrphanPoolSize, time.Duration(cfg.OrphanLongevity)*time.Second, tele),
+		reattached:         make(chan *Vertex, reattachedCapacity),
 		verifier:           verifier,
 		signer:             signer,
 		dag:                dag.NewDAG(),
 		trustedNodesDB:     trustedNodesDB,
 		trxsToVertxDB:      trxsToVertxDB,
 		verticesDB:         verticesDB,
+		addressesIndexDB:   addressesIndexDB,
+		journalDB:          journalDB,
+		ledger:             newBalanceLedger(),
+		tipSelector:        tipSelector,
+		approvals:          newApprovals(cfg.ConfirmedApprovers, cfg.FinalApprovers),
+		conflicts:          make(map[string]*conflictSet),
+		spenders:           make(spenders),
 		mux:                sync.RWMutex{},
 		log:                l,
 		weight:             atomic.Uint64{},
 		throughput:         atomic.Uint64{},
 		lastBackup:         uint64(0),
+		backupDir:          cfg.BackupDir,
+		backupRetention:    cfg.BackupRetentionCount,
+		backupRetentionAge: time.Duration(cfg.BackupRetentionAge) * time.Second,
+		backupMergeEvery:   cfg.BackupMergeEvery,
+		tele:               tele,
 		nextWeightTruncate: cfg.Truncate,
+		truncateEvery:      cfg.Truncate,
+		checkBalance:       cfg.BalanceConsistencyCheck,
+		fees:               cfg.Fee,
+		nonceLegacy:        cfg.NonceLegacy,
+	}
+	if cfg.NonceActivation > 0 {
+		ab.nonceActivation = time.Unix(cfg.NonceActivation, 0)
+	}
+	if ab.nonceLegacy {
+		l.Warn("Nonce is not required, transactions without a nonce can be replayed. Turn off the nonce legacy mode once the network nodes issue transactions with a nonce.")
 	}
 
-	go ab.runLeafSubscriber(ctx)
-	go ab.runTruncate(ctx)
+	if err := ab.forEachfundFromStorage(ab.ledger.set); err != nil {
+		return nil, err
+	}
+	if err := ab.readFundsLocksFromStorage("", &ab.ledger.funds.locks); err != nil {
+		return nil, err
+	}
+	if err := ab.forEachNonceFromStorage(ab.ledger.setNonce); err != nil {
+		return nil, err
+	}
+	if err := ab.backfillAddressesIndex(ctx); err != nil {
+		return nil, err
+	}
 
-	return ab, nil
-}
+	if err := ab.replayJournal(ctx); err != nil {
+		return nil, err
+	}
 
-func (ab *AccountingBook) runLeafSubscriber(ctx context.Context) {
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case v := <-ab.repeater.subscribe():
-			if v.vrx == nil {
-				continue
-			}
-			ab.addLeafMemorized(ctx, v)
-		}
+	ab.auditSequence, err = ab.readLastAuditSequence()
+	if err != nil {
+		return nil, err
 	}
+
+	tele.CreateUpdateObservableHistogram(backupDurationTelemetryHistogram, "Truncate backup duration in [ ms ].")
+	tele.CreateUpdateObservableHistogram(backupSizeTelemetryHistogram, "Truncate backup size in [ bytes ].")
+	tele.CreateUpdateObservableHistogram(conflictResolutionTelemetryHistogram, "Double spending conflict resolution duration in [ ms ].")
+	tele.CreateUpdateObservableHistogram(conflictRemovedVerticesTelemetryHistogram, "Vertices removed from the DAG when resolving double spending conflict.")
+
+	tele.CreateUpdateObservableGauge(orphanPoolSizeTelemetryGauge, "Vertices waiting in the orphan pool for missing parents.")
+	tele.CreateUpdateObservableGauge(orphanPoolMissingParentsTelemetryGauge, "Missing parents the orphan pool vertices wait for.")
+
+	go ab.orphans.run(ctx)
+	go ab.runTruncate(ctx)
+
+	return ab, nil
 }
 
 func (ab *AccountingBook) runTruncate(ctx context.Context) {
@@ -196,39 +272,44 @@ func (ab *AccountingBook) truncate(ctx context.Context) error {
 
 	topVrxHash := h.getHash()
 
-	f, err := os.Create(fmt.Sprintf("%s%v.bak", backupName, ab.lastBackup))
-	if err != nil {
+	if err := ab.backup(); err != nil {
 		return err
 	}
-	defer f.Close()
-
-	lastBackup, err := ab.verticesDB.Backup(f, ab.lastBackup)
-	if err != nil {
-		return err
-	}
-	ab.lastBackup = lastBackup + 1
 
 	fm := newFoundsMemMap()
 
 	if err := ab.forEachfundFromStorage(fm.set); err != nil {
 		return err
 	}
+	if err := ab.readFundsLocksFromStorage("", &fm.locks); err != nil {
+		return err
+	}
+
+	round, err := ab.nextFundsSnapshotRound()
+	if err != nil {
+		return err
+	}
+	sr := newFundsSnapshotRound(round)
+	if err := ab.journalTruncationRound(round); err != nil {
+		return err
+	}
 
+	// Vertex can be already saved in the storage by the truncation that has not been committed before the node restart.
 	perform := func(v *Vertex) error {
 		if err := fm.nextVertex(v); err != nil {
 			return err
 		}
-		if err := ab.saveVertexToStorage(v); err != nil {
+		if err := ab.saveVertexToStorage(v); err != nil && !errors.Is(err, ErrVertexAlreadyExists) {
 			return err
 		}
-		return nil
+		return ab.snapshotTruncatedVertex(&sr, v)
 	}
 
 	if err := ab.performOnAncestorWalker(ctx, string(topVrxHash[:]), perform); err != nil && !errors.Is(err, ErrBreak) {
 		return err
 	}
 
-	if err := fm.saveToStorage(ab.saveFundsToStorage); err != nil {
+	if err := ab.commitTruncation(&fm, &sr); err != nil {
 		return err
 	}
 
@@ -247,12 +328,37 @@ func (ab *AccountingBook) truncate(ctx context.Context) error {
 		if !ok {
 			break
 		}
+		if item, err := ab.dag.GetVertex(k); err == nil {
+			if vrx, ok := item.(*Vertex); ok && vrx != nil {
+				ab.spenders.remove(vrx)
+			}
+		}
 		ab.dag.DeleteVertex(k)
+		ab.approvals.remove([32]byte([]byte(k)))
+		ab.unjournalVertex([32]byte([]byte(k)))
 	}
+	ab.unjournalTruncationRound()
+	ab.pruneConflicts()
+	ab.ledger.pruneLocks()
 
 	return nil
 }
 
+// commitTruncation saves the funds, locks and nonces calculated from the truncated vertices together with the funds snapshots
+// and the snapshot round in a single transaction, so the truncation round is either committed as a whole or not committed at all.
+func (ab *AccountingBook) commitTruncation(fm *fundsMemMap, sr *fundsSnapshotRound) error {
+	return ab.verticesDB.Update(func(txn *badger.Txn) error {
+		if err := fm.saveToStorage(
+			func(address string, s spice.Melange) error { return saveFunds(txn, address, s) },
+			func(fl *fundsLocks) error { return saveFundsLocks(txn, fl) },
+			func(nonces map[string]uint64) error { return saveNonces(txn, nonces) },
+		); err != nil {
+			return err
+		}
+		return saveFundsSnapshots(txn, sr)
+	})
+}
+
 func (ab *AccountingBook) performOnAncestorWalker(ctx context.Context, topVrxHash string, perform func(vrx *Vertex) error) error {
 	visited := make(map[string]struct{})
 	vertices, signal, err := ab.dag.AncestorsWalker(topVrxHash)
@@ -312,7 +418,7 @@ func (ab *AccountingBook) validateLeaf(ctx context.Context, leaf *Vertex) error
 		)
 	}
 
-	if err := leaf.verify(ab.verifier); err != nil {
+	if err := leaf.Verify(ab.verifier); err != nil {
 		return errors.Join(ErrLeafRejected, err)
 	}
 	isRoot, err := ab.dag.IsRoot(string(leaf.Hash[:]))
@@ -322,11 +428,14 @@ func (ab *AccountingBook) validateLeaf(ctx context.Context, leaf *Vertex) error
 	if isRoot {
 		return nil
 	}
+	if err := ab.fees.validateFee(leaf); err != nil {
+		return errors.Join(ErrLeafRejected, err)
+	}
 	trusted, err := ab.checkIsTrustedNode(leaf.SignerPublicAddress)
 	if err != nil {
 		return errors.Join(ErrUnexpected, err)
 	}
-	if !leaf.Transaction.IsSpiceTransfer() || trusted {
+	if !leaf.spends() || trusted {
 		_, err := ab.dag.GetVertex(string(leaf.RightParentHash[:]))
 		if err != nil {
 			return errors.Join(ErrLeafRejected, err)
@@ -350,8 +459,12 @@ func (ab *AccountingBook) validateLeaf(ctx context.Context, leaf *Vertex) error
 	if err := spiceIn.Supply(s); err != nil {
 		return err
 	}
+	locks := newFundsLocks()
+	if err := ab.readFundsLocksFromStorage(leaf.Transaction.IssuerAddress, &locks); err != nil {
+		return err
+	}
 
-	if err := pourFunds(leaf.Transaction.IssuerAddress, *leaf, &spiceIn, &spiceOut); err != nil {
+	if err := pourFunds(leaf.Transaction.IssuerAddress, *leaf, &spiceIn, &spiceOut, &locks); err != nil {
 		return err
 	}
 
@@ -379,18 +492,18 @@ func (ab *AccountingBook) validateLeaf(ctx context.Context, leaf *Vertex) error
 				return ErrUnexpected
 			}
 			if vrx.Hash == leaf.LeftParentHash {
-				if err := vrx.verify(ab.verifier); err != nil {
+				if err := vrx.Verify(ab.verifier); err != nil {
 					signal <- true
 					return errors.Join(ErrLeafRejected, err)
 				}
 			}
 			if vrx.Hash == leaf.RightParentHash {
-				if err := vrx.verify(ab.verifier); err != nil {
+				if err := vrx.Verify(ab.verifier); err != nil {
 					signal <- true
 					return errors.Join(ErrLeafRejected, err)
 				}
 			}
-			if err := pourFunds(leaf.Transaction.IssuerAddress, *vrx, &spiceIn, &spiceOut); err != nil {
+			if err := pourFunds(leaf.Transaction.IssuerAddress, *vrx, &spiceIn, &spiceOut, &locks); err != nil {
 				return errors.Join(ErrTransferringFoundsFailure, err)
 			}
 
@@ -400,7 +513,8 @@ func (ab *AccountingBook) validateLeaf(ctx context.Context, leaf *Vertex) error
 		}
 	}
 
-	err = checkHasSufficientfunds(&spiceIn, &spiceOut)
+	// Lock time is compared with the vertex creation time, so all the nodes validate the leaf the same way.
+	err = checkHasSufficientfunds(leaf.Transaction.IssuerAddress, &spiceIn, &spiceOut, &locks, leaf.CreatedAt)
 	if err != nil {
 		ab.log.Info(
 			fmt.Sprintf(
@@ -463,47 +577,63 @@ func (ab *AccountingBook) isValidWeight(weight uint64) bool {
 }
 
 func (ab *AccountingBook) getValidLeaves(ctx context.Context) (leftLeaf, rightLeaf *Vertex, err error) {
-	var i int
-	for _, item := range ab.dag.GetLeaves() {
-		if i == 2 {
-			break
-		}
-
+	items := ab.dag.GetLeaves()
+	leaves := make([]*Vertex, 0, len(items))
+	for _, item := range items {
 		switch vrx := item.(type) {
 		case *Vertex:
 			if vrx == nil {
 				err = errors.Join(ErrUnexpected, errors.New("vertex is nil"))
 				return
 			}
-			err = ab.validateLeaf(ctx, vrx)
-			if err != nil {
-				ab.dag.DeleteVertex(string(vrx.Hash[:]))
-				ab.removeTrxInVertex(vrx.Transaction.Hash[:])
-				ab.log.Error(
-					fmt.Sprintf("Accounting book rejected leaf hash [ %v ], from [ %v ], %s",
-						vrx.Hash, vrx.SignerPublicAddress, err),
-				)
-				ab.updateWeightAndThroughput(vrx.Weight)
-				continue
-			}
-			switch i {
-			case 0:
-				leftLeaf = vrx
-			case 1:
-				rightLeaf = vrx
-			}
-			i++
-
+			leaves = append(leaves, vrx)
 		default:
 			err = errors.Join(ErrUnexpected, errors.New("cannot match vertex type"))
 			return
 		}
 	}
+
+	var i int
+	for _, vrx := range ab.tipSelector.SelectTips(ab.dag, leaves, ab.approvals.count) {
+		if i == 2 {
+			break
+		}
+		err = ab.validateLeaf(ctx, vrx)
+		if err != nil {
+			ab.removeRejectedLeaf(vrx)
+			ab.log.Error(
+				fmt.Sprintf("Accounting book rejected leaf hash [ %v ], from [ %v ], %s",
+					vrx.Hash, vrx.SignerPublicAddress, err),
+			)
+			ab.updateWeightAndThroughput(vrx.Weight)
+			continue
+		}
+		switch i {
+		case 0:
+			leftLeaf = vrx
+		case 1:
+			rightLeaf = vrx
+		}
+		i++
+	}
 	return
 }
 
-func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error {
-	leaf := m.vrx
+func (ab *AccountingBook) removeRejectedLeaf(vrx *Vertex) {
+	ab.approvals.disapprove(ab.dag, vrx)
+	ab.spenders.remove(vrx)
+	ab.dag.DeleteVertex(string(vrx.Hash[:]))
+	ab.unjournalVertex(vrx.Hash)
+	ab.removeTrxsInVertex(vrx)
+	if err := ab.removeVertexFromAddressesIndex(vrx); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to remove rejected leaf [ %v ] from addresses index, %s.", vrx.Hash, err))
+	}
+	if err := ab.ledger.revert(vrx); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to revert balance of rejected leaf [ %v ], %s.", vrx.Hash, err))
+	}
+}
+
+func (ab *AccountingBook) addLeaf(ctx context.Context, leaf *Vertex) error {
 	if leaf == nil {
 		return errors.Join(ErrUnexpected, errors.New("leaf is nil"))
 	}
@@ -519,7 +649,7 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 	if ok {
 		return ErrLeafAlreadyExists
 	}
-	ok, err = ab.checkTrxInVertexExists(leaf.Transaction.Hash[:])
+	ok, err = ab.checkTrxsInVertexExist(leaf)
 	if err != nil {
 		ab.log.Error(fmt.Sprintf("Accounting book adding leaf failed when checking if trx to vertex mapping exists, %s", err))
 		return errors.Join(ErrUnexpected, err)
@@ -527,8 +657,11 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 	if ok {
 		return ErrTrxInVertexAlreadyExists
 	}
+	if err := ab.checkStoredNonces(leaf); err != nil {
+		return err
+	}
 
-	if err := leaf.verify(ab.verifier); err != nil {
+	if err := leaf.Verify(ab.verifier); err != nil {
 		ab.log.Error(
 			fmt.Sprintf(
 				"Accounting book rejected leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when verifying, %s.",
@@ -540,25 +673,35 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 	ab.mux.Lock()
 	defer ab.mux.Unlock()
 
+	missing := make([][32]byte, 0, 2)
+	for _, hash := range uniqueParents(leaf) {
+		if _, err := ab.dag.GetVertex(string(hash[:])); err != nil {
+			missing = append(missing, hash)
+		}
+	}
+	if len(missing) > 0 {
+		if err := ab.orphans.add(leaf, missing); err != nil {
+			ab.log.Error(
+				fmt.Sprintf(
+					"Accounting book rejected leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when adding to orphan pool, %s.",
+					leaf.Hash, leaf.SignerPublicAddress, leaf.LeftParentHash, leaf.RightParentHash, err),
+			)
+			return ErrLeafRejected
+		}
+		ab.log.Info(
+			fmt.Sprintf(
+				"Accounting book added leaf [ %v ] from [ %v ] to orphan pool waiting for [ %v ] missing parents.",
+				leaf.Hash, leaf.SignerPublicAddress, len(missing)),
+		)
+		return ErrParentDoesNotExists
+	}
+
 	validatedLeafs := make([]*Vertex, 0, 2)
 
 	for _, hash := range [][32]byte{leaf.LeftParentHash, leaf.RightParentHash} {
 		item, err := ab.dag.GetVertex(string(hash[:]))
 		if err != nil {
-			ab.log.Info(
-				fmt.Sprintf(
-					"Accounting book proceeded with memorizing leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when reading vertex for future validation, %s.",
-					leaf.Hash, leaf.SignerPublicAddress, leaf.LeftParentHash, leaf.RightParentHash, err),
-			)
-			if err := ab.repeater.insert(m); err != nil {
-				ab.log.Error(
-					fmt.Sprintf(
-						"Accounting book rejected leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when reading vertex, %s.",
-						leaf.Hash, leaf.SignerPublicAddress, leaf.LeftParentHash, leaf.RightParentHash, err),
-				)
-				return ErrLeafRejected
-			}
-			return ErrParentDoesNotExists
+			return errors.Join(ErrUnexpected, err)
 		}
 		existingLeaf, ok := item.(*Vertex)
 		if !ok {
@@ -575,8 +718,7 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 		}
 		if isLeaf {
 			if err := ab.validateLeaf(ctx, existingLeaf); err != nil {
-				ab.dag.DeleteVertex(string(existingLeaf.Hash[:]))
-				ab.removeTrxInVertex(existingLeaf.Transaction.Hash[:])
+				ab.removeRejectedLeaf(existingLeaf)
 				return errors.Join(ErrLeafRejected, err)
 			}
 			ab.updateWeightAndThroughput(existingLeaf.Weight)
@@ -584,11 +726,19 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 		validatedLeafs = append(validatedLeafs, existingLeaf)
 	}
 
-	if err := ab.saveTrxInVertex(leaf.Transaction.Hash[:], leaf.Hash[:]); err != nil {
+	if ab.resolveConflictsMeetingAt(ctx, validatedLeafs...) {
+		for _, parent := range validatedLeafs {
+			if _, err := ab.dag.GetVertex(string(parent.Hash[:])); err != nil {
+				return ErrConflictLost
+			}
+		}
+	}
+
+	if err := ab.saveTrxsInVertex(leaf); err != nil {
 		ab.log.Error(
 			fmt.Sprintf(
-				"Accounting book leaf add failed saving transaction [ %v ] in leaf [ %v ], %s.",
-				leaf.Transaction.Hash[:], leaf.Hash, err,
+				"Accounting book leaf add failed saving transactions [ %v ] in leaf [ %v ], %s.",
+				leaf.TransactionsHashes(), leaf.Hash, err,
 			),
 		)
 		return errors.Join(ErrUnexpected, err)
@@ -596,7 +746,7 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 
 	if err := ab.dag.AddVertexByID(string(leaf.Hash[:]), leaf); err != nil {
 		ab.log.Error(fmt.Sprintf("Accounting book rejected new leaf [ %v ], %s.", leaf.Hash, err))
-		ab.removeTrxInVertex(leaf.Transaction.Hash[:])
+		ab.removeTrxsInVertex(leaf)
 		return ErrLeafRejected
 	}
 
@@ -607,7 +757,7 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 		}
 		if err := ab.dag.AddEdge(string(validVrx.Hash[:]), string(leaf.Hash[:])); err != nil {
 			ab.dag.DeleteVertex(string(leaf.Hash[:]))
-			ab.removeTrxInVertex(leaf.Transaction.Hash[:])
+			ab.removeTrxsInVertex(leaf)
 			ab.log.Error(
 				fmt.Sprintf(
 					"Accounting book rejected leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when adding edge, %s.",
@@ -618,6 +768,17 @@ func (ab *AccountingBook) addLeafMemorized(ctx context.Context, m memory) error
 		addedHash = validVrx.Hash
 	}
 
+	if err := ab.saveVertexInAddressesIndex(leaf); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to index leaf [ %v ] by addresses, %s.", leaf.Hash, err))
+	}
+	if err := ab.ledger.apply(leaf); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to update balance with leaf [ %v ], %s.", leaf.Hash, err))
+	}
+	ab.approvals.approve(ab.dag, leaf)
+	ab.spenders.add(leaf)
+	ab.trackConflict(leaf)
+	ab.journalVertex(leaf)
+
 	ab.truncateSignal <- leaf.Weight
 
 	return nil
@@ -629,17 +790,17 @@ func (ab *AccountingBook) CreateGenesis(subject string, spc spice.Melange, data
 		return Vertex{}, errors.Join(ErrGenesisRejected, errors.New("receiver and issuer cannot be the same wallet"))
 	}
 
-	trx, err := transaction.New(subject, spc, data, receiverPublicAddress, ab.signer)
+	trx, err := transaction.NewWithNonce(subject, spc, data, receiverPublicAddress, ab.ledger.readNonce(ab.signer.Address())+1, ab.signer)
 	if err != nil {
 		return Vertex{}, errors.Join(ErrGenesisRejected, err)
 	}
 
-	vrx, err := NewVertex(trx, [32]byte{}, [32]byte{}, 0, ab.signer)
+	vrx, err := NewVertex(trx, [32]byte{}, [32]byte{}, 0, spice.Melange{}, ab.signer)
 	if err != nil {
 		return Vertex{}, errors.Join(ErrGenesisRejected, err)
 	}
 
-	if err := ab.saveTrxInVertex(trx.Hash[:], vrx.Hash[:]); err != nil {
+	if err := ab.saveTrxsInVertex(&vrx); err != nil {
 		return Vertex{}, errors.Join(ErrGenesisRejected, err)
 	}
 
@@ -650,6 +811,18 @@ func (ab *AccountingBook) CreateGenesis(subject string, spc spice.Melange, data
 		return Vertex{}, err
 	}
 
+	if err := ab.saveVertexInAddressesIndex(&vrx); err != nil {
+		return Vertex{}, errors.Join(ErrGenesisRejected, err)
+	}
+
+	if err := ab.ledger.apply(&vrx); err != nil {
+		return Vertex{}, errors.Join(ErrGenesisRejected, err)
+	}
+	ab.spenders.add(&vrx)
+
+	ab.journalVertex(&vrx)
+	ab.journalGenesisAddress(ab.signer.Address())
+
 	ab.throughput.Store(initialThroughput)
 	ab.updateWeightAndThroughput(initialThroughput)
 
@@ -677,7 +850,7 @@ VertexLoop:
 		if vrx == nil {
 			break VertexLoop
 		}
-		if err := ab.saveTrxInVertex(vrx.Transaction.Hash[:], vrx.Hash[:]); err != nil {
+		if err := ab.saveTrxsInVertex(vrx); err != nil {
 			cancelF(ErrLeafRejected)
 			return
 		}
@@ -686,6 +859,19 @@ VertexLoop:
 			cancelF(err)
 			return
 		}
+
+		if err := ab.saveVertexInAddressesIndex(vrx); err != nil {
+			cancelF(err)
+			return
+		}
+
+		if err := ab.ledger.apply(vrx); err != nil {
+			cancelF(err)
+			return
+		}
+		ab.spenders.add(vrx)
+
+		ab.journalVertex(vrx)
 	}
 
 	var maxWeight uint64
@@ -704,10 +890,11 @@ VertexLoop:
 				}
 				genesisCandidateReceived = true
 			}
-			if vrx.Transaction.IsEmpty() {
-				cancelF(fmt.Errorf("loading DAG process stopped due to transaction being empty, %w", ErrUnexpected))
-				return
-
+			for _, trx := range vrx.Transactions() {
+				if trx.IsEmpty() {
+					cancelF(fmt.Errorf("loading DAG process stopped due to transaction being empty, %w", ErrUnexpected))
+					return
+				}
 			}
 			if vrx.Weight > maxWeight {
 				maxWeight = vrx.Weight
@@ -754,6 +941,9 @@ VertexLoop:
 		}
 	}
 
+	ab.approvals.recount(ab.dag)
+	ab.journalGenesisAddress(ab.genesisPublicAddress)
+
 	ab.dagLoaded = true
 	ab.nextWeightTruncate = ab.nextWeightTruncate + maxWeight
 
@@ -834,57 +1024,98 @@ func (ab *AccountingBook) StreamDAG(ctx context.Context) <-chan *Vertex {
 	return cVrx
 }
 
-// AddTrustedNode adds trusted node public address to the trusted nodes public address repository.
-func (ab *AccountingBook) AddTrustedNode(trustedNodePublicAddress string) error {
-	return ab.trustedNodesDB.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(trustedNodePublicAddress), []byte{})
-	})
-}
+// StreamStoredVertices provides the channel to subscribe to a stream of vertices moved to the storage when DAG has been truncated.
+// The channel is closed when all the stored vertices are streamed or the context is done.
+func (ab *AccountingBook) StreamStoredVertices(ctx context.Context) <-chan *Vertex {
+	cVrx := make(chan *Vertex, 100)
+	go func(cVrx chan<- *Vertex) {
+		defer close(cVrx)
+		if err := forEachVertexInDB(ctx, ab.verticesDB, func(vrx *Vertex) error {
+			select {
+			case <-ctx.Done():
+				return ErrStreamingProcessStopped
+			case cVrx <- vrx:
+				return nil
+			}
+		}); err != nil && !errors.Is(err, ErrStreamingProcessStopped) {
+			ab.log.Error(fmt.Sprintf("Accounting book failed to stream stored vertices, %s.", err))
+		}
+	}(cVrx)
 
-// RemoveTrustedNode removes trusted node public address from trusted nodes public address repository.
-func (ab *AccountingBook) RemoveTrustedNode(trustedNodePublicAddress string) error {
-	return ab.trustedNodesDB.Update(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(trustedNodePublicAddress))
-	})
+	return cVrx
 }
 
 // CreateLeaf creates leaf vertex also known as a tip.
 // All the graph validations before adding the leaf happens in that function,
 // Created leaf will be a subject of validation by another tip.
 func (ab *AccountingBook) CreateLeaf(ctx context.Context, trx *transaction.Transaction) (Vertex, error) {
+	return ab.createLeaf(ctx, []*transaction.Transaction{trx})
+}
+
+// CreateBatchLeaf creates leaf vertex carrying the batch of transactions of the same issuer.
+// The batch cannot exceed MaxBatchSize transactions and the vertex fee sums the fees of all the batch transactions.
+func (ab *AccountingBook) CreateBatchLeaf(ctx context.Context, trxs []*transaction.Transaction) (Vertex, error) {
+	return ab.createLeaf(ctx, trxs)
+}
+
+func (ab *AccountingBook) createLeaf(ctx context.Context, trxs []*transaction.Transaction) (Vertex, error) {
 	if !ab.DagLoaded() {
 		return Vertex{}, ErrDagIsNotLoaded
 	}
-	if trx.IsEmpty() {
-		return Vertex{}, ErrTrxIsEmpty
-	}
-	if trx.IssuerAddress == ab.signer.Address() {
-		return Vertex{}, ErrCannotTransferFoundsViaOwnedNode
+	if len(trxs) == 0 {
+		return Vertex{}, ErrBatchIsEmpty
 	}
-	if trx.IssuerAddress == ab.genesisPublicAddress {
-		return Vertex{}, ErrCannotTransferFoundsFromGenesisWallet
+	if len(trxs) > MaxBatchSize {
+		return Vertex{}, ErrBatchTooLarge
 	}
+	batch := make([]transaction.Transaction, 0, len(trxs))
+	for _, trx := range trxs {
+		if trx == nil || trx.IsEmpty() {
+			return Vertex{}, ErrTrxIsEmpty
+		}
+		if err := trx.CheckNewMessageVersion(); err != nil {
+			return Vertex{}, err
+		}
+		if trx.IssuerAddress == ab.signer.Address() {
+			return Vertex{}, ErrCannotTransferFoundsViaOwnedNode
+		}
+		if trx.IssuerAddress == ab.genesisPublicAddress {
+			return Vertex{}, ErrCannotTransferFoundsFromGenesisWallet
+		}
 
-	ok, err := ab.checkTrxInVertexExists(trx.Hash[:])
-	if err != nil {
-		ab.log.Error(fmt.Sprintf(
-			"Accounting book creating transaction failed when checking trx to vertex mapping, %s", err,
-		),
-		)
-		return Vertex{}, ErrUnexpected
-	}
-	if ok {
-		return Vertex{}, ErrTrxInVertexAlreadyExists
+		ok, err := ab.checkTrxInVertexExists(trx.Hash[:])
+		if err != nil {
+			ab.log.Error(fmt.Sprintf(
+				"Accounting book creating transaction failed when checking trx to vertex mapping, %s", err,
+			),
+			)
+			return Vertex{}, ErrUnexpected
+		}
+		if ok {
+			return Vertex{}, ErrTrxInVertexAlreadyExists
+		}
+		batch = append(batch, *trx)
 	}
 
 	ab.mux.Lock()
 	defer ab.mux.Unlock()
 
+	if err := ab.checkNonces(trxs); err != nil {
+		return Vertex{}, err
+	}
+
 	leftLeaf, rightLeaf, err := ab.getValidLeaves(ctx)
 	if err != nil {
 		return Vertex{}, err
 	}
 
+	if ab.resolveConflictsMeetingAt(ctx, leftLeaf, rightLeaf) {
+		leftLeaf, rightLeaf, err = ab.getValidLeaves(ctx)
+		if err != nil {
+			return Vertex{}, err
+		}
+	}
+
 	if leftLeaf == nil {
 		leftLeaf, rightLeaf, err = ab.getValidLeaves(ctx)
 		if err != nil {
@@ -901,25 +1132,36 @@ func (ab *AccountingBook) CreateLeaf(ctx context.Context, trx *transaction.Trans
 		rightLeaf = leftLeaf
 	}
 
-	tip, err := NewVertex(
-		*trx, leftLeaf.Hash, rightLeaf.Hash,
-		calcNewWeight(leftLeaf.Weight, rightLeaf.Weight), ab.signer,
+	fee, err := ab.fees.batchFee(trxs)
+	if err != nil {
+		return Vertex{}, errors.Join(ErrNewLeafRejected, err)
+	}
+
+	return ab.attachTip(batch, fee, leftLeaf, rightLeaf)
+}
+
+// attachTip creates the tip signed by the node on top of given leaves and adds it to the DAG.
+// It is assumed that the caller holds the lock and the leaves are valid.
+func (ab *AccountingBook) attachTip(batch []transaction.Transaction, fee spice.Melange, leftLeaf, rightLeaf *Vertex) (Vertex, error) {
+	tip, err := NewBatchVertex(
+		batch, leftLeaf.Hash, rightLeaf.Hash,
+		calcNewWeight(leftLeaf.Weight, rightLeaf.Weight), fee, ab.signer,
 	)
 	if err != nil {
 		ab.log.Error(fmt.Sprintf("Accounting book rejected new leaf [ %v ], %s.", tip.Hash, err))
 		return Vertex{}, errors.Join(ErrNewLeafRejected, err)
 	}
-	if err := ab.saveTrxInVertex(trx.Hash[:], tip.Hash[:]); err != nil {
+	if err := ab.saveTrxsInVertex(&tip); err != nil {
 		ab.log.Error(
 			fmt.Sprintf(
-				"Accounting book vertex create failed saving transaction [ %v ] in tip [ %v ], %s.",
-				trx.Hash[:], tip.Hash, err,
+				"Accounting book vertex create failed saving transactions [ %v ] in tip [ %v ], %s.",
+				tip.TransactionsHashes(), tip.Hash, err,
 			),
 		)
 		return Vertex{}, ErrUnexpected
 	}
 	if err := ab.dag.AddVertexByID(string(tip.Hash[:]), &tip); err != nil {
-		ab.removeTrxInVertex(trx.Hash[:])
+		ab.removeTrxsInVertex(&tip)
 		ab.log.Error(fmt.Sprintf("Accounting book rejected new leaf [ %v ], %s.", tip.Hash, err))
 		return Vertex{}, ErrNewLeafRejected
 	}
@@ -931,7 +1173,7 @@ func (ab *AccountingBook) CreateLeaf(ctx context.Context, trx *transaction.Trans
 		}
 		if err := ab.dag.AddEdge(string(vrx.Hash[:]), string(tip.Hash[:])); err != nil {
 			ab.dag.DeleteVertex(string(tip.Hash[:]))
-			ab.removeTrxInVertex(trx.Hash[:])
+			ab.removeTrxsInVertex(&tip)
 			ab.log.Error(
 				fmt.Sprintf(
 					"Accounting book rejected leaf [ %v ] from [ %v ] referring to [ %v ] and [ %v ] when adding an edge, %s,",
@@ -941,6 +1183,16 @@ func (ab *AccountingBook) CreateLeaf(ctx context.Context, trx *transaction.Trans
 		}
 		addedHash = vrx.Hash
 	}
+	if err := ab.saveVertexInAddressesIndex(&tip); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to index tip [ %v ] by addresses, %s.", tip.Hash, err))
+	}
+	if err := ab.ledger.apply(&tip); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to update balance with tip [ %v ], %s.", tip.Hash, err))
+	}
+	ab.approvals.approve(ab.dag, &tip)
+	ab.spenders.add(&tip)
+	ab.trackConflict(&tip)
+	ab.journalVertex(&tip)
 	ab.truncateSignal <- tip.Weight
 	return tip, nil
 }
@@ -957,82 +1209,195 @@ func (ab *AccountingBook) AddLeaf(ctx context.Context, leaf *Vertex) error {
 	if leaf.Transaction.IssuerAddress == leaf.SignerPublicAddress {
 		return ErrCannotTransferFoundsViaOwnedNode
 	}
-	if leaf.Transaction.IsEmpty() {
-		return ErrTrxIsEmpty
+	for _, trx := range leaf.Transactions() {
+		if trx.IsEmpty() {
+			return ErrTrxIsEmpty
+		}
+	}
+	if err := ab.addLeaf(ctx, leaf); err != nil {
+		return err
 	}
-	return ab.addLeafMemorized(ctx, newMemory(leaf))
+	ab.releaseOrphans(ctx, leaf.Hash)
+	return nil
 }
 
-// CalculateBalance traverses the graph starting from the recent accepted Vertex,
-// and calculates the balance for the given address.
-func (ab *AccountingBook) CalculateBalance(ctx context.Context, walletPubAddr string) (Balance, error) {
+// MissingParents returns the channel of hashes of parents that orphan vertices wait for.
+// Received parents shall be fetched from other nodes and added to the AccountingBook with AddLeaf.
+func (ab *AccountingBook) MissingParents() <-chan [32]byte {
+	return ab.orphans.requests
+}
+
+// Reattached returns the channel of vertices created by the node to attach again transactions of its vertices
+// removed when resolving double spending conflict. Received vertices shall be gossiped to other nodes.
+func (ab *AccountingBook) Reattached() <-chan *Vertex {
+	return ab.reattached
+}
+
+// ReadLeaves reads hashes of the current DAG leaves.
+// Leaves summarise the DAG state, so nodes comparing them find the vertices they miss.
+func (ab *AccountingBook) ReadLeaves() [][32]byte {
 	ab.mux.RLock()
 	defer ab.mux.RUnlock()
+	leaves := ab.dag.GetLeaves()
+	hashes := make([][32]byte, 0, len(leaves))
+	for id := range leaves {
+		if len(id) != 32 {
+			continue
+		}
+		hashes = append(hashes, [32]byte([]byte(id)))
+	}
+	return hashes
+}
 
-	var leaf *Vertex
-	var ok bool
-	for _, item := range ab.dag.GetLeaves() {
-		leaf, ok = item.(*Vertex)
-		if !ok {
-			return Balance{}, errors.Join(ErrUnexpected, errors.New("calculate balance, cannot cast item to leaf"))
+// ReadDAGHashes reads hashes of the vertices in the DAG that is not truncated yet, ordered by the vertex weight,
+// so parents precede their children.
+func (ab *AccountingBook) ReadDAGHashes() [][32]byte {
+	ab.mux.RLock()
+	defer ab.mux.RUnlock()
+	vertices := make([]*Vertex, 0, ab.dag.GetOrder())
+	for _, item := range ab.dag.GetVertices() {
+		if vrx, ok := item.(*Vertex); ok && vrx != nil {
+			vertices = append(vertices, vrx)
 		}
 	}
-	if leaf == nil {
-		return Balance{}, errors.Join(ErrUnexpected, errors.New("calculate balance, cannot read leaf"))
+	slices.SortFunc(vertices, func(a, b *Vertex) int {
+		return cmp.Compare(a.Weight, b.Weight)
+	})
+	hashes := make([][32]byte, 0, len(vertices))
+	for _, vrx := range vertices {
+		hashes = append(hashes, vrx.Hash)
 	}
+	return hashes
+}
 
-	item, err := ab.dag.GetVertex(string(leaf.Hash[:]))
+// releaseOrphans adds to the DAG the orphans waiting for the arrived vertex,
+// and transitively the orphans waiting for the released ones.
+func (ab *AccountingBook) releaseOrphans(ctx context.Context, arrived [32]byte) {
+	queue := [][32]byte{arrived}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		for _, orphan := range ab.orphans.release(h) {
+			if err := ab.addLeaf(ctx, orphan); err != nil {
+				ab.log.Info(fmt.Sprintf("Accounting book failed to add released orphan [ %v ], %s.", orphan.Hash, err))
+				continue
+			}
+			queue = append(queue, orphan.Hash)
+		}
+	}
+}
+
+// CalculateBalance reads the balance for the given address from the running balance ledger.
+// The ledger is updated each time the leaf is added to the DAG, so reading is not traversing the graph.
+// When balance consistency check is configured, the balance is as well calculated by traversing the graph
+// and the traversed balance is returned if both differs.
+func (ab *AccountingBook) CalculateBalance(ctx context.Context, walletPubAddr string) (Balance, error) {
+	if !ab.checkBalance {
+		return ab.readBalanceFromLedger(walletPubAddr)
+	}
+
+	ab.mux.RLock()
+	defer ab.mux.RUnlock()
+
+	ledgerBalance, err := ab.readBalanceFromLedger(walletPubAddr)
 	if err != nil {
-		return Balance{}, errors.Join(ErrUnexpected, err)
+		return Balance{}, err
 	}
+	s := ledgerBalance.Spice
 
-	spiceOut := spice.New(0, 0)
-	spiceIn := spice.New(0, 0)
-	switch vrx := item.(type) {
-	case *Vertex:
-		if vrx == nil {
-			return Balance{}, ErrUnexpected
-		}
-		if err := pourFunds(walletPubAddr, *vrx, &spiceIn, &spiceOut); err != nil {
-			return Balance{}, err
-		}
-	default:
-		return Balance{}, ErrUnexpected
+	balance, err := ab.calculateBalanceByWalk(ctx, walletPubAddr)
+	if err != nil {
+		return Balance{}, err
+	}
 
+	if s != balance.Spice {
+		ab.log.Error(
+			fmt.Sprintf(
+				"Accounting book balance ledger is inconsistent for address [ %s ], ledger: [ %s ], traversed: [ %s ].",
+				walletPubAddr, s, balance.Spice,
+			),
+		)
 	}
-	visited := make(map[string]struct{})
-	vertices, signal, err := ab.dag.AncestorsWalker(string(leaf.Hash[:]))
+
+	return balance, nil
+}
+
+func (ab *AccountingBook) readBalanceFromLedger(walletPubAddr string) (Balance, error) {
+	s, err := ab.ledger.read(walletPubAddr)
 	if err != nil {
-		return Balance{}, errors.Join(ErrUnexpected, err)
+		return Balance{}, err
 	}
-	for ancestorID := range vertices {
-		select {
-		case <-ctx.Done():
-			signal <- true
-			return Balance{}, ErrLeafBallanceCalculationProcessStopped
-		default:
-		}
-		if _, ok := visited[ancestorID]; ok {
-			continue
-		}
-		visited[ancestorID] = struct{}{}
+	locked, err := ab.ledger.readLocked(walletPubAddr)
+	if err != nil {
+		return Balance{}, err
+	}
+	balance := NewBalance(walletPubAddr, s)
+	balance.Locked = locked
+	return balance, nil
+}
 
-		item, err := ab.dag.GetVertex(ancestorID)
-		if err != nil {
-			signal <- true
-			return Balance{}, errors.Join(ErrUnexpected, err)
+// calculateBalanceByWalk traverses the graph starting from all the leaves,
+// and calculates the balance for the given address.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) calculateBalanceByWalk(ctx context.Context, walletPubAddr string) (Balance, error) {
+	spiceOut := spice.New(0, 0)
+	spiceIn := spice.New(0, 0)
+	locks := newFundsLocks()
+	if err := ab.readFundsLocksFromStorage(walletPubAddr, &locks); err != nil {
+		return Balance{}, err
+	}
+	visited := make(map[string]struct{})
+
+	for leafID, item := range ab.dag.GetLeaves() {
+		if _, ok := visited[leafID]; ok {
+			continue
 		}
+		visited[leafID] = struct{}{}
 		switch vrx := item.(type) {
 		case *Vertex:
 			if vrx == nil {
 				return Balance{}, ErrUnexpected
 			}
-			if err := pourFunds(walletPubAddr, *vrx, &spiceIn, &spiceOut); err != nil {
+			if err := pourFunds(walletPubAddr, *vrx, &spiceIn, &spiceOut, &locks); err != nil {
 				return Balance{}, err
 			}
 		default:
-			signal <- true
-			return Balance{}, ErrUnexpected
+			return Balance{}, errors.Join(ErrUnexpected, errors.New("calculate balance, cannot cast item to leaf"))
+		}
+
+		vertices, signal, err := ab.dag.AncestorsWalker(leafID)
+		if err != nil {
+			return Balance{}, errors.Join(ErrUnexpected, err)
+		}
+		for ancestorID := range vertices {
+			select {
+			case <-ctx.Done():
+				signal <- true
+				return Balance{}, ErrLeafBallanceCalculationProcessStopped
+			default:
+			}
+			if _, ok := visited[ancestorID]; ok {
+				continue
+			}
+			visited[ancestorID] = struct{}{}
+
+			item, err := ab.dag.GetVertex(ancestorID)
+			if err != nil {
+				signal <- true
+				return Balance{}, errors.Join(ErrUnexpected, err)
+			}
+			switch vrx := item.(type) {
+			case *Vertex:
+				if vrx == nil {
+					return Balance{}, ErrUnexpected
+				}
+				if err := pourFunds(walletPubAddr, *vrx, &spiceIn, &spiceOut, &locks); err != nil {
+					return Balance{}, err
+				}
+			default:
+				signal <- true
+				return Balance{}, ErrUnexpected
+			}
 		}
 	}
 
@@ -1048,14 +1413,76 @@ func (ab *AccountingBook) CalculateBalance(ctx context.Context, walletPubAddr st
 		return Balance{}, errors.Join(ErrBalanceCalculationUnexpectedFailure, err)
 	}
 
-	return NewBalance(walletPubAddr, s), nil
+	return balanceWithLocks(walletPubAddr, s, &locks, time.Now())
+}
+
+// CalculateBalanceAtVertex calculates the balance for the given address as it was when the vertex of given hash was created.
+// All the vertices created before or at the same time as the given vertex are taken into account,
+// including the vertices moved to the storage when DAG has been truncated.
+func (ab *AccountingBook) CalculateBalanceAtVertex(ctx context.Context, walletPubAddr string, vrxHash [32]byte) (Balance, error) {
+	ab.mux.RLock()
+	vrx, err := ab.readVertex(vrxHash[:])
+	ab.mux.RUnlock()
+	if err != nil {
+		return Balance{}, ErrVertexHashNotfound
+	}
+
+	return ab.CalculateBalanceAtTime(ctx, walletPubAddr, vrx.CreatedAt)
+}
+
+// CalculateBalanceAtTime calculates the balance for the given address as it was at the given time.
+// Calculation starts from the latest funds snapshot stored when DAG has been truncated that is valid at the given time,
+// and adds the indexed vertices created before or at the given time that are not in the snapshot.
+func (ab *AccountingBook) CalculateBalanceAtTime(ctx context.Context, walletPubAddr string, at time.Time) (Balance, error) {
+	entries, err := ab.readAddressIndexEntriesUntilFromIndex(walletPubAddr, at)
+	if err != nil {
+		return Balance{}, err
+	}
+
+	ab.mux.RLock()
+	defer ab.mux.RUnlock()
+
+	snapshot, _, err := ab.readFundsSnapshotFromStorage(walletPubAddr, at)
+	if err != nil {
+		ab.log.Error(fmt.Sprintf("reading funds snapshot for address [ %s ] at [ %s ] failed, %s", walletPubAddr, at, err))
+		return Balance{}, errors.Join(ErrUnexpected, err)
+	}
+
+	spiceIn := snapshot.In
+	spiceOut := snapshot.Out
+	locks := snapshot.fundsLocks()
+	for _, entry := range entries {
+		select {
+		case <-ctx.Done():
+			return Balance{}, ErrLeafBallanceCalculationProcessStopped
+		default:
+		}
+		if entry.round != 0 && entry.round <= snapshot.Round {
+			continue
+		}
+		vrx, err := ab.readVertex(entry.hash[:])
+		if err != nil {
+			ab.log.Error(fmt.Sprintf("reading indexed vertex [ %v ] for address [ %s ] failed, %s", entry.hash, walletPubAddr, err))
+			return Balance{}, errors.Join(ErrUnexpected, err)
+		}
+		if err := pourFunds(walletPubAddr, vrx, &spiceIn, &spiceOut, &locks); err != nil {
+			return Balance{}, err
+		}
+	}
+
+	if err := spiceIn.Drain(spiceOut, &spice.Melange{}); err != nil {
+		return Balance{}, errors.Join(ErrBalanceCalculationUnexpectedFailure, err)
+	}
+
+	return balanceWithLocks(walletPubAddr, spiceIn, &locks, at)
 }
 
 // ReadTransactionByHash  reads transactions by hashes from DAG and DB.
-func (ab *AccountingBook) ReadTransactionByHash(ctx context.Context, hash [32]byte) (transaction.Transaction, error) {
+// Returned confirmation tells how final the transaction is, transactions truncated from the DAG to the storage are final.
+func (ab *AccountingBook) ReadTransactionByHash(ctx context.Context, hash [32]byte) (transaction.Transaction, Confirmation, error) {
 	vertexHash, err := ab.readVertexHashContainingTrxHashFromStorage(hash)
 	if err != nil {
-		return transaction.Transaction{}, err
+		return transaction.Transaction{}, Confirmation{}, err
 	}
 
 	ab.mux.RLock()
@@ -1067,11 +1494,15 @@ func (ab *AccountingBook) ReadTransactionByHash(ctx context.Context, hash [32]by
 		switch vrx := item.(type) {
 		case *Vertex:
 			if vrx == nil {
-				return transaction.Transaction{}, ErrUnexpected
+				return transaction.Transaction{}, Confirmation{}, ErrUnexpected
+			}
+			trx, ok := vrx.transaction(hash)
+			if !ok {
+				return transaction.Transaction{}, Confirmation{}, ErrUnexpected
 			}
-			return vrx.Transaction, nil // success
+			return trx, ab.approvals.confirmation(vrx.Hash), nil // success
 		default:
-			return transaction.Transaction{}, ErrUnexpected
+			return transaction.Transaction{}, Confirmation{}, ErrUnexpected
 		}
 	default:
 		if !errors.Is(err, dag.IDUnknownError{}) {
@@ -1079,7 +1510,11 @@ func (ab *AccountingBook) ReadTransactionByHash(ctx context.Context, hash [32]by
 		}
 	}
 
-	return ab.readTransactionFromStorage(vertexHash) // success
+	trx, err := ab.readTransactionFromStorage(vertexHash, hash)
+	if err != nil {
+		return transaction.Transaction{}, Confirmation{}, err
+	}
+	return trx, ab.approvals.finalConfirmation(), nil // success
 }
 
 // ReadDAGTransactionsByAddress reads all the transactions from DAG only, that given address appears in as issuer or receiver.
@@ -1111,9 +1546,7 @@ func (ab *AccountingBook) ReadDAGTransactionsByAddress(ctx context.Context, addr
 		if vrx == nil {
 			return nil, ErrUnexpected
 		}
-		if vrx.Transaction.ReceiverAddress == address || vrx.Transaction.IssuerAddress == address {
-			transactions = append(transactions, vrx.Transaction)
-		}
+		transactions = append(transactions, vrx.addressTransactions(address)...)
 
 	default:
 		return nil, ErrUnexpected
@@ -1146,9 +1579,7 @@ func (ab *AccountingBook) ReadDAGTransactionsByAddress(ctx context.Context, addr
 			if vrx == nil {
 				return nil, ErrUnexpected
 			}
-			if vrx.Transaction.ReceiverAddress == address || vrx.Transaction.IssuerAddress == address {
-				transactions = append(transactions, vrx.Transaction)
-			}
+			transactions = append(transactions, vrx.addressTransactions(address)...)
 
 		default:
 			signal <- true
@@ -1159,6 +1590,56 @@ func (ab *AccountingBook) ReadDAGTransactionsByAddress(ctx context.Context, addr
 	return transactions, nil
 }
 
+// ReadTransactionsByAddress reads transactions that given address appears in as issuer or receiver,
+// ordered by the creation time of the vertex holding the transaction.
+// Transactions are read from the DAG and from the vertices storage, so it reads as well transactions after DAG has been truncated.
+// Offset is a number of transactions to skip and limit is a maximum number of transactions to read,
+// transactions of the vertex carrying a batch are counted one by one, so the batch may be split between the pages.
+func (ab *AccountingBook) ReadTransactionsByAddress(ctx context.Context, address string, offset, limit int) ([]transaction.Transaction, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, ErrWrongPaginationParameters
+	}
+
+	ab.mux.RLock()
+	defer ab.mux.RUnlock()
+
+	transactions := make([]transaction.Transaction, 0, min(limit, prefetch))
+	var skipped, vrxOffset int
+	for {
+		hashes, err := ab.readVerticesHashesForAddressFromIndex(address, vrxOffset, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			return transactions, nil
+		}
+		vrxOffset += len(hashes)
+
+		for _, h := range hashes {
+			select {
+			case <-ctx.Done():
+				return nil, ErrLeafBallanceCalculationProcessStopped
+			default:
+			}
+			vrx, err := ab.readVertex(h[:])
+			if err != nil {
+				ab.log.Error(fmt.Sprintf("reading indexed vertex [ %v ] for address [ %s ] failed, %s", h, address, err))
+				return nil, errors.Join(ErrUnexpected, err)
+			}
+			for _, trx := range vrx.addressTransactions(address) {
+				if skipped < offset {
+					skipped++
+					continue
+				}
+				transactions = append(transactions, trx)
+				if len(transactions) == limit {
+					return transactions, nil
+				}
+			}
+		}
+	}
+}
+
 // Address returns signer public address that is a core cryptographic padlock for the DAG Vertices.
 func (ab *AccountingBook) Address() string {
 	return ab.signer.Address()
@@ -8,21 +8,64 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/bartossh/Computantis/src/inclusion"
 	"github.com/bartossh/Computantis/src/logging"
 	"github.com/bartossh/Computantis/src/spice"
 	"github.com/bartossh/Computantis/src/stdoutwriter"
 	"github.com/bartossh/Computantis/src/transaction"
 	"github.com/bartossh/Computantis/src/wallet"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/heimdalr/dag"
 	msgpackv2 "github.com/shamaton/msgpack/v2"
 	"github.com/vmihailenco/msgpack"
 	"gotest.tools/v3/assert"
 )
 
+type telemetryMock struct{}
+
+func (t *telemetryMock) CreateUpdateObservableHistogram(name, description string) {}
+
+func (t *telemetryMock) RecordHistogramTime(name string, d time.Duration) bool {
+	return true
+}
+
+func (t *telemetryMock) RecordHistogramValue(name string, f float64) bool {
+	return true
+}
+
+func (t *telemetryMock) CreateUpdateObservableGauge(name, description string) {}
+
+func (t *telemetryMock) AddToGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *telemetryMock) RemoveFromGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *telemetryMock) IncrementGauge(name string) bool {
+	return true
+}
+
+func (t *telemetryMock) DecrementGauge(name string) bool {
+	return true
+}
+
+func (t *telemetryMock) SetGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *telemetryMock) SetToCurrentTimeGauge(name string) bool {
+	return true
+}
+
 type EmptyLogger struct{}
 
 func (l EmptyLogger) Write(p []byte) (n int, err error) {
@@ -186,7 +229,7 @@ func TestDagStart(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	_, err = NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	_, err = NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 	time.Sleep(time.Millisecond * 200)
 	cancel()
@@ -198,7 +241,7 @@ func TestNewVertex(t *testing.T) {
 	assert.NilError(t, err)
 	trx, err := transaction.New("Vertex Test", spice.New(10, 10), []byte{}, signer.Address(), &signer)
 	assert.NilError(t, err)
-	_, err = NewVertex(trx, [32]byte{}, [32]byte{}, 0, &signer)
+	_, err = NewVertex(trx, [32]byte{}, [32]byte{}, 0, spice.Melange{}, &signer)
 	assert.NilError(t, err)
 }
 
@@ -216,7 +259,7 @@ func TestCreateGensis(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 1000000000000000000)
@@ -244,7 +287,7 @@ func TestSingleIssuerSingleReceiverSpiceTransferConsecutive(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -291,7 +334,7 @@ func TestSingleIssuerSingleReceiverSpiceTransferConcurrent(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -345,7 +388,7 @@ func TestMultipleIssuerMultipleReceiversSpiceTransferConcurrentLegitimate(t *tes
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -408,7 +451,7 @@ func TestMultipleIssuerMultipleReceiversSpiceTransferConcurrentDoubleSpending(t
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -504,7 +547,7 @@ func TestMultipleIssuerMultipleReceiversMultipleAccountantSpiceTransferLegitimat
 		verifier := wallet.NewVerifier()
 		signer, err := wallet.New()
 		assert.NilError(t, err)
-		ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+		ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 		assert.NilError(t, err)
 
 		switch i {
@@ -589,7 +632,7 @@ func TestMultipleIssuerMultipleReceiversMultipleAccountantSpiceLoadDAG(t *testin
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -626,7 +669,7 @@ func TestMultipleIssuerMultipleReceiversMultipleAccountantSpiceLoadDAG(t *testin
 	balanceGenessis, err := ab.CalculateBalance(ctx, receiver.Address())
 	assert.NilError(t, err)
 	// Load Dag test.
-	abLoad, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	abLoad, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	ctxx, cancelF := context.WithCancelCause(ctx)
@@ -640,6 +683,1933 @@ func TestMultipleIssuerMultipleReceiversMultipleAccountantSpiceLoadDAG(t *testin
 	assert.Equal(t, int64(balanceGenessis.Spice.Currency), int64(balanceLoadedDag.Spice.Currency))
 }
 
+func TestReadTransactionsByAddressPaginated(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(math.MaxUint64-1, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfLeaves := 25
+	leaves := make([]Vertex, 0, numberOfLeaves)
+	hashes := make([][32]byte, 0, numberOfLeaves+3)
+	for i := 0; i < numberOfLeaves; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+		hashes = append(hashes, trx.Hash)
+	}
+	batch := make([]*transaction.Transaction, 0, 3)
+	for i := 0; i < 3; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Batched supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		batch = append(batch, &trx)
+		hashes = append(hashes, trx.Hash)
+	}
+	_, err = ab.CreateBatchLeaf(ctx, batch)
+	assert.NilError(t, err)
+	numberOfTransactions := len(hashes)
+
+	_, err = ab.ReadTransactionsByAddress(ctx, receiver.Address(), -1, 10)
+	assert.ErrorIs(t, err, ErrWrongPaginationParameters)
+	_, err = ab.ReadTransactionsByAddress(ctx, receiver.Address(), 0, 0)
+	assert.ErrorIs(t, err, ErrWrongPaginationParameters)
+
+	// Move the oldest vertices to the storage, the way truncate does it.
+	for _, leaf := range leaves[:10] {
+		err := ab.saveVertexToStorage(&leaf)
+		assert.NilError(t, err)
+		err = ab.dag.DeleteVertex(string(leaf.Hash[:]))
+		assert.NilError(t, err)
+	}
+
+	// Offset and limit count transactions, so the last but one page splits the batch.
+	var offset int
+	limit := 9
+	for offset < numberOfTransactions {
+		trxs, err := ab.ReadTransactionsByAddress(ctx, receiver.Address(), offset, limit)
+		assert.NilError(t, err)
+		assert.Equal(t, len(trxs), min(limit, numberOfTransactions-offset))
+		for i, trx := range trxs {
+			assert.Equal(t, trx.Hash, hashes[offset+i])
+		}
+		offset += limit
+	}
+
+	trxs, err := ab.ReadTransactionsByAddress(ctx, genesisReceiver.Address(), 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), numberOfTransactions+1)
+
+	trxs, err = ab.ReadTransactionsByAddress(ctx, receiver.Address(), numberOfTransactions, limit)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), 0)
+}
+
+func TestAddressesIndexBackfill(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 5
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		err = ab.saveVertexToStorage(&leaf)
+		assert.NilError(t, err)
+		err = ab.dag.DeleteVertex(string(leaf.Hash[:]))
+		assert.NilError(t, err)
+	}
+
+	// Drop the index, the way the ledger stored before the addresses index existed looks like.
+	err = ab.addressesIndexDB.DropAll()
+	assert.NilError(t, err)
+	trxs, err := ab.ReadTransactionsByAddress(ctx, receiver.Address(), 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), 0)
+
+	err = ab.backfillAddressesIndex(ctx)
+	assert.NilError(t, err)
+	trxs, err = ab.ReadTransactionsByAddress(ctx, receiver.Address(), 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), numberOfTransactions)
+
+	err = ab.backfillAddressesIndex(ctx)
+	assert.NilError(t, err)
+	trxs, err = ab.ReadTransactionsByAddress(ctx, receiver.Address(), 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), numberOfTransactions)
+}
+
+func TestCalculateBalanceLedgerConsistency(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{BalanceConsistencyCheck: true, NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisSpice := spice.New(1000, 0)
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", genesisSpice, []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 20
+	var last Vertex
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		last, err = ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+	}
+
+	for _, c := range []struct {
+		address  string
+		expected spice.Melange
+	}{
+		{address: genesisReceiver.Address(), expected: spice.New(800, 0)},
+		{address: receiver.Address(), expected: spice.New(200, 0)},
+	} {
+		s, err := ab.ledger.read(c.address)
+		assert.NilError(t, err)
+		assert.Equal(t, s, c.expected)
+
+		ab.mux.RLock()
+		walked, err := ab.calculateBalanceByWalk(ctx, c.address)
+		ab.mux.RUnlock()
+		assert.NilError(t, err)
+		assert.Equal(t, walked.Spice, c.expected)
+
+		balance, err := ab.CalculateBalance(ctx, c.address)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, c.expected)
+	}
+
+	ab.mux.Lock()
+	ab.removeRejectedLeaf(&last)
+	ab.mux.Unlock()
+
+	s, err := ab.ledger.read(receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, s, spice.New(190, 0))
+	s, err = ab.ledger.read(genesisReceiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, s, spice.New(810, 0))
+}
+
+func TestCalculateHistoricalBalance(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 10
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	// Move the oldest vertices to the storage, the way truncate does it.
+	for _, leaf := range leaves[:5] {
+		err := ab.saveVertexToStorage(&leaf)
+		assert.NilError(t, err)
+		err = ab.dag.DeleteVertex(string(leaf.Hash[:]))
+		assert.NilError(t, err)
+	}
+
+	balance, err := ab.CalculateBalanceAtVertex(ctx, genesisReceiver.Address(), genesis.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(1000, 0))
+
+	for i, leaf := range leaves {
+		balance, err := ab.CalculateBalanceAtVertex(ctx, receiver.Address(), leaf.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(uint64(i+1)*10, 0))
+
+		balance, err = ab.CalculateBalanceAtTime(ctx, genesisReceiver.Address(), leaf.CreatedAt)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(1000-uint64(i+1)*10, 0))
+	}
+
+	balance, err = ab.CalculateBalanceAtTime(ctx, receiver.Address(), genesis.CreatedAt.Add(-time.Second))
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(0, 0))
+
+	_, err = ab.CalculateBalanceAtVertex(ctx, receiver.Address(), [32]byte{})
+	assert.ErrorIs(t, err, ErrVertexHashNotfound)
+}
+
+func TestCalculateHistoricalBalanceFromSnapshot(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 10
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	// Truncate the oldest vertices in two rounds, the way truncate does it.
+	truncated := append([]Vertex{genesis}, leaves[:5]...)
+	for _, vertices := range [][]Vertex{truncated[:3], truncated[3:]} {
+		round, err := ab.nextFundsSnapshotRound()
+		assert.NilError(t, err)
+		sr := newFundsSnapshotRound(round)
+		for _, vrx := range vertices {
+			err := ab.saveVertexToStorage(&vrx)
+			assert.NilError(t, err)
+			err = ab.snapshotTruncatedVertex(&sr, &vrx)
+			assert.NilError(t, err)
+			err = ab.dag.DeleteVertex(string(vrx.Hash[:]))
+			assert.NilError(t, err)
+		}
+		err = ab.verticesDB.Update(func(txn *badger.Txn) error {
+			return saveFundsSnapshots(txn, &sr)
+		})
+		assert.NilError(t, err)
+	}
+
+	for i, leaf := range leaves {
+		balance, err := ab.CalculateBalanceAtTime(ctx, receiver.Address(), leaf.CreatedAt)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(uint64(i+1)*10, 0))
+
+		balance, err = ab.CalculateBalanceAtTime(ctx, genesisReceiver.Address(), leaf.CreatedAt)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(1000-uint64(i+1)*10, 0))
+	}
+
+	// Drop truncated vertices from the index, the way history stored before the addresses index existed looks like.
+	for _, vrx := range truncated {
+		err := ab.removeVertexFromAddressesIndex(&vrx)
+		assert.NilError(t, err)
+	}
+
+	for i, leaf := range leaves[4:] {
+		balance, err := ab.CalculateBalanceAtTime(ctx, receiver.Address(), leaf.CreatedAt)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(uint64(i+5)*10, 0))
+
+		balance, err = ab.CalculateBalanceAtTime(ctx, genesisReceiver.Address(), leaf.CreatedAt)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(1000-uint64(i+5)*10, 0))
+	}
+
+	balance, err := ab.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(uint64(numberOfTransactions)*10, 0))
+}
+
+func TestRestoreFromBackups(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 10
+	leaves := []Vertex{genesis}
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	dir := t.TempDir()
+	var since uint64
+	for i, chunk := range [][]Vertex{leaves[:5], leaves[5:]} {
+		for _, leaf := range chunk {
+			err := ab.saveVertexToStorage(&leaf)
+			assert.NilError(t, err)
+		}
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("%s%v%s", backupName, i, backupExtension)))
+		assert.NilError(t, err)
+		since, err = ab.verticesDB.Backup(f, since)
+		assert.NilError(t, err)
+		assert.NilError(t, f.Close())
+	}
+
+	backups, err := ListBackups(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), 2)
+
+	restored, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	err = restored.RestoreFromBackups(ctx, backups)
+	assert.NilError(t, err)
+
+	for _, leaf := range leaves {
+		vrx, err := restored.readTrxVertex(leaf.Transaction.Hash[:])
+		assert.NilError(t, err)
+		assert.Equal(t, vrx.Hash, leaf.Hash)
+	}
+
+	s, err := restored.readAddressFundsFromStorage(receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, s, spice.New(100, 0))
+	s, err = restored.readAddressFundsFromStorage(genesisReceiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, s, spice.New(900, 0))
+
+	balance, err := restored.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(100, 0))
+
+	trxs, err := restored.ReadTransactionsByAddress(ctx, receiver.Address(), 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), numberOfTransactions)
+
+	err = ab.RestoreFromBackups(ctx, backups)
+	assert.ErrorIs(t, err, ErrRestoreOnLoadedDAG)
+
+	forged := leaves[len(leaves)-1]
+	forged.Hash = [32]byte{1}
+	err = ab.saveVertexToStorage(&forged)
+	assert.NilError(t, err)
+	f, err := os.Create(filepath.Join(dir, fmt.Sprintf("%s%v%s", backupName, 2, backupExtension)))
+	assert.NilError(t, err)
+	_, err = ab.verticesDB.Backup(f, since)
+	assert.NilError(t, err)
+	assert.NilError(t, f.Close())
+
+	backups, err = ListBackups(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), 3)
+
+	rejected, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	err = rejected.RestoreFromBackups(ctx, backups)
+	assert.ErrorIs(t, err, ErrBackupVertexRejected)
+	_, err = rejected.readVertexFromStorage(genesis.Hash[:])
+	assert.ErrorIs(t, err, ErrVertexHashNotfound)
+}
+
+func TestBackupMergeAndRetention(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	dir := t.TempDir()
+	cfg := Config{BackupDir: dir, BackupMergeEvery: 2, BackupRetentionCount: 2, NonceLegacy: true}
+	ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	err = ab.saveVertexToStorage(&genesis)
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	leaves := []Vertex{genesis}
+	for i, expected := range []struct {
+		full, incremental int
+	}{
+		{full: 1, incremental: 0},
+		{full: 1, incremental: 1},
+		{full: 1, incremental: 2},
+		{full: 2, incremental: 0},
+		{full: 2, incremental: 1},
+		{full: 2, incremental: 2},
+		{full: 2, incremental: 0},
+	} {
+		err := ab.backup()
+		assert.NilError(t, err)
+
+		files, err := readBackupFiles(dir)
+		assert.NilError(t, err)
+		var full, incremental int
+		for _, f := range files {
+			if f.full {
+				full++
+				continue
+			}
+			incremental++
+		}
+		assert.Equal(t, full, expected.full)
+		assert.Equal(t, incremental, expected.incremental)
+
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		err = ab.saveVertexToStorage(&leaf)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	err = ab.backup()
+	assert.NilError(t, err)
+
+	backups, err := ListBackups(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), 2)
+
+	restored, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	err = restored.RestoreFromBackups(ctx, backups)
+	assert.NilError(t, err)
+	for _, leaf := range leaves {
+		_, err := restored.readVertexFromStorage(leaf.Hash[:])
+		assert.NilError(t, err)
+	}
+}
+
+func TestReadInclusionProof(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 20
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	dagLeaves := ab.dag.GetLeaves()
+	for _, leaf := range leaves {
+		proof, err := ab.ReadInclusionProof(ctx, leaf.Transaction.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, proof.Steps[0].Hash, leaf.Hash)
+		assert.NilError(t, inclusion.Verify(proof, verifier))
+		top := proof.Leaf()
+		_, ok := dagLeaves[string(top[:])]
+		assert.Equal(t, ok, true)
+	}
+
+	_, err = ab.ReadInclusionProof(ctx, [32]byte{})
+	assert.ErrorIs(t, err, ErrTrxToVertexNotfound)
+
+	err = ab.saveVertexToStorage(&leaves[0])
+	assert.NilError(t, err)
+	err = ab.dag.DeleteVertex(string(leaves[0].Hash[:]))
+	assert.NilError(t, err)
+	_, err = ab.ReadInclusionProof(ctx, leaves[0].Transaction.Hash)
+	assert.ErrorIs(t, err, ErrInclusionProofUnavailable)
+}
+
+func TestConfirmationConfidence(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	cfg := Config{ConfirmedApprovers: 2, FinalApprovers: 4, NonceLegacy: true}
+	ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 6
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	expected := []Confirmation{
+		{Level: ConfidenceFinal, Approvers: 4},
+		{Level: ConfidenceFinal, Approvers: 4},
+		{Level: ConfidenceConfirmed, Approvers: 3},
+		{Level: ConfidenceConfirmed, Approvers: 2},
+		{Level: ConfidencePending, Approvers: 1},
+		{Level: ConfidencePending, Approvers: 0},
+	}
+	for i, leaf := range leaves {
+		trx, confirmation, err := ab.ReadTransactionByHash(ctx, leaf.Transaction.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, trx.Hash, leaf.Transaction.Hash)
+		assert.Equal(t, confirmation, expected[i])
+	}
+
+	loaded, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 100)
+	for vrx := range ab.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	loaded.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+	for i, leaf := range leaves {
+		_, confirmation, err := loaded.ReadTransactionByHash(ctx, leaf.Transaction.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, confirmation, expected[i])
+	}
+
+	last := leaves[len(leaves)-1]
+	ab.mux.Lock()
+	ab.removeRejectedLeaf(&last)
+	ab.mux.Unlock()
+	assert.Equal(t, ab.approvals.confirmation(leaves[len(leaves)-2].Hash), Confirmation{Level: ConfidencePending, Approvers: 0})
+	assert.Equal(t, ab.approvals.confirmation(leaves[2].Hash), Confirmation{Level: ConfidenceConfirmed, Approvers: 2})
+
+	err = ab.saveVertexToStorage(&leaves[0])
+	assert.NilError(t, err)
+	err = ab.dag.DeleteVertex(string(leaves[0].Hash[:]))
+	assert.NilError(t, err)
+	_, confirmation, err := ab.ReadTransactionByHash(ctx, leaves[0].Transaction.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, confirmation.Level, ConfidenceFinal)
+}
+
+func TestDoubleSpendingConflictResolution(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	wallets := make(map[string]*wallet.Wallet)
+	for _, name := range []string{"genesis", "issuer", "y", "z", "q", "r", "w", "h"} {
+		w, err := wallet.New()
+		assert.NilError(t, err)
+		wallets[name] = &w
+	}
+	transfer := func(ab *AccountingBook, from, to string, amount uint64) Vertex {
+		trx, err := transaction.New("transfer", spice.New(amount, 0), []byte{}, wallets[to].Address(), wallets[from])
+		assert.NilError(t, err)
+		vrx, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		return vrx
+	}
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, wallets["genesis"].Address())
+	assert.NilError(t, err)
+	transfer(a, "genesis", "issuer", 100)
+
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 100)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	// Issuer spends the same funds on both nodes. Branch on node A gets more approvers,
+	// but it is heavier, as node A attached another vertex before, so branch on node B wins on both nodes.
+	vW := transfer(a, "genesis", "w", 1)
+	vA := transfer(a, "issuer", "y", 80)
+	vA2 := transfer(a, "y", "q", 10)
+	vA3 := transfer(a, "y", "q", 10)
+	vA4 := transfer(a, "y", "q", 10)
+	// Honest descendant of the losing branch is attached again by the node that signed it.
+	vH := transfer(a, "genesis", "h", 5)
+	vB := transfer(b, "issuer", "z", 80)
+	vB2 := transfer(b, "z", "r", 10)
+	assert.Assert(t, vA.Weight > vB.Weight)
+
+	for _, vrx := range []Vertex{vB, vB2} {
+		vrx := vrx
+		assert.NilError(t, a.AddLeaf(ctx, &vrx))
+	}
+	for _, vrx := range []Vertex{vW, vA, vA2, vA3, vA4, vH} {
+		vrx := vrx
+		assert.NilError(t, b.AddLeaf(ctx, &vrx))
+	}
+	assert.Equal(t, len(a.conflicts), 1)
+	assert.Equal(t, len(b.conflicts), 1)
+
+	transfer(a, "genesis", "w", 1)
+	assert.Equal(t, len(a.conflicts), 0)
+	transfer(b, "genesis", "w", 1)
+	assert.Equal(t, len(b.conflicts), 0)
+
+	select {
+	case reattached := <-a.Reattached():
+		assert.Equal(t, reattached.Transaction.Hash, vH.Transaction.Hash)
+		assert.Equal(t, reattached.SignerPublicAddress, signerA.Address())
+		_, err := a.dag.GetVertex(string(reattached.Hash[:]))
+		assert.NilError(t, err)
+		assert.NilError(t, b.AddLeaf(ctx, reattached))
+	default:
+		t.Fatal("expected reattached vertex")
+	}
+
+	for _, ab := range []*AccountingBook{a, b} {
+		for _, vrx := range []Vertex{vA, vA2, vA3, vA4, vH} {
+			_, err := ab.dag.GetVertex(string(vrx.Hash[:]))
+			assert.ErrorType(t, err, dag.IDUnknownError{})
+		}
+		for _, vrx := range []Vertex{vB, vB2} {
+			_, err := ab.dag.GetVertex(string(vrx.Hash[:]))
+			assert.NilError(t, err)
+		}
+	}
+	for _, vrx := range []Vertex{vA, vA2, vA3, vA4} {
+		_, _, err = a.ReadTransactionByHash(ctx, vrx.Transaction.Hash)
+		assert.Assert(t, err != nil)
+	}
+
+	trx, confirmation, err := a.ReadTransactionByHash(ctx, vH.Transaction.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, trx.Hash, vH.Transaction.Hash)
+	assert.Equal(t, confirmation.Level, ConfidencePending)
+
+	for name, expected := range map[string]spice.Melange{
+		"issuer": spice.New(20, 0),
+		"y":      spice.New(0, 0),
+		"q":      spice.New(0, 0),
+		"z":      spice.New(70, 0),
+		"r":      spice.New(10, 0),
+		"w":      spice.New(2, 0),
+		"h":      spice.New(5, 0),
+	} {
+		balance, err := a.CalculateBalance(ctx, wallets[name].Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, expected, name)
+	}
+}
+
+func TestJournalReplay(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	cfg := Config{
+		TrustedNodesDBPath:      t.TempDir(),
+		TrxsToVerticesMapDBPath: t.TempDir(),
+		VerticesDBPath:          t.TempDir(),
+		AddressesIndexDBPath:    t.TempDir(),
+		JournalDBPath:           t.TempDir(),
+		NonceLegacy:             true,
+	}
+	ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 10
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+	ab.mux.Lock()
+	ab.removeRejectedLeaf(&leaves[numberOfTransactions-1])
+	ab.mux.Unlock()
+	leaves = leaves[:numberOfTransactions-1]
+
+	for _, db := range []*badger.DB{ab.trustedNodesDB, ab.trxsToVertxDB, ab.verticesDB, ab.addressesIndexDB, ab.journalDB} {
+		assert.NilError(t, db.Close())
+	}
+
+	restarted, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	assert.Equal(t, restarted.DagLoaded(), true)
+	assert.Equal(t, restarted.genesisPublicAddress, signer.Address())
+	assert.Equal(t, len(restarted.dag.GetVertices()), len(leaves)+1)
+
+	for _, leaf := range leaves {
+		trx, _, err := restarted.ReadTransactionByHash(ctx, leaf.Transaction.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, trx.Hash, leaf.Transaction.Hash)
+	}
+
+	balance, err := restarted.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(90, 0))
+
+	trx, err := transaction.New("Spice supply after restart", spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = restarted.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	balance, err = restarted.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(100, 0))
+}
+
+func TestDiscardDag(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	cfg := Config{
+		TrustedNodesDBPath:      t.TempDir(),
+		TrxsToVerticesMapDBPath: t.TempDir(),
+		VerticesDBPath:          t.TempDir(),
+		AddressesIndexDBPath:    t.TempDir(),
+		JournalDBPath:           t.TempDir(),
+		NonceLegacy:             true,
+	}
+	ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 5
+	leaves := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+	vertices := make([]*Vertex, 0, numberOfTransactions+1)
+	for vrx := range ab.StreamDAG(ctx) {
+		vertices = append(vertices, vrx)
+	}
+
+	for _, db := range []*badger.DB{ab.trustedNodesDB, ab.trxsToVertxDB, ab.verticesDB, ab.addressesIndexDB, ab.journalDB} {
+		assert.NilError(t, db.Close())
+	}
+
+	restarted, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	assert.Equal(t, restarted.DagLoaded(), true)
+
+	assert.NilError(t, restarted.DiscardDag())
+	assert.Equal(t, restarted.DagLoaded(), false)
+	assert.Equal(t, len(restarted.dag.GetVertices()), 0)
+	var journaled int
+	assert.NilError(t, forEachVertexInDB(ctx, restarted.journalDB, func(_ *Vertex) error {
+		journaled++
+		return nil
+	}))
+	assert.Equal(t, journaled, 0)
+	for _, leaf := range leaves {
+		ok, err := restarted.checkTrxInVertexExists(leaf.Transaction.Hash[:])
+		assert.NilError(t, err)
+		assert.Equal(t, ok, false)
+	}
+	s, err := restarted.ledger.read(receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, s, spice.New(0, 0))
+
+	// Discarded DAG is loaded again from the other node.
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, len(vertices))
+	for _, vrx := range vertices {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	restarted.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+	assert.Equal(t, restarted.DagLoaded(), true)
+
+	balance, err := restarted.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(50, 0))
+}
+
+func TestJournalReplaySkipsCommittedTruncation(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+
+	for _, committed := range []bool{true, false} {
+		cfg := Config{
+			TrustedNodesDBPath:      t.TempDir(),
+			TrxsToVerticesMapDBPath: t.TempDir(),
+			VerticesDBPath:          t.TempDir(),
+			AddressesIndexDBPath:    t.TempDir(),
+			JournalDBPath:           t.TempDir(),
+			NonceLegacy:             true,
+		}
+		ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+		assert.NilError(t, err)
+
+		genesisReceiver, err := wallet.New()
+		assert.NilError(t, err)
+		genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+		assert.NilError(t, err)
+
+		receiver, err := wallet.New()
+		assert.NilError(t, err)
+		numberOfTransactions := 10
+		leaves := make([]Vertex, 0, numberOfTransactions)
+		for i := 0; i < numberOfTransactions; i++ {
+			trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+			assert.NilError(t, err)
+			leaf, err := ab.CreateLeaf(ctx, &trx)
+			assert.NilError(t, err)
+			leaves = append(leaves, leaf)
+		}
+
+		// Node stops after the truncated vertices are saved to the storage, but before they are removed from the journal.
+		ab.mux.Lock()
+		round, err := ab.nextFundsSnapshotRound()
+		assert.NilError(t, err)
+		assert.NilError(t, ab.journalTruncationRound(round))
+		fm := newFoundsMemMap()
+		sr := newFundsSnapshotRound(round)
+		for _, vrx := range append([]Vertex{genesis}, leaves[:5]...) {
+			assert.NilError(t, fm.nextVertex(&vrx))
+			assert.NilError(t, ab.saveVertexToStorage(&vrx))
+			assert.NilError(t, ab.snapshotTruncatedVertex(&sr, &vrx))
+		}
+		if committed {
+			assert.NilError(t, ab.commitTruncation(&fm, &sr))
+		}
+		ab.mux.Unlock()
+
+		for _, db := range []*badger.DB{ab.trustedNodesDB, ab.trxsToVertxDB, ab.verticesDB, ab.addressesIndexDB, ab.journalDB} {
+			assert.NilError(t, db.Close())
+		}
+
+		restarted, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+		assert.NilError(t, err)
+		assert.Equal(t, restarted.DagLoaded(), true)
+		switch committed {
+		case true:
+			assert.Equal(t, len(restarted.dag.GetVertices()), numberOfTransactions-5)
+		default:
+			assert.Equal(t, len(restarted.dag.GetVertices()), numberOfTransactions+1)
+		}
+		_, ok, err := restarted.readJournalTruncationRound()
+		assert.NilError(t, err)
+		assert.Equal(t, ok, false)
+
+		balance, err := restarted.CalculateBalance(ctx, receiver.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(100, 0))
+
+		for _, leaf := range leaves {
+			trx, _, err := restarted.ReadTransactionByHash(ctx, leaf.Transaction.Hash)
+			assert.NilError(t, err)
+			assert.Equal(t, trx.Hash, leaf.Transaction.Hash)
+		}
+	}
+}
+
+func TestTrustedNodesAuditLog(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	cfg := Config{TrustedNodesDBPath: t.TempDir(), NonceLegacy: true}
+	ab, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	admin, err := wallet.New()
+	assert.NilError(t, err)
+	nodeA, err := wallet.New()
+	assert.NilError(t, err)
+	nodeB, err := wallet.New()
+	assert.NilError(t, err)
+
+	assert.NilError(t, ab.AddTrustedNode(admin.Address(), nodeA.Address()))
+	assert.NilError(t, ab.AddTrustedNode(admin.Address(), nodeB.Address()))
+	assert.ErrorIs(t, ab.AddTrustedNode(admin.Address(), nodeA.Address()), ErrTrustedNodeAlreadyExists)
+	assert.NilError(t, ab.RemoveTrustedNode(admin.Address(), nodeA.Address()))
+	assert.ErrorIs(t, ab.RemoveTrustedNode(admin.Address(), nodeA.Address()), ErrTrustedNodeNotFound)
+
+	nodes, err := ab.ReadTrustedNodes()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, nodes, []string{nodeB.Address()})
+	trusted, err := ab.checkIsTrustedNode(nodeB.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, trusted, true)
+
+	assert.NilError(t, ab.trustedNodesDB.Close())
+	restarted, err := NewAccountingBook(ctx, cfg, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	assert.NilError(t, restarted.RemoveTrustedNode(admin.Address(), nodeB.Address()))
+
+	entries, err := restarted.ReadAuditLog(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 4)
+	for i, expected := range []struct {
+		action string
+		node   string
+	}{
+		{AuditActionAddTrustedNode, nodeA.Address()},
+		{AuditActionAddTrustedNode, nodeB.Address()},
+		{AuditActionRemoveTrustedNode, nodeA.Address()},
+		{AuditActionRemoveTrustedNode, nodeB.Address()},
+	} {
+		assert.Equal(t, entries[i].Sequence, uint64(i+1))
+		assert.Equal(t, entries[i].Action, expected.action)
+		assert.Equal(t, entries[i].NodeAddress, expected.node)
+		assert.Equal(t, entries[i].AdminAddress, admin.Address())
+	}
+
+	entries, err = restarted.ReadAuditLog(3, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Sequence, uint64(3))
+
+	nodes, err = restarted.ReadTrustedNodes()
+	assert.NilError(t, err)
+	assert.Equal(t, len(nodes), 0)
+}
+
+func TestMultisigSpiceTransfer(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	members := make([]wallet.Wallet, 0, 3)
+	addresses := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		w, err := wallet.New()
+		assert.NilError(t, err)
+		members = append(members, w)
+		addresses = append(addresses, w.Address())
+	}
+	treasury, err := transaction.MultisigAddress(2, addresses)
+	assert.NilError(t, err)
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	trx, err := transaction.New("Treasury supply", spice.New(100, 0), []byte{}, treasury, &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 10)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	underSigned, err := transaction.NewMultisig("Treasury spend", spice.New(40, 0), []byte{}, receiver.Address(), 2, addresses)
+	assert.NilError(t, err)
+	assert.NilError(t, underSigned.SignMultisig(&members[1]))
+	rejected, err := a.CreateLeaf(ctx, &underSigned)
+	assert.NilError(t, err)
+	err = b.AddLeaf(ctx, &rejected)
+	assert.ErrorIs(t, err, ErrLeafRejected)
+
+	// Under signed leaf is rejected by the node that created it when validated by the next leaf.
+	trx, err = transaction.New("Receiver supply", spice.New(5, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.ErrorIs(t, err, ErrLeafRejected)
+	_, err = a.ReadVertex(ctx, rejected.Hash)
+	assert.Assert(t, err != nil)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	signed, err := transaction.NewMultisig("Treasury spend", spice.New(40, 0), []byte{}, receiver.Address(), 2, addresses)
+	assert.NilError(t, err)
+	assert.NilError(t, signed.SignMultisig(&members[0]))
+	assert.NilError(t, signed.SignMultisig(&members[2]))
+	vrx, err := b.CreateLeaf(ctx, &signed)
+	assert.NilError(t, err)
+	err = a.AddLeaf(ctx, &vrx)
+	assert.NilError(t, err)
+
+	for _, c := range []struct {
+		ab       *AccountingBook
+		received spice.Melange
+	}{
+		{a, spice.New(45, 0)},
+		{b, spice.New(40, 0)},
+	} {
+		balance, err := c.ab.CalculateBalance(ctx, treasury)
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(60, 0))
+		balance, err = c.ab.CalculateBalance(ctx, receiver.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, c.received)
+	}
+}
+
+func TestLockedAndEscrowSpiceTransfer(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	arbiter, err := wallet.New()
+	assert.NilError(t, err)
+	merchant, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 10)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	propagate := func(trx *transaction.Transaction) error {
+		vrx, err := a.CreateLeaf(ctx, trx)
+		if err != nil {
+			return err
+		}
+		return b.AddLeaf(ctx, &vrx)
+	}
+
+	trx, err := transaction.New("Receiver supply", spice.New(5, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&trx))
+	escrow, err := transaction.NewLocked(
+		"Device payment", spice.New(30, 0), []byte{}, receiver.Address(), transaction.Lock{ArbiterAddress: arbiter.Address()}, &genesisReceiver,
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&escrow))
+	unlockAt := time.Now().Add(time.Millisecond * 500)
+	timeLocked, err := transaction.NewLocked(
+		"Vesting", spice.New(20, 0), []byte{}, receiver.Address(), transaction.Lock{UnlockAt: unlockAt}, &genesisReceiver,
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&timeLocked))
+
+	for _, ab := range []*AccountingBook{a, b} {
+		balance, err := ab.CalculateBalance(ctx, receiver.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(5, 0))
+		assert.Equal(t, balance.Locked, spice.New(50, 0))
+	}
+
+	// Leaf spending locked spice is rejected when validated by the next leaf.
+	spend, err := transaction.New("Spend locked", spice.New(10, 0), []byte{}, merchant.Address(), &receiver)
+	assert.NilError(t, err)
+	rejected, err := a.CreateLeaf(ctx, &spend)
+	assert.NilError(t, err)
+	trx, err = transaction.New("Merchant supply", spice.New(1, 0), []byte{}, merchant.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.ErrorIs(t, err, ErrTransferringFoundsFailure)
+	_, err = a.ReadVertex(ctx, rejected.Hash)
+	assert.Assert(t, err != nil)
+	assert.NilError(t, propagate(&trx))
+
+	_, err = transaction.NewEscrowRelease(&escrow, &merchant)
+	assert.ErrorIs(t, err, transaction.ErrArbiterIsInvalid)
+	beforeRelease := time.Now()
+	release, err := transaction.NewEscrowRelease(&escrow, &arbiter)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&release))
+
+	// Release issued by other than the arbiter doesn't override the arbiter release when reverted.
+	forgedEscrow := escrow
+	forgedEscrow.Lock.ArbiterAddress = merchant.Address()
+	forged, err := transaction.NewEscrowRelease(&forgedEscrow, &merchant)
+	assert.NilError(t, err)
+	forgedVrx, err := a.CreateLeaf(ctx, &forged)
+	assert.NilError(t, err)
+	a.mux.Lock()
+	a.removeRejectedLeaf(&forgedVrx)
+	a.mux.Unlock()
+
+	spend, err = transaction.New("Spend released", spice.New(35, 0), []byte{}, merchant.Address(), &receiver)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&spend))
+
+	time.Sleep(time.Until(unlockAt))
+	spend, err = transaction.New("Spend unlocked", spice.New(20, 0), []byte{}, merchant.Address(), &receiver)
+	assert.NilError(t, err)
+	assert.NilError(t, propagate(&spend))
+
+	for _, ab := range []*AccountingBook{a, b} {
+		balance, err := ab.CalculateBalance(ctx, receiver.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(0, 0))
+		assert.Equal(t, balance.Locked, spice.New(0, 0))
+		balance, err = ab.CalculateBalance(ctx, merchant.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(56, 0))
+	}
+
+	balance, err := a.CalculateBalanceAtTime(ctx, receiver.Address(), beforeRelease)
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(5, 0))
+	assert.Equal(t, balance.Locked, spice.New(50, 0))
+}
+
+func TestSpiceTransferWithFee(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	fees := FeeSchedule{Base: spice.New(1, 0), PerKilobyte: spice.New(1, 0)}
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	signerC, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{Fee: fees, NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{Fee: fees, NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	c, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerC, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	issuer, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = c.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	for _, ab := range []*AccountingBook{a, b} {
+		ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+		defer cancelLoad(nil)
+		chVrx := make(chan *Vertex, 10)
+		for vrx := range c.StreamDAG(ctx) {
+			chVrx <- vrx
+		}
+		close(chVrx)
+		ab.LoadDag(cancelLoad, chVrx)
+		assert.NilError(t, context.Cause(ctxLoad))
+	}
+
+	trx, err := transaction.New("Issuer supply", spice.New(12, 0), []byte{}, issuer.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	supply, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.Equal(t, supply.Fee, spice.New(1, 0))
+	assert.NilError(t, b.AddLeaf(ctx, &supply))
+	assert.NilError(t, c.AddLeaf(ctx, &supply))
+
+	trx, err = transaction.New("Spend with data", spice.New(9, 0), make([]byte, 100), receiver.Address(), &issuer)
+	assert.NilError(t, err)
+	spend, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.Equal(t, spend.Fee, spice.New(2, 0))
+	assert.NilError(t, b.AddLeaf(ctx, &spend))
+
+	// Node with lower fee schedule rejects the leaf charging more when validated by the next leaf.
+	err = c.AddLeaf(ctx, &spend)
+	assert.ErrorIs(t, err, ErrLeafRejected)
+	assert.ErrorIs(t, err, ErrFeeExceedsSchedule)
+
+	// Issuer funds shall cover both the transferred spice and the fee.
+	trx, err = transaction.New("Spend without fee", spice.New(1, 0), []byte{}, receiver.Address(), &issuer)
+	assert.NilError(t, err)
+	rejected, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	trx, err = transaction.New("Receiver supply", spice.New(1, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.ErrorIs(t, err, ErrTransferringFoundsFailure)
+	_, err = a.ReadVertex(ctx, rejected.Hash)
+	assert.Assert(t, err != nil)
+	vrx, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.AddLeaf(ctx, &vrx))
+
+	for _, ab := range []*AccountingBook{a, b} {
+		for _, expected := range []struct {
+			address string
+			spice   spice.Melange
+		}{
+			{issuer.Address(), spice.New(1, 0)},
+			{receiver.Address(), spice.New(10, 0)},
+			{signerA.Address(), spice.New(4, 0)},
+			{genesisReceiver.Address(), spice.New(985, 0)},
+		} {
+			balance, err := ab.CalculateBalance(ctx, expected.address)
+			assert.NilError(t, err)
+			assert.Equal(t, balance.Spice, expected.spice)
+		}
+	}
+
+	// Fees collected in the vertices moved to the storage are found through the signer addresses index.
+	for _, leaf := range []Vertex{supply, spend, vrx} {
+		err := a.saveVertexToStorage(&leaf)
+		assert.NilError(t, err)
+		err = a.dag.DeleteVertex(string(leaf.Hash[:]))
+		assert.NilError(t, err)
+	}
+	balance, err := a.CalculateBalanceAtTime(ctx, signerA.Address(), vrx.CreatedAt)
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(4, 0))
+}
+
+func TestBatchVertex(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	issuer, err := wallet.New()
+	assert.NilError(t, err)
+	receiverA, err := wallet.New()
+	assert.NilError(t, err)
+	receiverB, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 10)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	trx, err := transaction.New("Issuer supply", spice.New(50, 0), []byte{}, issuer.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	supply, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.AddLeaf(ctx, &supply))
+
+	batch := make([]*transaction.Transaction, 0, 3)
+	for _, transfer := range []struct {
+		receiver string
+		spice    spice.Melange
+	}{
+		{receiverA.Address(), spice.New(10, 0)},
+		{receiverB.Address(), spice.New(5, 0)},
+		{receiverA.Address(), spice.New(3, 0)},
+	} {
+		trx, err := transaction.New("Batched transfer", transfer.spice, []byte{}, transfer.receiver, &issuer)
+		assert.NilError(t, err)
+		batch = append(batch, &trx)
+	}
+
+	other, err := transaction.New("Other issuer", spice.New(1, 0), []byte{}, receiverA.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateBatchLeaf(ctx, []*transaction.Transaction{batch[0], &other})
+	assert.ErrorIs(t, err, ErrBatchIssuerMismatch)
+	_, err = a.CreateBatchLeaf(ctx, make([]*transaction.Transaction, MaxBatchSize+1))
+	assert.ErrorIs(t, err, ErrBatchTooLarge)
+
+	vrx, err := a.CreateBatchLeaf(ctx, batch)
+	assert.NilError(t, err)
+	assert.Equal(t, len(vrx.Transactions()), 3)
+
+	// Replacing any transaction in the batch breaks the vertex signature over the Merkle root.
+	forged := vrx
+	forged.Batch = append([]transaction.Transaction{}, vrx.Batch...)
+	forged.Batch[1].Spice = spice.New(1, 0)
+	assert.ErrorIs(t, b.AddLeaf(ctx, &forged), ErrLeafRejected)
+
+	assert.NilError(t, b.AddLeaf(ctx, &vrx))
+	_, err = a.CreateLeaf(ctx, batch[1])
+	assert.ErrorIs(t, err, ErrTrxInVertexAlreadyExists)
+
+	trx, err = transaction.New("Next supply", spice.New(1, 0), []byte{}, receiverB.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	next, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.AddLeaf(ctx, &next))
+
+	for _, ab := range []*AccountingBook{a, b} {
+		for _, expected := range []struct {
+			address string
+			spice   spice.Melange
+		}{
+			{issuer.Address(), spice.New(32, 0)},
+			{receiverA.Address(), spice.New(13, 0)},
+			{receiverB.Address(), spice.New(6, 0)},
+			{genesisReceiver.Address(), spice.New(949, 0)},
+		} {
+			balance, err := ab.CalculateBalance(ctx, expected.address)
+			assert.NilError(t, err)
+			assert.Equal(t, balance.Spice, expected.spice)
+		}
+
+		read, _, err := ab.ReadTransactionByHash(ctx, batch[1].Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, read.Hash, batch[1].Hash)
+		assert.Equal(t, read.ReceiverAddress, receiverB.Address())
+
+		trxs, err := ab.ReadTransactionsByAddress(ctx, receiverA.Address(), 0, 10)
+		assert.NilError(t, err)
+		assert.Equal(t, len(trxs), 2)
+
+		proof, err := ab.ReadInclusionProof(ctx, batch[2].Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, len(proof.Batch), 3)
+		assert.NilError(t, inclusion.Verify(proof, verifier))
+	}
+}
+
+func TestTransactionNonce(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	issuer, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 10)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	trx, err := transaction.New("Issuer supply", spice.New(50, 0), []byte{}, issuer.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	// Issuer that never used the nonce can transfer without it.
+	trx, err = transaction.New("Without nonce", spice.New(1, 0), []byte{}, receiver.Address(), &issuer)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	trx, err = transaction.NewWithNonce("Nonce", spice.New(1, 0), []byte{}, receiver.Address(), 2, &issuer)
+	assert.NilError(t, err)
+	_, err = a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	for _, nonce := range []uint64{0, 1, 2} {
+		var trx transaction.Transaction
+		var err error
+		switch nonce {
+		case 0:
+			trx, err = transaction.New("Without nonce", spice.New(1, 0), []byte{}, receiver.Address(), &issuer)
+		default:
+			trx, err = transaction.NewWithNonce("Reused nonce", spice.New(1, 0), []byte{}, receiver.Address(), nonce, &issuer)
+		}
+		assert.NilError(t, err)
+		_, err = a.CreateLeaf(ctx, &trx)
+		assert.ErrorIs(t, err, ErrNonceOutOfOrder)
+	}
+
+	batch := make([]*transaction.Transaction, 0, 2)
+	for _, nonce := range []uint64{5, 4} {
+		trx, err := transaction.NewWithNonce("Batched nonce", spice.New(1, 0), []byte{}, receiver.Address(), nonce, &issuer)
+		assert.NilError(t, err)
+		batch = append(batch, &trx)
+	}
+	_, err = a.CreateBatchLeaf(ctx, batch)
+	assert.ErrorIs(t, err, ErrNonceOutOfOrder)
+	_, err = a.CreateBatchLeaf(ctx, []*transaction.Transaction{batch[1], batch[0]})
+	assert.NilError(t, err)
+
+	// Node rejects the leaf with nonce not greater than the nonce stored when DAG was truncated.
+	trx, err = transaction.NewWithNonce("Nonce", spice.New(1, 0), []byte{}, receiver.Address(), 6, &issuer)
+	assert.NilError(t, err)
+	vrx, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.saveNoncesToStorage(map[string]uint64{issuer.Address(): 6}))
+	assert.ErrorIs(t, b.AddLeaf(ctx, &vrx), ErrNonceOutOfOrder)
+
+	nonce, err := b.readNonceFromStorage(issuer.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, nonce, uint64(6))
+	assert.NilError(t, b.saveNoncesToStorage(map[string]uint64{issuer.Address(): 3}))
+	nonce, err = b.readNonceFromStorage(issuer.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, nonce, uint64(6))
+	assert.Equal(t, a.ledger.readNonce(issuer.Address()), uint64(6))
+}
+
+func TestTransactionNonceActivation(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceActivation: time.Now().Add(-time.Hour).Unix()}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	signerC, err := wallet.New()
+	assert.NilError(t, err)
+	c, err := NewAccountingBook(ctx, Config{}, verifier, &signerC, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, a.NextNonce(signerA.Address()), uint64(2))
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	for _, ab := range []*AccountingBook{b, c} {
+		chVrx := make(chan *Vertex, 10)
+		for vrx := range a.StreamDAG(ctx) {
+			chVrx <- vrx
+		}
+		close(chVrx)
+		ab.LoadDag(cancelLoad, chVrx)
+		assert.NilError(t, context.Cause(ctxLoad))
+	}
+
+	// Transaction without nonce created after the activation is rejected.
+	trx, err := transaction.New("Without nonce", spice.New(1, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = b.CreateLeaf(ctx, &trx)
+	assert.ErrorIs(t, err, ErrNonceRequired)
+	vrx, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.ErrorIs(t, b.AddLeaf(ctx, &vrx), ErrNonceRequired)
+
+	// Transaction without nonce created before the activation is accepted.
+	trx, err = transaction.New("Legacy", spice.New(1, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	trx.CreatedAt = time.Now().Add(-2 * time.Hour)
+	trx.Hash, trx.IssuerSignature = genesisReceiver.Sign(trx.GetMessage())
+	_, err = b.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	// Transaction without nonce is rejected whenever created if the activation is not set.
+	_, err = c.CreateLeaf(ctx, &trx)
+	assert.ErrorIs(t, err, ErrNonceRequired)
+
+	assert.Equal(t, b.NextNonce(genesisReceiver.Address()), uint64(1))
+	trx, err = transaction.NewWithNonce("Nonce", spice.New(1, 0), []byte{}, receiver.Address(), b.NextNonce(genesisReceiver.Address()), &genesisReceiver)
+	assert.NilError(t, err)
+	_, err = b.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.Equal(t, b.NextNonce(genesisReceiver.Address()), uint64(2))
+}
+
+func TestLegacyMessageVersion(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+
+	genesis, err := a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	legacy, err := transaction.New("Legacy", spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	legacy.Version = transaction.MessageVersionLegacy
+	legacy.Hash, legacy.IssuerSignature = genesisReceiver.Sign(legacy.GetMessage())
+	_, err = a.CreateLeaf(ctx, &legacy)
+	assert.ErrorIs(t, err, transaction.ErrLegacyMessageVersion)
+
+	// Vertex of the legacy transaction already in the ledger is loaded and approved by the new vertex.
+	vrx, err := NewVertex(legacy, genesis.Hash, genesis.Hash, calcNewWeight(genesis.Weight, genesis.Weight), spice.Melange{}, &signerA)
+	assert.NilError(t, err)
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 2)
+	chVrx <- &genesis
+	chVrx <- &vrx
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	trx, err := transaction.New("Spend", spice.New(5, 0), []byte{}, genesisReceiver.Address(), &receiver)
+	assert.NilError(t, err)
+	_, err = b.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	balance, err := b.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(5, 0))
+}
+
+func TestReadLeavesAndDAGHashes(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+
+	genesis, err := a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, a.ReadLeaves(), [][32]byte{genesis.Hash})
+
+	trx, err := transaction.New("Leaf", spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	vrx, err := a.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, a.ReadLeaves(), [][32]byte{vrx.Hash})
+	assert.DeepEqual(t, a.ReadDAGHashes(), [][32]byte{genesis.Hash, vrx.Hash})
+}
+
+func TestTipSelectors(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+
+	_, err = NewAccountingBook(ctx, Config{TipSelection: "unknown"}, verifier, &signer, &telemetryMock{}, l)
+	assert.ErrorIs(t, err, ErrUnknownTipSelection)
+
+	for _, selection := range []string{TipSelectionFirst, TipSelectionUniformRandom, TipSelectionOldestFirst, TipSelectionMCMC} {
+		ab, err := NewAccountingBook(ctx, Config{TipSelection: selection, NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
+		assert.NilError(t, err)
+
+		genesisReceiver, err := wallet.New()
+		assert.NilError(t, err)
+		_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+		assert.NilError(t, err)
+
+		receiver, err := wallet.New()
+		assert.NilError(t, err)
+		for i := 0; i < 10; i++ {
+			trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+			assert.NilError(t, err)
+			_, err = ab.CreateLeaf(ctx, &trx)
+			assert.NilError(t, err)
+		}
+		balance, err := ab.CalculateBalance(ctx, receiver.Address())
+		assert.NilError(t, err)
+		assert.Equal(t, balance.Spice, spice.New(100, 0))
+	}
+
+	// root <- a <- (c, d, e), root <- b
+	now := time.Now()
+	g := dag.NewDAG()
+	vertices := make(map[string]*Vertex)
+	for i, name := range []string{"root", "a", "b", "c", "d", "e"} {
+		vrx := &Vertex{Hash: [32]byte{byte(i + 1)}, CreatedAt: now.Add(time.Duration(10-i) * time.Second), Weight: uint64(i)}
+		vertices[name] = vrx
+		assert.NilError(t, g.AddVertexByID(string(vrx.Hash[:]), vrx))
+	}
+	for _, edge := range [][2]string{{"root", "a"}, {"root", "b"}, {"a", "c"}, {"a", "d"}, {"a", "e"}} {
+		parent, child := vertices[edge[0]], vertices[edge[1]]
+		child.LeftParentHash, child.RightParentHash = parent.Hash, parent.Hash
+		assert.NilError(t, g.AddEdge(string(parent.Hash[:]), string(child.Hash[:])))
+	}
+	vertices["root"].LeftParentHash, vertices["root"].RightParentHash = [32]byte{255}, [32]byte{255}
+
+	leaves := func() []*Vertex {
+		return []*Vertex{vertices["b"], vertices["c"], vertices["d"], vertices["e"]}
+	}
+
+	a := newApprovals(defaultConfirmedApprovers, defaultFinalApprovers)
+	a.recount(g)
+	assert.Equal(t, a.count(vertices["a"].Hash), uint64(3))
+
+	tips := oldestFirstTipSelector{}.SelectTips(g, leaves(), a.count)
+	assert.DeepEqual(t, tips, []*Vertex{vertices["e"], vertices["d"], vertices["c"], vertices["b"]})
+
+	mcmc, err := NewTipSelector(Config{TipSelection: TipSelectionMCMC, TipSelectionAlpha: 100})
+	assert.NilError(t, err)
+	for i := 0; i < 20; i++ {
+		tips := mcmc.SelectTips(g, leaves(), a.count)
+		assert.Equal(t, len(tips), 4)
+		assert.Assert(t, tips[0] != vertices["b"])
+		unique := make(map[[32]byte]struct{})
+		for _, tip := range tips {
+			unique[tip.Hash] = struct{}{}
+		}
+		assert.Equal(t, len(unique), 4)
+	}
+}
+
 func BenchmarkSingleIssuerSingleReceiverSpiceTransferConsecutive(b *testing.B) {
 	callOnLogErr := func(err error) {
 		fmt.Printf("logger failed with error: %s\n", err)
@@ -654,7 +2624,7 @@ func BenchmarkSingleIssuerSingleReceiverSpiceTransferConsecutive(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -702,7 +2672,7 @@ func BenchmarkSingleIssuerSingleReceiverSpiceTransferConcurrent(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -756,7 +2726,7 @@ func BenchmarkMultipleIssuerMultipleReceiversSpiceTransferConcurrentLegitimate(b
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	genesisSpice := spice.New(math.MaxUint64-1, 0)
@@ -831,7 +2801,7 @@ func TestVertexStorageAdd(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveVertexToStorage(&v)
@@ -870,7 +2840,7 @@ func TestVertexStorageAddRepeted(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveVertexToStorage(&v)
@@ -911,7 +2881,7 @@ func TestVertexStorageAddRead(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveVertexToStorage(&v)
@@ -936,7 +2906,7 @@ func BenchmarkVertexStorageAdd(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	for n := 0; n < b.N; n++ {
@@ -977,7 +2947,7 @@ func BenchmarkVertexStorageSaveRead(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	for n := 0; n < b.N; n++ {
@@ -1022,7 +2992,7 @@ func TestTrxToVrxStorageSaveRead(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveTrxInVertex(trxHash, vrxHash)
@@ -1050,7 +3020,7 @@ func TestTrxToVrxStorageSaveReadRemove(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveTrxInVertex(trxHash, vrxHash)
@@ -1085,7 +3055,7 @@ func TestTrxToVrxStorageSaveExisting(t *testing.T) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(t, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(t, err)
 
 	err = ab.saveTrxInVertex(trxHash, vrxHash)
@@ -1109,7 +3079,7 @@ func BenchmarkTrxToVertexStorageSave(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	for n := 0; n < b.N; n++ {
@@ -1135,7 +3105,7 @@ func BenchmarkTrxToVertexStorageSaveRead(b *testing.B) {
 	verifier := wallet.NewVerifier()
 	signer, err := wallet.New()
 	assert.NilError(b, err)
-	ab, err := NewAccountingBook(ctx, Config{}, verifier, &signer, l)
+	ab, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signer, &telemetryMock{}, l)
 	assert.NilError(b, err)
 
 	for n := 0; n < b.N; n++ {
@@ -0,0 +1,205 @@
+package accountant
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	backupName       = "vertex_db_backup_"
+	fullBackupName   = "vertex_db_full_backup_"
+	backupExtension  = ".bak"
+	backupInProgress = ".tmp"
+)
+
+const (
+	backupDurationTelemetryHistogram = "truncate_backup_duration"
+	backupSizeTelemetryHistogram     = "truncate_backup_size_bytes"
+)
+
+type backupFile struct {
+	path    string
+	n       uint64
+	full    bool
+	modTime time.Time
+}
+
+func readBackupFiles(dir string) ([]backupFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "vertex_db_*"+backupExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]backupFile, 0, len(paths))
+	for _, p := range paths {
+		base := strings.TrimSuffix(filepath.Base(p), backupExtension)
+		var f backupFile
+		switch {
+		case strings.HasPrefix(base, fullBackupName):
+			f.full = true
+			base = strings.TrimPrefix(base, fullBackupName)
+		case strings.HasPrefix(base, backupName):
+			base = strings.TrimPrefix(base, backupName)
+		default:
+			continue
+		}
+		n, err := strconv.ParseUint(base, 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		f.path, f.n, f.modTime = p, n, info.ModTime()
+		files = append(files, f)
+	}
+
+	// Incremental backup numbered n holds entries newer than the full backup numbered n,
+	// so the full backup goes first.
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].n == files[j].n {
+			return files[i].full && !files[j].full
+		}
+		return files[i].n < files[j].n
+	})
+
+	return files, nil
+}
+
+// backupChains splits backup files in to chains, each starting with the full backup followed by incremental backups.
+// Incremental backups created before the first full backup form the first chain.
+func backupChains(files []backupFile) [][]backupFile {
+	var chains [][]backupFile
+	for _, f := range files {
+		if f.full || len(chains) == 0 {
+			chains = append(chains, []backupFile{})
+		}
+		chains[len(chains)-1] = append(chains[len(chains)-1], f)
+	}
+	return chains
+}
+
+// ListBackups lists truncate backup files found in the given directory that are required to restore the vertices storage.
+// Those are the most recent full backup followed by the incremental backups created after it, in the order they have been created.
+func ListBackups(dir string) ([]string, error) {
+	files, err := readBackupFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	chains := backupChains(files)
+	if len(chains) == 0 {
+		return nil, nil
+	}
+
+	last := chains[len(chains)-1]
+	backups := make([]string, 0, len(last))
+	for _, f := range last {
+		backups = append(backups, f.path)
+	}
+
+	return backups, nil
+}
+
+// backup writes the vertices storage backup to the backup directory.
+// The first backup and every merge backup is a full backup, the others are incremental backups holding entries added since the previous backup.
+// Merge backup supersedes the incremental backups created since the previous full backup, so those are removed.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) backup() error {
+	t := time.Now()
+
+	full := ab.lastBackup == 0 || (ab.backupMergeEvery != 0 && uint64(len(ab.incrementalBackups)) >= ab.backupMergeEvery)
+	since := ab.lastBackup
+	path := filepath.Join(ab.backupDir, fmt.Sprintf("%s%v%s", backupName, since, backupExtension))
+	if full {
+		since = 0
+		path = filepath.Join(ab.backupDir, fmt.Sprintf("%s%v%s", fullBackupName, t.UnixNano(), backupInProgress))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	lastBackup, err := ab.verticesDB.Backup(f, since)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if full {
+		fullPath := filepath.Join(ab.backupDir, fmt.Sprintf("%s%v%s", fullBackupName, lastBackup, backupExtension))
+		if err := os.Rename(path, fullPath); err != nil {
+			return err
+		}
+		if err := removeBackups(ab.incrementalBackups); err != nil {
+			ab.log.Error(fmt.Sprintf("Accounting book failed to remove backups merged in to full backup, %s.", err))
+		}
+		ab.incrementalBackups = ab.incrementalBackups[:0]
+	} else {
+		ab.incrementalBackups = append(ab.incrementalBackups, path)
+	}
+
+	ab.lastBackup = lastBackup // Backup streams only versions greater than since, incrementing it would skip an entry.
+
+	ab.tele.RecordHistogramTime(backupDurationTelemetryHistogram, time.Since(t))
+	ab.tele.RecordHistogramValue(backupSizeTelemetryHistogram, float64(info.Size()))
+
+	if err := ab.applyBackupRetention(); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to apply backups retention, %s.", err))
+	}
+
+	return nil
+}
+
+func removeBackups(paths []string) error {
+	var errs []error
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyBackupRetention removes the backup chains that exceed the retention count or are older than retention age.
+// The most recent chain is always kept as it is required to restore the vertices storage.
+func (ab *AccountingBook) applyBackupRetention() error {
+	if ab.backupRetention <= 0 && ab.backupRetentionAge == 0 {
+		return nil
+	}
+
+	files, err := readBackupFiles(ab.backupDir)
+	if err != nil {
+		return err
+	}
+	chains := backupChains(files)
+
+	expired := make([]string, 0, len(files))
+	for i := 0; i < len(chains)-1; i++ {
+		chain := chains[i]
+		exceedsCount := ab.backupRetention > 0 && len(chains)-i > ab.backupRetention
+		exceedsAge := ab.backupRetentionAge != 0 && time.Since(chain[len(chain)-1].modTime) > ab.backupRetentionAge
+		if !exceedsCount && !exceedsAge {
+			continue
+		}
+		for _, f := range chain {
+			expired = append(expired, f.path)
+		}
+	}
+
+	return removeBackups(expired)
+}
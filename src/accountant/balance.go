@@ -10,10 +10,12 @@ import (
 )
 
 // Balance holds the wallet balance.
+// Spice is available for spending, Locked is received in the locked transactions and not yet unlocked.
 type Balance struct {
 	AccountedAt         time.Time     `msgpack:"accounted_at"`
 	WalletPublicAddress string        `msgpack:"wallet_public_address"`
 	Spice               spice.Melange `msgpack:"spice"`
+	Locked              spice.Melange `msgpack:"locked"`
 }
 
 // NewBalance creates a new balance entity.
@@ -22,6 +24,21 @@ func NewBalance(walletPubAddr string, s spice.Melange) Balance {
 	return Balance{AccountedAt: now, WalletPublicAddress: walletPubAddr, Spice: s}
 }
 
+// balanceWithLocks creates the balance splitting the funds in to available and locked at the given time.
+func balanceWithLocks(walletPubAddr string, funds spice.Melange, locks *fundsLocks, at time.Time) (Balance, error) {
+	locked, err := locks.lockedFor(walletPubAddr, at)
+	if err != nil {
+		return Balance{}, err
+	}
+	available, err := availableFunds(walletPubAddr, funds, locks, at)
+	if err != nil {
+		return Balance{}, err
+	}
+	b := NewBalance(walletPubAddr, available)
+	b.Locked = locked
+	return b, nil
+}
+
 func (b *Balance) encode() ([]byte, error) {
 	buf, err := msgpack.Marshal(*b)
 	if err != nil {
@@ -0,0 +1,147 @@
+package accountant
+
+import "github.com/heimdalr/dag"
+
+const (
+	defaultConfirmedApprovers uint64 = 5
+	defaultFinalApprovers     uint64 = 50
+)
+
+// Confidence describes how final is the transaction in the DAG.
+type Confidence uint8
+
+const (
+	// ConfidencePending means the vertex has less approvers than required to be confirmed.
+	ConfidencePending Confidence = iota
+	// ConfidenceConfirmed means the vertex has enough approvers to be confirmed but can still be rejected.
+	ConfidenceConfirmed
+	// ConfidenceFinal means the vertex has enough approvers to be final or has been truncated from the DAG to the storage.
+	ConfidenceFinal
+)
+
+// String returns the confidence level name.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceConfirmed:
+		return "confirmed"
+	case ConfidenceFinal:
+		return "final"
+	default:
+		return "pending"
+	}
+}
+
+// Confirmation holds the confidence level of the vertex and the number of vertices approving it directly or indirectly.
+// Approvers count is not tracked above the final threshold.
+type Confirmation struct {
+	Level     Confidence
+	Approvers uint64
+}
+
+// approvals tracks the cumulative weight of vertices in the DAG, that is the number of vertices approving the vertex directly or indirectly.
+// Once the vertex reaches the final threshold its count and the counts of its ancestors are no longer updated,
+// so adding the leaf costs only walking the vertices that are not final yet.
+// It is assumed that the caller holds the AccountingBook lock.
+type approvals struct {
+	counts    map[[32]byte]uint64
+	confirmed uint64
+	final     uint64
+}
+
+func newApprovals(confirmed, final uint64) *approvals {
+	if final == 0 {
+		final = defaultFinalApprovers
+	}
+	if confirmed == 0 {
+		confirmed = defaultConfirmedApprovers
+	}
+	if confirmed > final {
+		confirmed = final
+	}
+	return &approvals{counts: make(map[[32]byte]uint64), confirmed: confirmed, final: final}
+}
+
+// approve increments approvers count of all the not final ancestors of the vertex added to the DAG.
+func (a *approvals) approve(d *dag.DAG, vrx *Vertex) {
+	a.walkNotFinalAncestors(d, vrx, func(h [32]byte) {
+		a.counts[h]++
+	})
+}
+
+// disapprove decrements approvers count of all the not final ancestors of the vertex removed from the DAG.
+// Ancestors that became final are not decremented.
+func (a *approvals) disapprove(d *dag.DAG, vrx *Vertex) {
+	a.walkNotFinalAncestors(d, vrx, func(h [32]byte) {
+		if a.counts[h] > 0 {
+			a.counts[h]--
+		}
+	})
+	delete(a.counts, vrx.Hash)
+}
+
+func (a *approvals) walkNotFinalAncestors(d *dag.DAG, vrx *Vertex, perform func(h [32]byte)) {
+	visited := map[string]struct{}{string(vrx.Hash[:]): {}}
+	queue := []string{string(vrx.Hash[:])}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		parents, err := d.GetParents(id)
+		if err != nil {
+			continue
+		}
+		for parentID := range parents {
+			if _, ok := visited[parentID]; ok {
+				continue
+			}
+			visited[parentID] = struct{}{}
+			h := [32]byte([]byte(parentID))
+			if a.counts[h] >= a.final {
+				continue
+			}
+			perform(h)
+			queue = append(queue, parentID)
+		}
+	}
+}
+
+// recount calculates approvers count of all the vertices in the DAG.
+func (a *approvals) recount(d *dag.DAG) {
+	a.counts = make(map[[32]byte]uint64)
+	for id := range d.GetVertices() {
+		descendants, err := d.GetDescendants(id)
+		if err != nil {
+			continue
+		}
+		count := uint64(len(descendants))
+		if count > a.final {
+			count = a.final
+		}
+		a.counts[[32]byte([]byte(id))] = count
+	}
+}
+
+func (a *approvals) remove(h [32]byte) {
+	delete(a.counts, h)
+}
+
+// count returns approvers count of the vertex, it is the CumulativeWeight of the vertex capped at the final threshold.
+func (a *approvals) count(h [32]byte) uint64 {
+	return a.counts[h]
+}
+
+func (a *approvals) confirmation(h [32]byte) Confirmation {
+	count := a.counts[h]
+	switch {
+	case count >= a.final:
+		return Confirmation{Level: ConfidenceFinal, Approvers: count}
+	case count >= a.confirmed:
+		return Confirmation{Level: ConfidenceConfirmed, Approvers: count}
+	default:
+		return Confirmation{Level: ConfidencePending, Approvers: count}
+	}
+}
+
+// finalConfirmation is the confirmation of the vertex truncated from the DAG to the storage.
+func (a *approvals) finalConfirmation() Confirmation {
+	return Confirmation{Level: ConfidenceFinal, Approvers: a.final}
+}
@@ -2,9 +2,25 @@ package accountant
 
 // Config contains configuration for the AccountingBook.
 type Config struct {
-	TrustedNodesDBPath      string `yaml:"trusted_nodes_db_path"`
-	TokensDBPath            string `yaml:"tokens_db_path"`
-	TrxsToVerticesMapDBPath string `yaml:"trxs_to_vertices_map_db_path"`
-	VerticesDBPath          string `yaml:"vertices_db_path"`
-	Truncate                uint64 `yaml:"truncate_at_weight"`
+	TrustedNodesDBPath      string      `yaml:"trusted_nodes_db_path"`
+	TokensDBPath            string      `yaml:"tokens_db_path"`
+	TrxsToVerticesMapDBPath string      `yaml:"trxs_to_vertices_map_db_path"`
+	VerticesDBPath          string      `yaml:"vertices_db_path"`
+	AddressesIndexDBPath    string      `yaml:"addresses_index_db_path"`
+	JournalDBPath           string      `yaml:"journal_db_path"`
+	BackupDir               string      `yaml:"backup_dir"`
+	BackupRetentionCount    int         `yaml:"backup_retention_count"`
+	BackupRetentionAge      uint64      `yaml:"backup_retention_age"`
+	BackupMergeEvery        uint64      `yaml:"backup_merge_every"`
+	Truncate                uint64      `yaml:"truncate_at_weight"`
+	BalanceConsistencyCheck bool        `yaml:"balance_consistency_check"`
+	TipSelection            string      `yaml:"tip_selection"`
+	TipSelectionAlpha       float64     `yaml:"tip_selection_alpha"`
+	ConfirmedApprovers      uint64      `yaml:"confirmed_approvers"`
+	FinalApprovers          uint64      `yaml:"final_approvers"`
+	OrphanPoolSize          int         `yaml:"orphan_pool_size"`
+	OrphanLongevity         uint64      `yaml:"orphan_longevity"`
+	Fee                     FeeSchedule `yaml:"fee"`
+	NonceActivation         int64       `yaml:"nonce_activation"`
+	NonceLegacy             bool        `yaml:"nonce_legacy"`
 }
@@ -0,0 +1,382 @@
+package accountant
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/transaction"
+)
+
+const (
+	conflictResolutionTelemetryHistogram      = "conflict_resolution_duration"
+	conflictRemovedVerticesTelemetryHistogram = "conflict_removed_vertices"
+)
+
+const reattachedCapacity = 1_000
+
+// conflictSet holds vertices spending funds of the same issuer in parallel branches of the DAG,
+// that together exceed the issuer funds. Each vertex is valid in its own past cone, so the conflict
+// becomes visible only when the cones meet.
+type conflictSet struct {
+	issuer  string
+	members map[[32]byte]*Vertex
+}
+
+// conflictResolution describes the resolved conflict.
+// Revalidated are hashes of transactions of removed descendants that are still valid and are attached again by their signers,
+// rejected are hashes of transactions of removed descendants that are no longer valid,
+// reattached are hashes of vertices created by the node to attach again transactions of its own removed descendants.
+type conflictResolution struct {
+	issuer      string
+	winners     [][32]byte
+	losers      [][32]byte
+	revalidated [][32]byte
+	rejected    [][32]byte
+	reattached  [][32]byte
+}
+
+// spenders indexes the DAG vertices spending the issuer funds by the issuer, so parallel spends are found without scanning the DAG.
+type spenders map[string]map[[32]byte]*Vertex
+
+func (s spenders) add(vrx *Vertex) {
+	if !vrx.spends() {
+		return
+	}
+	issuer := vrx.Transaction.IssuerAddress
+	m, ok := s[issuer]
+	if !ok {
+		m = make(map[[32]byte]*Vertex)
+		s[issuer] = m
+	}
+	m[vrx.Hash] = vrx
+}
+
+func (s spenders) remove(vrx *Vertex) {
+	issuer := vrx.Transaction.IssuerAddress
+	m, ok := s[issuer]
+	if !ok {
+		return
+	}
+	delete(m, vrx.Hash)
+	if len(m) == 0 {
+		delete(s, issuer)
+	}
+}
+
+// trackConflict opens or extends the conflict set of the vertex issuer when vertex added to the DAG overspends the issuer funds.
+// Members are the issuer spends that are not ancestors of the vertex, so they are in parallel branches.
+// It is assumed that the caller holds the lock and the vertex has been applied to the ledger and indexed in spenders.
+func (ab *AccountingBook) trackConflict(vrx *Vertex) {
+	if !vrx.spends() {
+		return
+	}
+	issuer := vrx.Transaction.IssuerAddress
+	if !ab.ledger.overspent(issuer) {
+		return
+	}
+
+	ancestors, err := ab.dag.GetAncestors(string(vrx.Hash[:]))
+	if err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to track conflict of vertex [ %v ], %s.", vrx.Hash, err))
+		return
+	}
+
+	set, ok := ab.conflicts[issuer]
+	if !ok {
+		set = &conflictSet{issuer: issuer, members: make(map[[32]byte]*Vertex)}
+		ab.conflicts[issuer] = set
+	}
+	set.members[vrx.Hash] = vrx
+
+	for h, other := range ab.spenders[issuer] {
+		if h == vrx.Hash {
+			continue
+		}
+		if _, ok := ancestors[string(h[:])]; ok {
+			continue
+		}
+		set.members[h] = other
+	}
+
+	ab.log.Info(fmt.Sprintf("Accounting book detected conflict of [ %v ] spends from issuer [ %s ].", len(set.members), issuer))
+}
+
+// resolveConflictsMeetingAt resolves conflict sets that have at least two members in the past cones of given vertices.
+// Returns true if any conflict has been resolved.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) resolveConflictsMeetingAt(ctx context.Context, vertices ...*Vertex) bool {
+	if len(ab.conflicts) == 0 {
+		return false
+	}
+
+	cone := make(map[[32]byte]struct{})
+	for _, vrx := range vertices {
+		if vrx == nil {
+			continue
+		}
+		cone[vrx.Hash] = struct{}{}
+		ancestors, err := ab.dag.GetAncestors(string(vrx.Hash[:]))
+		if err != nil {
+			continue
+		}
+		for id := range ancestors {
+			cone[[32]byte([]byte(id))] = struct{}{}
+		}
+	}
+
+	var resolved bool
+	for issuer, set := range ab.conflicts {
+		var met int
+		for h := range set.members {
+			if _, ok := cone[h]; ok {
+				met++
+			}
+		}
+		if met < 2 {
+			continue
+		}
+		res, err := ab.resolveConflict(ctx, set)
+		if err != nil {
+			ab.log.Error(fmt.Sprintf("Accounting book failed to resolve conflict of issuer [ %s ], %s.", issuer, err))
+			continue
+		}
+		delete(ab.conflicts, issuer)
+		resolved = true
+		ab.log.Info(
+			fmt.Sprintf(
+				"Accounting book resolved conflict of issuer [ %s ], winners: [ %v ], losers: [ %v ], revalidated transactions: [ %v ], rejected transactions: [ %v ], reattached vertices: [ %v ].",
+				res.issuer, res.winners, res.losers, res.revalidated, res.rejected, res.reattached,
+			),
+		)
+	}
+
+	return resolved
+}
+
+// resolveConflict keeps the members with lower weight, then lower hash, as long as the issuer funds suffice,
+// and removes the others together with all their descendants. Removed descendants transactions are re-validated against the ledger
+// and those still valid are attached again on top of the current leaves by the node that signed the removed descendant,
+// so each node reattaches only its own vertices and the transactions are not duplicated across the nodes.
+// Members that are no longer in the DAG are considered final and always kept.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) resolveConflict(ctx context.Context, set *conflictSet) (conflictResolution, error) {
+	t := time.Now()
+	res := conflictResolution{issuer: set.issuer}
+
+	members := make([]*Vertex, 0, len(set.members))
+	for h, vrx := range set.members {
+		if _, err := ab.dag.GetVertex(string(h[:])); err != nil {
+			continue
+		}
+		members = append(members, vrx)
+	}
+	// Order depends only on the members data, not on the approvers seen by the node, so all the nodes pick the same winners.
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Weight != members[j].Weight {
+			return members[i].Weight < members[j].Weight
+		}
+		return bytes.Compare(members[i].Hash[:], members[j].Hash[:]) < 0
+	})
+
+	available, err := ab.ledger.readExcluding(set.issuer, members)
+	if err != nil {
+		return res, err
+	}
+
+	losers := make([]*Vertex, 0, len(members))
+	for _, vrx := range members {
+		spent, err := vrx.spent()
+		if err != nil {
+			return res, err
+		}
+		if err := available.Drain(spent, &spice.Melange{}); err != nil {
+			losers = append(losers, vrx)
+			res.losers = append(res.losers, vrx.Hash)
+			continue
+		}
+		res.winners = append(res.winners, vrx.Hash)
+	}
+
+	removed := make(map[[32]byte]*Vertex)
+	for _, loser := range losers {
+		if err := ab.removeVertexWithDescendants(ctx, loser, removed); err != nil {
+			return res, err
+		}
+	}
+
+	descendants := make([]*Vertex, 0, len(removed))
+	for _, vrx := range removed {
+		if _, ok := set.members[vrx.Hash]; ok {
+			continue
+		}
+		descendants = append(descendants, vrx)
+	}
+	// Child weight is always greater than its parents weight, so parents are revalidated and reattached first.
+	sort.Slice(descendants, func(i, j int) bool {
+		if descendants[i].Weight != descendants[j].Weight {
+			return descendants[i].Weight < descendants[j].Weight
+		}
+		return bytes.Compare(descendants[i].Hash[:], descendants[j].Hash[:]) < 0
+	})
+
+	for _, vrx := range descendants {
+		if err := ab.revalidateRemoved(vrx); err != nil {
+			res.rejected = append(res.rejected, vrx.TransactionsHashes()...)
+			continue
+		}
+		res.revalidated = append(res.revalidated, vrx.TransactionsHashes()...)
+		if vrx.SignerPublicAddress != ab.signer.Address() {
+			continue
+		}
+		tip, err := ab.reattachRemoved(ctx, vrx)
+		if err != nil {
+			ab.log.Error(fmt.Sprintf("Accounting book failed to reattach transactions [ %v ] of removed vertex [ %v ], %s.", vrx.TransactionsHashes(), vrx.Hash, err))
+			continue
+		}
+		res.reattached = append(res.reattached, tip.Hash)
+	}
+
+	ab.tele.RecordHistogramTime(conflictResolutionTelemetryHistogram, time.Since(t))
+	ab.tele.RecordHistogramValue(conflictRemovedVerticesTelemetryHistogram, float64(len(removed)))
+
+	return res, nil
+}
+
+// removeVertexWithDescendants removes the vertex and all its descendants from the DAG, starting from the leaves,
+// so approvers of the remaining vertices are decremented correctly.
+func (ab *AccountingBook) removeVertexWithDescendants(ctx context.Context, vrx *Vertex, removed map[[32]byte]*Vertex) error {
+	if _, ok := removed[vrx.Hash]; ok {
+		return nil
+	}
+	if _, err := ab.dag.GetVertex(string(vrx.Hash[:])); err != nil {
+		return nil
+	}
+	ordered, err := ab.dag.GetOrderedDescendants(string(vrx.Hash[:]))
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+
+	// Kahn ordering of the descendants sub graph, so children are removed before their parents.
+	inDegree := make(map[string]int, len(ordered)+1)
+	inDegree[string(vrx.Hash[:])] = 0
+	for _, id := range ordered {
+		inDegree[id] = 0
+	}
+	for id := range inDegree {
+		children, err := ab.dag.GetChildren(id)
+		if err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		for childID := range children {
+			inDegree[childID]++
+		}
+	}
+	queue := []string{string(vrx.Hash[:])}
+	topological := make([]string, 0, len(inDegree))
+	for len(queue) > 0 {
+		select {
+		case <-ctx.Done():
+			return ErrLeafValidationProcessStopped
+		default:
+		}
+		id := queue[0]
+		queue = queue[1:]
+		topological = append(topological, id)
+		children, err := ab.dag.GetChildren(id)
+		if err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		for childID := range children {
+			inDegree[childID]--
+			if inDegree[childID] == 0 {
+				queue = append(queue, childID)
+			}
+		}
+	}
+
+	for i := len(topological) - 1; i >= 0; i-- {
+		item, err := ab.dag.GetVertex(topological[i])
+		if err != nil {
+			continue
+		}
+		descendant, ok := item.(*Vertex)
+		if !ok || descendant == nil {
+			return ErrUnexpected
+		}
+		ab.removeRejectedLeaf(descendant)
+		removed[descendant.Hash] = descendant
+	}
+
+	return nil
+}
+
+// revalidateRemoved checks if the transaction of the vertex removed due to conflict resolution is still covered by the issuer funds.
+func (ab *AccountingBook) revalidateRemoved(vrx *Vertex) error {
+	if !vrx.spends() {
+		return nil
+	}
+	s, err := ab.ledger.read(vrx.Transaction.IssuerAddress)
+	if err != nil {
+		return err
+	}
+	spent, err := vrx.spent()
+	if err != nil {
+		return err
+	}
+	return s.Drain(spent, &spice.Melange{})
+}
+
+// reattachRemoved attaches the transactions of the vertex removed due to conflict resolution on top of the current valid leaves.
+// Created tip is scheduled to be gossiped to other nodes. When the tips are not consumed fast enough the tip is not scheduled
+// and other nodes receive it when reconciling the DAG.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) reattachRemoved(ctx context.Context, vrx *Vertex) (Vertex, error) {
+	trxs := vrx.Transactions()
+	batch := make([]transaction.Transaction, 0, len(trxs))
+	for _, trx := range trxs {
+		batch = append(batch, *trx)
+	}
+	fee, err := ab.fees.batchFee(trxs)
+	if err != nil {
+		return Vertex{}, err
+	}
+	leftLeaf, rightLeaf, err := ab.getValidLeaves(ctx)
+	if err != nil {
+		return Vertex{}, err
+	}
+	if leftLeaf == nil {
+		return Vertex{}, errors.Join(ErrUnexpected, errors.New("expected at least one leaf but got zero"))
+	}
+	if rightLeaf == nil {
+		rightLeaf = leftLeaf
+	}
+	tip, err := ab.attachTip(batch, fee, leftLeaf, rightLeaf)
+	if err != nil {
+		return Vertex{}, err
+	}
+	select {
+	case ab.reattached <- &tip:
+	default:
+	}
+	return tip, nil
+}
+
+// pruneConflicts drops members truncated from the DAG, as those are final, and conflict sets that have no parallel spends left.
+// It is assumed that the caller holds the lock.
+func (ab *AccountingBook) pruneConflicts() {
+	for issuer, set := range ab.conflicts {
+		for h := range set.members {
+			if _, err := ab.dag.GetVertex(string(h[:])); err != nil {
+				delete(set.members, h)
+			}
+		}
+		if len(set.members) < 2 {
+			delete(ab.conflicts, issuer)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package accountant
+
+import (
+	"errors"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/transaction"
+)
+
+const feeDataChunkSize = 1024
+
+var ErrFeeExceedsSchedule = errors.New("vertex fee exceeds the fee schedule")
+
+// FeeSchedule describes the fee the notary node charges the transaction issuer for signing the vertex.
+// The fee is paid to the vertex signer address. Every node accepts vertices charging up to its own schedule,
+// so the schedule shall be the same for all the nodes in the network. Empty schedule charges nothing.
+type FeeSchedule struct {
+	Base        spice.Melange `yaml:"base"`         // Charged for every transaction.
+	PerKilobyte spice.Melange `yaml:"per_kilobyte"` // Charged for every started kilobyte of the transaction data.
+}
+
+// Fee calculates the fee for the transaction.
+func (f FeeSchedule) Fee(trx *transaction.Transaction) (spice.Melange, error) {
+	fee := f.Base.Clone()
+	if f.PerKilobyte.Empty() {
+		return fee, nil
+	}
+	for chunks := (len(trx.Data) + feeDataChunkSize - 1) / feeDataChunkSize; chunks > 0; chunks-- {
+		if err := fee.Supply(f.PerKilobyte); err != nil {
+			return spice.Melange{}, err
+		}
+	}
+	return fee, nil
+}
+
+// batchFee calculates the fee for all the transactions in the batch.
+func (f FeeSchedule) batchFee(trxs []*transaction.Transaction) (spice.Melange, error) {
+	fee := spice.New(0, 0)
+	for _, trx := range trxs {
+		trxFee, err := f.Fee(trx)
+		if err != nil {
+			return spice.Melange{}, err
+		}
+		if err := fee.Supply(trxFee); err != nil {
+			return spice.Melange{}, err
+		}
+	}
+	return fee, nil
+}
+
+// validateFee checks the vertex fee doesn't exceed the fee calculated from the schedule.
+func (f FeeSchedule) validateFee(vrx *Vertex) error {
+	if vrx.Fee.Empty() {
+		return nil
+	}
+	fee, err := f.batchFee(vrx.Transactions())
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	if err := fee.Drain(vrx.Fee, &spice.Melange{}); err != nil {
+		return ErrFeeExceedsSchedule
+	}
+	return nil
+}
@@ -2,35 +2,70 @@ package accountant
 
 import (
 	"errors"
+	"time"
 
 	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/transaction"
 )
 
-func pourFunds(issuerAddress string, vrx Vertex, spiceIn, spiceOut *spice.Melange) error {
-	if spiceIn == nil || spiceOut == nil {
+func pourFunds(issuerAddress string, vrx Vertex, spiceIn, spiceOut *spice.Melange, locks *fundsLocks) error {
+	if spiceIn == nil || spiceOut == nil || locks == nil {
 		return ErrUnexpected
 	}
-	if !vrx.Transaction.IsSpiceTransfer() {
+	if !vrx.Fee.Empty() {
+		if vrx.Transaction.IssuerAddress == issuerAddress {
+			if err := spiceOut.Supply(vrx.Fee); err != nil {
+				return errors.Join(ErrUnexpected, err)
+			}
+		}
+		if vrx.SignerPublicAddress == issuerAddress {
+			if err := spiceIn.Supply(vrx.Fee); err != nil {
+				return errors.Join(ErrUnexpected, err)
+			}
+		}
+	}
+	for _, trx := range vrx.Transactions() {
+		if err := pourTransactionFunds(issuerAddress, trx, spiceIn, spiceOut, locks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pourTransactionFunds(issuerAddress string, trx *transaction.Transaction, spiceIn, spiceOut *spice.Melange, locks *fundsLocks) error {
+	if trx.IsEscrowRelease() {
+		locks.nextTransaction(trx)
+	}
+	if !trx.IsSpiceTransfer() {
 		return nil
 	}
-	if vrx.Transaction.IssuerAddress == issuerAddress {
-		if err := spiceOut.Supply(vrx.Transaction.Spice); err != nil {
+	if trx.IssuerAddress == issuerAddress {
+		if err := spiceOut.Supply(trx.Spice); err != nil {
 			return errors.Join(ErrUnexpected, err)
 		}
 	}
-	if vrx.Transaction.ReceiverAddress == issuerAddress {
-		if err := spiceIn.Supply(vrx.Transaction.Spice); err != nil {
+	if trx.ReceiverAddress == issuerAddress {
+		if err := spiceIn.Supply(trx.Spice); err != nil {
 			return errors.Join(ErrUnexpected, err)
 		}
+		locks.nextTransaction(trx)
 	}
 	return nil
 }
 
-func checkHasSufficientfunds(in, out *spice.Melange) error {
-	if in == nil || out == nil {
+// checkHasSufficientfunds checks the funds received by the issuer that are not locked at the given time cover the spent funds.
+func checkHasSufficientfunds(issuerAddress string, in, out *spice.Melange, locks *fundsLocks, at time.Time) error {
+	if in == nil || out == nil || locks == nil {
 		return ErrUnexpected
 	}
+	locked, err := locks.lockedFor(issuerAddress, at)
+	if err != nil {
+		return err
+	}
 	sink := spice.New(0, 0)
+	if err := in.Drain(locked, &sink); err != nil {
+		return errors.Join(ErrDoubleSpending, err)
+	}
 	if err := in.Drain(*out, &sink); err != nil {
 		return errors.Join(ErrDoubleSpending, err)
 	}
@@ -0,0 +1,274 @@
+package accountant
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/heimdalr/dag"
+)
+
+const (
+	journalGenesisAddressKey  = "genesis_public_address"
+	journalTruncationRoundKey = "truncation_round"
+)
+
+// journalVertex writes the vertex accepted in to the DAG to the journal, so it can be replayed after the node restart.
+func (ab *AccountingBook) journalVertex(vrx *Vertex) {
+	buf, err := vrx.encode()
+	if err == nil {
+		err = ab.journalDB.Update(func(txn *badger.Txn) error {
+			return txn.SetEntry(badger.NewEntry(vrx.Hash[:], buf))
+		})
+	}
+	if err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to journal vertex [ %v ], %s.", vrx.Hash, err))
+	}
+}
+
+// unjournalVertex removes the vertex that left the DAG from the journal.
+func (ab *AccountingBook) unjournalVertex(h [32]byte) {
+	if err := ab.journalDB.Update(func(txn *badger.Txn) error {
+		return txn.Delete(h[:])
+	}); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to remove vertex [ %v ] from journal, %s.", h, err))
+	}
+}
+
+func (ab *AccountingBook) journalGenesisAddress(address string) {
+	if err := ab.journalDB.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(badger.NewEntry([]byte(journalGenesisAddressKey), []byte(address)))
+	}); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to journal genesis address, %s.", err))
+	}
+}
+
+func (ab *AccountingBook) readJournalGenesisAddress() (string, error) {
+	var address string
+	err := ab.journalDB.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(journalGenesisAddressKey))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			address = string(val)
+			return nil
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return "", nil
+	}
+	return address, err
+}
+
+// journalTruncationRound marks the truncation round as started. Marker is removed when the truncated vertices are removed from the journal,
+// so the marker that outlives the node restart tells which journal vertices may have been truncated.
+func (ab *AccountingBook) journalTruncationRound(round uint64) error {
+	return ab.journalDB.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(journalTruncationRoundKey), binary.BigEndian.AppendUint64(nil, round))
+	})
+}
+
+func (ab *AccountingBook) unjournalTruncationRound() {
+	if err := ab.journalDB.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(journalTruncationRoundKey))
+	}); err != nil {
+		ab.log.Error(fmt.Sprintf("Accounting book failed to remove truncation round from journal, %s.", err))
+	}
+}
+
+func (ab *AccountingBook) readJournalTruncationRound() (uint64, bool, error) {
+	var round uint64
+	err := ab.journalDB.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(journalTruncationRoundKey))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			if len(val) != 8 {
+				return ErrUnexpected
+			}
+			round = binary.BigEndian.Uint64(val)
+			return nil
+		})
+	})
+	switch {
+	case err == nil:
+		return round, true, nil
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return 0, false, nil
+	default:
+		return 0, false, err
+	}
+}
+
+// truncatedInJournal returns true if the truncation round marked in the journal has been committed before the node restart.
+// Journal vertices that are in the storage are then already counted in the stored funds.
+func (ab *AccountingBook) truncatedInJournal() (bool, error) {
+	round, ok, err := ab.readJournalTruncationRound()
+	if err != nil || !ok {
+		return false, err
+	}
+	next, err := ab.nextFundsSnapshotRound()
+	if err != nil {
+		return false, err
+	}
+	return round < next, nil
+}
+
+// replayJournal loads the vertices from the journal in to the DAG, recovering the DAG state from before the node restart.
+// Edges to parents that are not in the journal are skipped, as those parents have been truncated to the storage.
+// Vertices truncated in the round committed before the node restart removed them from the journal are skipped,
+// as their funds are already stored. Conflicts of the replayed vertices are tracked again.
+// It is assumed that the DAG is empty and the ledger holds the stored funds.
+func (ab *AccountingBook) replayJournal(ctx context.Context) error {
+	ab.mux.Lock()
+	defer ab.mux.Unlock()
+
+	truncated, err := ab.truncatedInJournal()
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+
+	vertices := make([]*Vertex, 0)
+	skipped := make([][32]byte, 0)
+	if err := forEachVertexInDB(ctx, ab.journalDB, func(vrx *Vertex) error {
+		if truncated {
+			ok, err := ab.checkVertexExistInStorage(vrx.Hash[:])
+			if err != nil {
+				return errors.Join(ErrUnexpected, err)
+			}
+			if ok {
+				skipped = append(skipped, vrx.Hash)
+				return nil
+			}
+		}
+		if err := ab.dag.AddVertexByID(string(vrx.Hash[:]), vrx); err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		vertices = append(vertices, vrx)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, h := range skipped {
+		ab.unjournalVertex(h)
+	}
+	ab.unjournalTruncationRound()
+	if len(vertices) == 0 {
+		return nil
+	}
+
+	var maxWeight uint64
+	for _, vrx := range vertices {
+		if err := ab.saveTrxsInVertex(vrx); err != nil && !errors.Is(err, ErrTrxInVertexAlreadyExists) {
+			return errors.Join(ErrUnexpected, err)
+		}
+		if err := ab.saveVertexInAddressesIndex(vrx); err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		if err := ab.ledger.apply(vrx); err != nil {
+			return err
+		}
+		ab.spenders.add(vrx)
+		for _, parent := range uniqueParents(vrx) {
+			if _, err := ab.dag.GetVertex(string(parent[:])); err != nil {
+				continue
+			}
+			if err := ab.dag.AddEdge(string(parent[:]), string(vrx.Hash[:])); err != nil {
+				return errors.Join(ErrUnexpected, err)
+			}
+		}
+		if vrx.Weight > maxWeight {
+			maxWeight = vrx.Weight
+		}
+	}
+
+	genesisAddress, err := ab.readJournalGenesisAddress()
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	if genesisAddress == "" {
+		for _, item := range ab.dag.GetRoots() {
+			if vrx, ok := item.(*Vertex); ok && vrx != nil {
+				genesisAddress = vrx.Transaction.IssuerAddress
+				break
+			}
+		}
+	}
+
+	ab.approvals.recount(ab.dag)
+	for _, vrx := range vertices {
+		ab.trackConflict(vrx)
+	}
+	ab.genesisPublicAddress = genesisAddress
+	ab.throughput.Store(initialThroughput)
+	ab.updateWeightAndThroughput(maxWeight)
+	ab.nextWeightTruncate = ab.nextWeightTruncate + maxWeight
+	ab.dagLoaded = true
+
+	ab.log.Info(
+		fmt.Sprintf(
+			"Accounting book replayed [ %v ] vertices from journal to weight [ %v ], skipped [ %v ] truncated vertices.",
+			len(vertices), maxWeight, len(skipped),
+		),
+	)
+
+	return nil
+}
+
+// DiscardDag discards the DAG replayed from the journal, so the DAG can be loaded from the other node with LoadDag.
+// It is used when the replayed DAG cannot be synced, because the other node truncated the vertices the replayed DAG misses.
+// Replayed vertices are removed from the journal and the indexes, and the ledger is read again from the storage.
+func (ab *AccountingBook) DiscardDag() error {
+	ab.mux.Lock()
+	defer ab.mux.Unlock()
+
+	for _, item := range ab.dag.GetVertices() {
+		vrx, ok := item.(*Vertex)
+		if !ok || vrx == nil {
+			return ErrUnexpected
+		}
+		if err := ab.removeTrxsInVertex(vrx); err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		if err := ab.removeVertexFromAddressesIndex(vrx); err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		ab.unjournalVertex(vrx.Hash)
+	}
+
+	ledger := newBalanceLedger()
+	if err := ab.forEachfundFromStorage(ledger.set); err != nil {
+		return err
+	}
+	if err := ab.readFundsLocksFromStorage("", &ledger.funds.locks); err != nil {
+		return err
+	}
+	if err := ab.forEachNonceFromStorage(ledger.setNonce); err != nil {
+		return err
+	}
+
+	size := ab.dag.GetSize()
+	ab.dag = dag.NewDAG()
+	ab.ledger = ledger
+	ab.approvals = newApprovals(ab.approvals.confirmed, ab.approvals.final)
+	ab.conflicts = make(map[string]*conflictSet)
+	ab.spenders = make(spenders)
+	ab.weight.Store(0)
+	ab.nextWeightTruncate = ab.truncateEvery
+	ab.dagLoaded = false
+
+	ab.log.Info(fmt.Sprintf("Accounting book discarded [ %v ] vertices replayed from journal.", size))
+
+	return nil
+}
+
+func uniqueParents(vrx *Vertex) [][32]byte {
+	if vrx.LeftParentHash == vrx.RightParentHash {
+		return [][32]byte{vrx.LeftParentHash}
+	}
+	return [][32]byte{vrx.LeftParentHash, vrx.RightParentHash}
+}
@@ -0,0 +1,159 @@
+package accountant
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+)
+
+// balanceLedger is a running balance of every address that has been taking part in spice transfer.
+// It holds all the funds stored after DAG truncation and all the funds transferred by vertices in the DAG,
+// so the balance can be read without walking the graph.
+type balanceLedger struct {
+	mux   sync.RWMutex
+	funds fundsMemMap
+}
+
+func newBalanceLedger() *balanceLedger {
+	return &balanceLedger{funds: newFoundsMemMap()}
+}
+
+func (l *balanceLedger) set(address string, s *spice.Melange) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.funds.set(address, s)
+}
+
+func (l *balanceLedger) apply(vrx *Vertex) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.funds.nextVertex(vrx)
+}
+
+func (l *balanceLedger) revert(vrx *Vertex) error {
+	if vrx == nil {
+		return errors.Join(ErrUnexpected, errors.New("reverted vertex cannot be nil"))
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.funds.locks.revertVertex(vrx)
+	if !vrx.Fee.Empty() {
+		if err := l.revertTransfer(vrx.Transaction.IssuerAddress, vrx.SignerPublicAddress, vrx.Fee); err != nil {
+			return err
+		}
+	}
+	for _, trx := range vrx.Transactions() {
+		if !trx.IsSpiceTransfer() {
+			continue
+		}
+		if err := l.revertTransfer(trx.IssuerAddress, trx.ReceiverAddress, trx.Spice); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *balanceLedger) revertTransfer(issuer, receiver string, s spice.Melange) error {
+	ip := l.funds.m[issuer]
+	if err := ip.out.Drain(s, &spice.Melange{}); err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	l.funds.m[issuer] = ip
+	rp := l.funds.m[receiver]
+	if err := rp.in.Drain(s, &spice.Melange{}); err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	l.funds.m[receiver] = rp
+	return nil
+}
+
+// read reads the spice of the address available at the moment, the spice that is still locked is not available.
+func (l *balanceLedger) read(address string) (spice.Melange, error) {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	pf, ok := l.funds.m[address]
+	if !ok {
+		return spice.New(0, 0), nil
+	}
+	s := pf.in.Clone()
+	if err := s.Drain(pf.out, &spice.Melange{}); err != nil {
+		return spice.Melange{}, errors.Join(ErrBalanceCalculationUnexpectedFailure, err)
+	}
+	return availableFunds(address, s, &l.funds.locks, time.Now())
+}
+
+// setNonce sets the highest nonce of the issuer.
+func (l *balanceLedger) setNonce(address string, nonce uint64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.funds.nonces[address] = nonce
+}
+
+// readNonce reads the highest nonce of the issuer, nonces of reverted vertices remain used.
+func (l *balanceLedger) readNonce(address string) uint64 {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return l.funds.nonces[address]
+}
+
+// readLocked reads the spice received by the address that is still locked at the moment.
+func (l *balanceLedger) readLocked(address string) (spice.Melange, error) {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.funds.locks.lockedFor(address, time.Now())
+}
+
+// pruneLocks drops the locks that are met at the moment.
+func (l *balanceLedger) pruneLocks() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.funds.locks.prune(time.Now())
+}
+
+// overspent returns true if the address spent more than it received, that happens when conflicting spends are in parallel branches of the DAG.
+func (l *balanceLedger) overspent(address string) bool {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	pf, ok := l.funds.m[address]
+	if !ok {
+		return false
+	}
+	s := pf.in.Clone()
+	return s.Drain(pf.out, &spice.Melange{}) != nil
+}
+
+// readExcluding reads the balance of the address as if spends of the excluded vertices never happened.
+// Returns zero balance if the address is overspent even without the excluded vertices.
+func (l *balanceLedger) readExcluding(address string, excluded []*Vertex) (spice.Melange, error) {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	pf := l.funds.m[address]
+	out := pf.out.Clone()
+	for _, vrx := range excluded {
+		if vrx.Transaction.IssuerAddress != address || !vrx.spends() {
+			continue
+		}
+		spent, err := vrx.spent()
+		if err != nil {
+			return spice.Melange{}, err
+		}
+		if err := out.Drain(spent, &spice.Melange{}); err != nil {
+			return spice.Melange{}, errors.Join(ErrUnexpected, err)
+		}
+	}
+	s := pf.in.Clone()
+	if err := s.Drain(out, &spice.Melange{}); err != nil {
+		return spice.New(0, 0), nil
+	}
+	return availableFunds(address, s, &l.funds.locks, time.Now())
+}
@@ -0,0 +1,266 @@
+package accountant
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/dgraph-io/badger/v4"
+	msgpackv2 "github.com/shamaton/msgpack/v2"
+	"github.com/vmihailenco/msgpack"
+)
+
+// Outstanding locks and escrow releases are stored in the vertices repository next to the address funds when DAG is truncated.
+// Prefixes contain the character that is not used in the wallet public address encoding.
+var (
+	fundsLockPrefix    = []byte("lock_")
+	fundsReleasePrefix = []byte("release_")
+)
+
+// lockedFunds is the spice received in the locked transaction that is not yet spendable by the receiver.
+type lockedFunds struct {
+	Lock     transaction.Lock `msgpack:"lock"`
+	Receiver string           `msgpack:"receiver"`
+	Spice    spice.Melange    `msgpack:"spice"`
+	Hash     [32]byte         `msgpack:"hash"`
+}
+
+func (lf *lockedFunds) encode() ([]byte, error) {
+	return msgpack.Marshal(*lf)
+}
+
+func decodeLockedFunds(buf []byte) (lockedFunds, error) {
+	var lf lockedFunds
+	err := msgpackv2.Unmarshal(buf, &lf)
+	return lf, err
+}
+
+func fundsLockKey(receiver string, trxHash [32]byte) []byte {
+	key := make([]byte, 0, len(fundsLockPrefix)+len(receiver)+1+len(trxHash))
+	key = append(key, fundsLockPrefix...)
+	key = append(key, addressIndexPrefix(receiver)...)
+	return append(key, trxHash[:]...)
+}
+
+func fundsReleaseKey(escrowHash [32]byte, issuer string) []byte {
+	key := make([]byte, 0, len(fundsReleasePrefix)+len(escrowHash)+len(issuer))
+	key = append(key, fundsReleasePrefix...)
+	key = append(key, escrowHash[:]...)
+	return append(key, []byte(issuer)...)
+}
+
+func isFundsLockKey(key []byte) bool {
+	return hasPrefix(key, fundsLockPrefix) || hasPrefix(key, fundsReleasePrefix)
+}
+
+func hasPrefix(key, prefix []byte) bool {
+	return len(key) > len(prefix) && string(key[:len(prefix)]) == string(prefix)
+}
+
+// fundsLocks collects locked transfers per receiver and issuers of escrow releases per escrow transaction hash.
+// Anyone can issue the release, so all the issuers are kept and only the release of the escrow arbiter unlocks the funds.
+type fundsLocks struct {
+	locked   map[string]map[[32]byte]lockedFunds
+	released map[[32]byte]map[string]struct{}
+}
+
+func newFundsLocks() fundsLocks {
+	return fundsLocks{
+		locked:   make(map[string]map[[32]byte]lockedFunds),
+		released: make(map[[32]byte]map[string]struct{}),
+	}
+}
+
+func (fl *fundsLocks) lock(lf lockedFunds) {
+	m, ok := fl.locked[lf.Receiver]
+	if !ok {
+		m = make(map[[32]byte]lockedFunds)
+		fl.locked[lf.Receiver] = m
+	}
+	m[lf.Hash] = lf
+}
+
+func (fl *fundsLocks) release(escrowHash [32]byte, issuer string) {
+	issuers, ok := fl.released[escrowHash]
+	if !ok {
+		issuers = make(map[string]struct{})
+		fl.released[escrowHash] = issuers
+	}
+	issuers[issuer] = struct{}{}
+}
+
+// releasedBy returns the arbiter if the arbiter issued the release of the escrow, otherwise returns empty string.
+func (fl *fundsLocks) releasedBy(escrowHash [32]byte, arbiter string) string {
+	if _, ok := fl.released[escrowHash][arbiter]; ok {
+		return arbiter
+	}
+	return ""
+}
+
+// nextVertex records the locks and the escrow releases carried by the vertex transactions.
+func (fl *fundsLocks) nextVertex(vrx *Vertex) {
+	for _, trx := range vrx.Transactions() {
+		fl.nextTransaction(trx)
+	}
+}
+
+// nextTransaction records the lock or the escrow release carried by the transaction.
+func (fl *fundsLocks) nextTransaction(trx *transaction.Transaction) {
+	switch {
+	case trx.IsEscrowRelease():
+		fl.release(trx.Releases, trx.IssuerAddress)
+	case trx.IsLocked():
+		fl.lock(lockedFunds{
+			Lock:     trx.Lock,
+			Receiver: trx.ReceiverAddress,
+			Spice:    trx.Spice,
+			Hash:     trx.Hash,
+		})
+	}
+}
+
+// revertVertex removes the locks and the escrow releases carried by the vertex transactions.
+func (fl *fundsLocks) revertVertex(vrx *Vertex) {
+	for _, trx := range vrx.Transactions() {
+		switch {
+		case trx.IsEscrowRelease():
+			issuers := fl.released[trx.Releases]
+			delete(issuers, trx.IssuerAddress)
+			if len(issuers) == 0 {
+				delete(fl.released, trx.Releases)
+			}
+		case trx.IsLocked():
+			m := fl.locked[trx.ReceiverAddress]
+			delete(m, trx.Hash)
+			if len(m) == 0 {
+				delete(fl.locked, trx.ReceiverAddress)
+			}
+		}
+	}
+}
+
+// lockedFor sums the spice received by the address that is still locked at the given time.
+func (fl *fundsLocks) lockedFor(address string, at time.Time) (spice.Melange, error) {
+	locked := spice.New(0, 0)
+	for h, lf := range fl.locked[address] {
+		if lf.Lock.Unlocked(at, fl.releasedBy(h, lf.Lock.ArbiterAddress)) {
+			continue
+		}
+		if err := locked.Supply(lf.Spice); err != nil {
+			return spice.Melange{}, errors.Join(ErrUnexpected, err)
+		}
+	}
+	return locked, nil
+}
+
+// prune drops the locks that are unlocked at the given time together with their releases.
+// Once met, lock conditions stay met, so pruned locks no longer affect the balance.
+func (fl *fundsLocks) prune(at time.Time) {
+	for address, m := range fl.locked {
+		for h, lf := range m {
+			if lf.Lock.Unlocked(at, fl.releasedBy(h, lf.Lock.ArbiterAddress)) {
+				delete(m, h)
+				delete(fl.released, h)
+			}
+		}
+		if len(m) == 0 {
+			delete(fl.locked, address)
+		}
+	}
+}
+
+// availableFunds drains the still locked spice from the funds of the address.
+// Returns zero if the address spent more than its unlocked funds.
+func availableFunds(address string, funds spice.Melange, locks *fundsLocks, at time.Time) (spice.Melange, error) {
+	locked, err := locks.lockedFor(address, at)
+	if err != nil {
+		return spice.Melange{}, err
+	}
+	if err := funds.Drain(locked, &spice.Melange{}); err != nil {
+		return spice.New(0, 0), nil
+	}
+	return funds, nil
+}
+
+// saveFundsLocks replaces the outstanding locks and escrow releases stored in the vertices repository.
+func saveFundsLocks(txn *badger.Txn, fl *fundsLocks) error {
+	for _, prefix := range [][]byte{fundsLockPrefix, fundsReleasePrefix} {
+		keys := make([][]byte, 0)
+		iter := txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+		for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
+			keys = append(keys, iter.Item().KeyCopy(nil))
+		}
+		iter.Close()
+		for _, k := range keys {
+			if err := txn.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	for address, m := range fl.locked {
+		for h, lf := range m {
+			buf, err := lf.encode()
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(fundsLockKey(address, h), buf); err != nil {
+				return err
+			}
+		}
+	}
+	for h, issuers := range fl.released {
+		for issuer := range issuers {
+			if err := txn.Set(fundsReleaseKey(h, issuer), []byte(issuer)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readFundsLocksFromStorage reads the stored locks of the given receiver, or all the stored locks if the receiver is empty,
+// together with all the stored escrow releases.
+func (ab *AccountingBook) readFundsLocksFromStorage(receiver string, fl *fundsLocks) error {
+	lockPrefix := fundsLockPrefix
+	if receiver != "" {
+		lockPrefix = append(append([]byte{}, fundsLockPrefix...), addressIndexPrefix(receiver)...)
+	}
+	if err := ab.verticesDB.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.IteratorOptions{PrefetchSize: prefetch, PrefetchValues: true, Prefix: lockPrefix})
+		defer iter.Close()
+		for iter.Seek(lockPrefix); iter.ValidForPrefix(lockPrefix); iter.Next() {
+			if err := iter.Item().Value(func(v []byte) error {
+				lf, err := decodeLockedFunds(v)
+				if err != nil {
+					return err
+				}
+				fl.lock(lf)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		it := txn.NewIterator(badger.IteratorOptions{PrefetchSize: prefetch, PrefetchValues: true, Prefix: fundsReleasePrefix})
+		defer it.Close()
+		for it.Seek(fundsReleasePrefix); it.ValidForPrefix(fundsReleasePrefix); it.Next() {
+			key := it.Item().Key()
+			if len(key) < len(fundsReleasePrefix)+32 {
+				return ErrUnexpected
+			}
+			escrowHash := [32]byte(key[len(fundsReleasePrefix) : len(fundsReleasePrefix)+32])
+			if err := it.Item().Value(func(v []byte) error {
+				fl.release(escrowHash, string(v))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		ab.log.Error(err.Error())
+		return ErrUnexpected
+	}
+	return nil
+}
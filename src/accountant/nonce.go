@@ -0,0 +1,179 @@
+package accountant
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// Highest nonces of the issuers are stored in the vertices repository next to the address funds when DAG is truncated.
+// Prefix contains the character that is not used in the wallet public address encoding.
+var issuerNoncePrefix = []byte("nonce_")
+
+var (
+	ErrNonceOutOfOrder = errors.New("transaction nonce is reused or out of order")
+	ErrNonceRequired   = errors.New("transaction created after nonce activation shall have a nonce")
+)
+
+func issuerNonceKey(address string) []byte {
+	key := make([]byte, 0, len(issuerNoncePrefix)+len(address))
+	key = append(key, issuerNoncePrefix...)
+	return append(key, []byte(address)...)
+}
+
+func isIssuerNonceKey(key []byte) bool {
+	return hasPrefix(key, issuerNoncePrefix)
+}
+
+// nonceInOrder returns true if the nonce is greater than the highest nonce of the issuer.
+// Transaction without nonce is in order only if the issuer never used the nonce.
+func nonceInOrder(nonce, highest uint64) bool {
+	return highest == 0 || nonce > highest
+}
+
+// nonceMissing returns true if the transaction has no nonce while it is created at or after the nonce activation.
+// Transactions created before the activation may have no nonce, so the ledger data created before nonces were introduced remains valid.
+// In the nonce legacy mode the nonce is never required.
+func (ab *AccountingBook) nonceMissing(trx *transaction.Transaction) bool {
+	return trx.Nonce == 0 && !ab.nonceLegacy && !trx.CreatedAt.Before(ab.nonceActivation)
+}
+
+// NextNonce returns the nonce the next transaction of the issuer shall have.
+func (ab *AccountingBook) NextNonce(issuer string) uint64 {
+	return ab.ledger.readNonce(issuer) + 1
+}
+
+// recordNonce keeps the highest nonce of the transaction issuer.
+func recordNonce(nonces map[string]uint64, trx *transaction.Transaction) {
+	if trx.Nonce > nonces[trx.IssuerAddress] {
+		nonces[trx.IssuerAddress] = trx.Nonce
+	}
+}
+
+// checkNonces checks the transactions nonces are in order with the highest nonces of their issuers in the ledger
+// and are increasing within the given transactions.
+func (ab *AccountingBook) checkNonces(trxs []*transaction.Transaction) error {
+	highest := make(map[string]uint64, 1)
+	for _, trx := range trxs {
+		if ab.nonceMissing(trx) {
+			return ErrNonceRequired
+		}
+		h, ok := highest[trx.IssuerAddress]
+		if !ok {
+			h = ab.ledger.readNonce(trx.IssuerAddress)
+		}
+		if !nonceInOrder(trx.Nonce, h) {
+			return ErrNonceOutOfOrder
+		}
+		highest[trx.IssuerAddress] = max(h, trx.Nonce)
+	}
+	return nil
+}
+
+// checkStoredNonces checks the vertex transactions nonces are in order with the highest nonces stored when DAG was truncated,
+// and that transactions created after the nonce activation have a nonce.
+// Nonces of vertices in the DAG are not compared, as the vertices created by different nodes may be received in any order.
+func (ab *AccountingBook) checkStoredNonces(vrx *Vertex) error {
+	for _, trx := range vrx.Transactions() {
+		if ab.nonceMissing(trx) {
+			return ErrNonceRequired
+		}
+		h, err := ab.readNonceFromStorage(trx.IssuerAddress)
+		if err != nil {
+			return err
+		}
+		if !nonceInOrder(trx.Nonce, h) {
+			return ErrNonceOutOfOrder
+		}
+	}
+	return nil
+}
+
+// saveNoncesToStorage saves the highest nonces of the issuers, nonce lower than the stored one is not saved.
+func (ab *AccountingBook) saveNoncesToStorage(nonces map[string]uint64) error {
+	return ab.verticesDB.Update(func(txn *badger.Txn) error {
+		return saveNonces(txn, nonces)
+	})
+}
+
+func saveNonces(txn *badger.Txn, nonces map[string]uint64) error {
+	for address, nonce := range nonces {
+		key := issuerNonceKey(address)
+		item, err := txn.Get(key)
+		switch err {
+		case nil:
+			var stored uint64
+			if err := item.Value(func(v []byte) error {
+				if len(v) != 8 {
+					return ErrUnexpected
+				}
+				stored = binary.LittleEndian.Uint64(v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if stored >= nonce {
+				continue
+			}
+		case badger.ErrKeyNotFound:
+		default:
+			return err
+		}
+		if err := txn.Set(key, binary.LittleEndian.AppendUint64(nil, nonce)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ab *AccountingBook) readNonceFromStorage(address string) (uint64, error) {
+	var nonce uint64
+	err := ab.verticesDB.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(issuerNonceKey(address))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(v []byte) error {
+			if len(v) != 8 {
+				return ErrUnexpected
+			}
+			nonce = binary.LittleEndian.Uint64(v)
+			return nil
+		})
+	})
+	switch err {
+	case nil:
+		return nonce, nil
+	case badger.ErrKeyNotFound:
+		return 0, nil
+	default:
+		ab.log.Error(err.Error())
+		return 0, ErrUnexpected
+	}
+}
+
+func (ab *AccountingBook) forEachNonceFromStorage(set func(address string, nonce uint64)) error {
+	if err := ab.verticesDB.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.IteratorOptions{PrefetchSize: prefetch, PrefetchValues: true, Prefix: issuerNoncePrefix})
+		defer iter.Close()
+		for iter.Seek(issuerNoncePrefix); iter.ValidForPrefix(issuerNoncePrefix); iter.Next() {
+			address := string(iter.Item().Key()[len(issuerNoncePrefix):])
+			if err := iter.Item().Value(func(v []byte) error {
+				if len(v) != 8 {
+					return ErrUnexpected
+				}
+				set(address, binary.LittleEndian.Uint64(v))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		ab.log.Error(err.Error())
+		return ErrUnexpected
+	}
+	return nil
+}
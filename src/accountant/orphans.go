@@ -0,0 +1,185 @@
+package accountant
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bartossh/Computantis/src/providers"
+)
+
+const (
+	defaultOrphanPoolSize  = 1000
+	defaultOrphanLongevity = time.Minute
+	orphanExpireTick       = time.Second * 5
+	missingParentsCapacity = 500
+)
+
+const (
+	orphanPoolSizeTelemetryGauge           = "orphan_pool_size"
+	orphanPoolMissingParentsTelemetryGauge = "orphan_pool_missing_parents"
+)
+
+var ErrOrphanPoolFull = errors.New("orphan pool is full")
+
+type orphan struct {
+	vrx     *Vertex
+	missing map[[32]byte]struct{}
+	addedAt time.Time
+}
+
+// orphanPool holds vertices which parents are not in the DAG yet.
+// Orphans are indexed by the missing parent hash, so they are released as soon as the parent arrives.
+// Each parent that becomes missing is scheduled for fetching from other nodes.
+type orphanPool struct {
+	mux       sync.Mutex
+	orphans   map[[32]byte]*orphan
+	byParent  map[[32]byte]map[[32]byte]struct{}
+	requests  chan [32]byte
+	size      int
+	longevity time.Duration
+	tele      providers.GaugeProvider
+}
+
+func newOrphanPool(size int, longevity time.Duration, tele providers.GaugeProvider) *orphanPool {
+	if size <= 0 {
+		size = defaultOrphanPoolSize
+	}
+	if longevity <= 0 {
+		longevity = defaultOrphanLongevity
+	}
+	return &orphanPool{
+		orphans:   make(map[[32]byte]*orphan),
+		byParent:  make(map[[32]byte]map[[32]byte]struct{}),
+		requests:  make(chan [32]byte, missingParentsCapacity),
+		size:      size,
+		longevity: longevity,
+		tele:      tele,
+	}
+}
+
+// run expires stale orphans until the context is done.
+func (p *orphanPool) run(ctx context.Context) {
+	ticker := time.NewTicker(orphanExpireTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.expire(now)
+		}
+	}
+}
+
+// add puts the vertex in to the pool to wait for the missing parents.
+// Adding an orphan that is already waiting is a no-op.
+func (p *orphanPool) add(vrx *Vertex, missing [][32]byte) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if _, ok := p.orphans[vrx.Hash]; ok {
+		return nil
+	}
+	if len(p.orphans) >= p.size {
+		return ErrOrphanPoolFull
+	}
+
+	o := &orphan{vrx: vrx, missing: make(map[[32]byte]struct{}, len(missing)), addedAt: time.Now()}
+	for _, h := range missing {
+		o.missing[h] = struct{}{}
+		children, ok := p.byParent[h]
+		if !ok {
+			children = make(map[[32]byte]struct{})
+			p.byParent[h] = children
+			p.schedule(h)
+		}
+		children[vrx.Hash] = struct{}{}
+	}
+	p.orphans[vrx.Hash] = o
+	p.report()
+
+	return nil
+}
+
+// release marks the parent as arrived and returns the orphans that have no missing parents left.
+// Returned orphans are removed from the pool.
+func (p *orphanPool) release(parent [32]byte) []*Vertex {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	children, ok := p.byParent[parent]
+	if !ok {
+		return nil
+	}
+	delete(p.byParent, parent)
+
+	released := make([]*Vertex, 0, len(children))
+	for h := range children {
+		o, ok := p.orphans[h]
+		if !ok {
+			continue
+		}
+		delete(o.missing, parent)
+		if len(o.missing) > 0 {
+			continue
+		}
+		delete(p.orphans, h)
+		released = append(released, o.vrx)
+	}
+	p.report()
+
+	return released
+}
+
+// expire removes orphans waiting longer than the pool longevity and returns the number of removed orphans.
+func (p *orphanPool) expire(now time.Time) int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	var expired int
+	for h, o := range p.orphans {
+		if now.Sub(o.addedAt) < p.longevity {
+			continue
+		}
+		for parent := range o.missing {
+			children, ok := p.byParent[parent]
+			if !ok {
+				continue
+			}
+			delete(children, h)
+			if len(children) == 0 {
+				delete(p.byParent, parent)
+			}
+		}
+		delete(p.orphans, h)
+		expired++
+	}
+	if expired > 0 {
+		p.report()
+	}
+
+	return expired
+}
+
+// len returns the number of orphans and the number of missing parents they wait for.
+func (p *orphanPool) len() (orphans, parents int) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.orphans), len(p.byParent)
+}
+
+// schedule requests fetching the missing parent. When the requests are not consumed fast enough
+// the request is dropped and the orphan expires if the parent never arrives.
+func (p *orphanPool) schedule(h [32]byte) {
+	select {
+	case p.requests <- h:
+	default:
+	}
+}
+
+func (p *orphanPool) report() {
+	p.tele.SetGauge(orphanPoolSizeTelemetryGauge, float64(len(p.orphans)))
+	p.tele.SetGauge(orphanPoolMissingParentsTelemetryGauge, float64(len(p.byParent)))
+}
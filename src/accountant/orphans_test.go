@@ -0,0 +1,130 @@
+package accountant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bartossh/Computantis/src/logging"
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/bartossh/Computantis/src/wallet"
+	"gotest.tools/v3/assert"
+)
+
+func TestOrphanPool(t *testing.T) {
+	p := newOrphanPool(2, time.Minute, &telemetryMock{})
+
+	parentA, parentB, parentC := [32]byte{1}, [32]byte{2}, [32]byte{3}
+	first := &Vertex{Hash: [32]byte{10}, LeftParentHash: parentA, RightParentHash: parentB}
+	second := &Vertex{Hash: [32]byte{11}, LeftParentHash: parentB, RightParentHash: parentB}
+	third := &Vertex{Hash: [32]byte{12}, LeftParentHash: parentC, RightParentHash: parentC}
+
+	assert.NilError(t, p.add(first, [][32]byte{parentA, parentB}))
+	assert.NilError(t, p.add(second, [][32]byte{parentB}))
+	assert.NilError(t, p.add(second, [][32]byte{parentB}))
+	assert.ErrorIs(t, p.add(third, [][32]byte{parentC}), ErrOrphanPoolFull)
+
+	orphans, parents := p.len()
+	assert.Equal(t, orphans, 2)
+	assert.Equal(t, parents, 2)
+	assert.Equal(t, <-p.requests, parentA)
+	assert.Equal(t, <-p.requests, parentB)
+	assert.Equal(t, len(p.requests), 0)
+
+	released := p.release(parentB)
+	assert.Equal(t, len(released), 1)
+	assert.Equal(t, released[0].Hash, second.Hash)
+
+	released = p.release(parentA)
+	assert.Equal(t, len(released), 1)
+	assert.Equal(t, released[0].Hash, first.Hash)
+
+	assert.NilError(t, p.add(third, [][32]byte{parentC}))
+	assert.Equal(t, p.expire(time.Now()), 0)
+	assert.Equal(t, p.expire(time.Now().Add(time.Minute)), 1)
+	orphans, parents = p.len()
+	assert.Equal(t, orphans, 0)
+	assert.Equal(t, parents, 0)
+	assert.Equal(t, len(p.release(parentC)), 0)
+}
+
+func TestAddLeafReleasesOrphans(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signerA, err := wallet.New()
+	assert.NilError(t, err)
+	signerB, err := wallet.New()
+	assert.NilError(t, err)
+	a, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerA, &telemetryMock{}, l)
+	assert.NilError(t, err)
+	b, err := NewAccountingBook(ctx, Config{NonceLegacy: true}, verifier, &signerB, &telemetryMock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = a.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	ctxLoad, cancelLoad := context.WithCancelCause(ctx)
+	defer cancelLoad(nil)
+	chVrx := make(chan *Vertex, 10)
+	for vrx := range a.StreamDAG(ctx) {
+		chVrx <- vrx
+	}
+	close(chVrx)
+	b.LoadDag(cancelLoad, chVrx)
+	assert.NilError(t, context.Cause(ctxLoad))
+
+	numberOfTransactions := 5
+	vertices := make([]Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		vrx, err := a.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		vertices = append(vertices, vrx)
+	}
+
+	for i := len(vertices) - 1; i > 0; i-- {
+		vrx := vertices[i]
+		err := b.AddLeaf(ctx, &vrx)
+		assert.ErrorIs(t, err, ErrParentDoesNotExists)
+	}
+	orphans, _ := b.orphans.len()
+	assert.Equal(t, orphans, numberOfTransactions-1)
+
+	select {
+	case h := <-b.MissingParents():
+		assert.Equal(t, h, vertices[numberOfTransactions-2].Hash)
+	default:
+		t.Fatal("missing parent has not been scheduled")
+	}
+
+	err = b.AddLeaf(ctx, &vertices[0])
+	assert.NilError(t, err)
+	orphans, parents := b.orphans.len()
+	assert.Equal(t, orphans, 0)
+	assert.Equal(t, parents, 0)
+
+	for _, vrx := range vertices {
+		_, err := b.ReadVertex(ctx, vrx.Hash)
+		assert.NilError(t, err)
+	}
+	balance, err := b.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(uint64(numberOfTransactions*10), 0))
+}
@@ -2,6 +2,7 @@ package accountant
 
 import (
 	"errors"
+	"time"
 
 	"github.com/bartossh/Computantis/src/spice"
 )
@@ -13,11 +14,13 @@ type precalculatedFounds struct {
 }
 
 type fundsMemMap struct {
-	m map[string]precalculatedFounds
+	m      map[string]precalculatedFounds
+	locks  fundsLocks
+	nonces map[string]uint64
 }
 
 func newFoundsMemMap() fundsMemMap {
-	return fundsMemMap{m: make(map[string]precalculatedFounds)}
+	return fundsMemMap{m: make(map[string]precalculatedFounds), locks: newFundsLocks(), nonces: make(map[string]uint64)}
 }
 
 func (f *fundsMemMap) set(address string, s *spice.Melange) {
@@ -35,11 +38,19 @@ func (f *fundsMemMap) nextVertex(vrx *Vertex) error {
 		return errors.Join(ErrUnexpected, errors.New("next vertex cannot be nil"))
 	}
 
-	if !vrx.Transaction.IsSpiceTransfer() {
-		return nil
+	f.locks.nextVertex(vrx)
+
+	if !vrx.Fee.Empty() {
+		f.updateFounds(vrx.Transaction.IssuerAddress, vrx.SignerPublicAddress, &vrx.Fee)
 	}
 
-	f.updateFounds(vrx.Transaction.IssuerAddress, vrx.Transaction.ReceiverAddress, &vrx.Transaction.Spice)
+	for _, trx := range vrx.Transactions() {
+		recordNonce(f.nonces, trx)
+		if !trx.IsSpiceTransfer() {
+			continue
+		}
+		f.updateFounds(trx.IssuerAddress, trx.ReceiverAddress, &trx.Spice)
+	}
 
 	return nil
 }
@@ -53,12 +64,19 @@ func (f *fundsMemMap) updateFounds(issuer, receiver string, s *spice.Melange) {
 	f.m[receiver] = rp
 }
 
-func (f *fundsMemMap) saveToStorage(saveFoundsToStorage func(address string, s spice.Melange) error) error {
+func (f *fundsMemMap) saveToStorage(
+	saveFoundsToStorage func(address string, s spice.Melange) error, saveLocksToStorage func(fl *fundsLocks) error,
+	saveNoncesToStorage func(nonces map[string]uint64) error,
+) error {
 	for address, pf := range f.m {
 		pf.in.Drain(pf.out, &spice.Melange{})
 		if err := saveFoundsToStorage(address, pf.in); err != nil {
 			return err
 		}
 	}
-	return nil
+	if err := saveNoncesToStorage(f.nonces); err != nil {
+		return err
+	}
+	f.locks.prune(time.Now())
+	return saveLocksToStorage(&f.locks)
 }
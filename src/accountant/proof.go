@@ -0,0 +1,76 @@
+package accountant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bartossh/Computantis/src/inclusion"
+)
+
+func vertexToInclusionStep(vrx *Vertex) inclusion.Step {
+	return inclusion.Step{
+		SignerPublicAddress: vrx.SignerPublicAddress,
+		CreatedAt:           vrx.CreatedAt,
+		Signature:           vrx.Signature,
+		TrxHash:             inclusion.MerkleRoot(vrx.TransactionsHashes()),
+		Hash:                vrx.Hash,
+		LeftParentHash:      vrx.LeftParentHash,
+		RightParentHash:     vrx.RightParentHash,
+		Weight:              vrx.Weight,
+		Fee:                 vrx.Fee,
+	}
+}
+
+// ReadInclusionProof creates the proof of inclusion of the transaction with given hash in the DAG.
+// The proof leads from the vertex containing the transaction to one of the DAG leaves,
+// so it is only available for transactions in vertices that have not been truncated from the DAG.
+func (ab *AccountingBook) ReadInclusionProof(ctx context.Context, trxHash [32]byte) (inclusion.Proof, error) {
+	vrxHash, err := ab.readVertexHashContainingTrxHashFromStorage(trxHash)
+	if err != nil {
+		return inclusion.Proof{}, ErrTrxToVertexNotfound
+	}
+
+	ab.mux.RLock()
+	defer ab.mux.RUnlock()
+
+	vrx, err := ab.readVertexFromDAG(vrxHash)
+	if err != nil {
+		if errors.Is(err, ErrVertexHashNotfound) {
+			return inclusion.Proof{}, ErrInclusionProofUnavailable
+		}
+		return inclusion.Proof{}, err
+	}
+
+	proof := inclusion.Proof{TrxHash: trxHash, Steps: []inclusion.Step{vertexToInclusionStep(&vrx)}}
+	if len(vrx.Batch) > 0 {
+		proof.Batch = vrx.TransactionsHashes()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return inclusion.Proof{}, ErrInclusionProofProcessStopped
+		default:
+		}
+
+		children, err := ab.dag.GetChildren(string(vrx.Hash[:]))
+		if err != nil {
+			return inclusion.Proof{}, errors.Join(ErrUnexpected, err)
+		}
+		if len(children) == 0 {
+			return proof, nil
+		}
+
+		var next *Vertex
+		for _, item := range children {
+			child, ok := item.(*Vertex)
+			if !ok || child == nil {
+				return inclusion.Proof{}, errors.Join(ErrUnexpected, errors.New("inclusion proof, cannot cast item to vertex"))
+			}
+			if next == nil || child.Weight > next.Weight {
+				next = child
+			}
+		}
+		vrx = *next
+		proof.Steps = append(proof.Steps, vertexToInclusionStep(&vrx))
+	}
+}
@@ -0,0 +1,115 @@
+package accountant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+const restoreMaxPendingWrites = 256
+
+// RestoreFromBackups rebuilds the vertices storage, transaction to vertex mapping, addresses index,
+// stored funds and funds snapshots from the chain of incremental truncate backups given in the order they have been created.
+// Every vertex signature is verified before any vertex is accepted, and a single invalid vertex rejects the whole restore.
+// Stored funds are calculated from the restored vertices and never read from the backups.
+// Restore is only possible before the DAG is loaded.
+func (ab *AccountingBook) RestoreFromBackups(ctx context.Context, backups []string) error {
+	ab.mux.Lock()
+	defer ab.mux.Unlock()
+
+	if ab.dag.GetSize() != 0 {
+		return ErrRestoreOnLoadedDAG
+	}
+
+	ctxDB, cancel := context.WithCancel(ctx)
+	defer cancel()
+	db, err := createBadgerDB(ctxDB, "", ab.log, false)
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	defer db.Close()
+
+	for _, p := range backups {
+		if err := loadBackup(db, p); err != nil {
+			return err
+		}
+	}
+
+	if err := forEachVertexInDB(ctx, db, func(vrx *Vertex) error {
+		if err := vrx.Verify(ab.verifier); err != nil {
+			return errors.Join(ErrBackupVertexRejected, fmt.Errorf("vertex [ %v ], %w", vrx.Hash, err))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fm := newFoundsMemMap()
+	if err := ab.forEachfundFromStorage(fm.set); err != nil {
+		return err
+	}
+	if err := ab.readFundsLocksFromStorage("", &fm.locks); err != nil {
+		return err
+	}
+
+	round, err := ab.nextFundsSnapshotRound()
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+	sr := newFundsSnapshotRound(round)
+
+	var restored int
+	save := func(vrx *Vertex) error {
+		if err := ab.saveVertexToStorage(vrx); err != nil {
+			if errors.Is(err, ErrVertexAlreadyExists) {
+				return nil
+			}
+			return errors.Join(ErrUnexpected, err)
+		}
+		if err := ab.saveTrxsInVertex(vrx); err != nil && !errors.Is(err, ErrTrxInVertexAlreadyExists) {
+			return errors.Join(ErrUnexpected, err)
+		}
+		if err := ab.snapshotTruncatedVertex(&sr, vrx); err != nil {
+			return errors.Join(ErrUnexpected, err)
+		}
+		restored++
+		return fm.nextVertex(vrx)
+	}
+	if err := forEachVertexInDB(ctx, db, save); err != nil {
+		return err
+	}
+
+	if err := ab.commitTruncation(&fm, &sr); err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+
+	ab.ledger = newBalanceLedger()
+	if err := ab.forEachfundFromStorage(ab.ledger.set); err != nil {
+		return err
+	}
+	if err := ab.readFundsLocksFromStorage("", &ab.ledger.funds.locks); err != nil {
+		return err
+	}
+	if err := ab.forEachNonceFromStorage(ab.ledger.setNonce); err != nil {
+		return err
+	}
+
+	ab.log.Info(fmt.Sprintf("Accounting book restored [ %v ] vertices from [ %v ] backups.", restored, len(backups)))
+
+	return nil
+}
+
+func loadBackup(db *badger.DB, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := db.Load(f, restoreMaxPendingWrites); err != nil {
+		return errors.Join(ErrBackupCorrupted, fmt.Errorf("backup [ %s ], %w", path, err))
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package accountant
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/dgraph-io/badger/v4"
+	msgpackv2 "github.com/shamaton/msgpack/v2"
+	"github.com/vmihailenco/msgpack"
+)
+
+// Funds snapshots are stored in the vertices repository next to the address funds when DAG is truncated,
+// so they are backed up together with the truncated vertices.
+// Prefix contains the character that is not used in the wallet public address encoding.
+var (
+	fundsSnapshotPrefix   = []byte("snapshot_")
+	fundsSnapshotRoundKey = []byte("snapshot_round")
+)
+
+// escrowRelease is the escrow release stored in the funds snapshot.
+type escrowRelease struct {
+	Escrow [32]byte `msgpack:"escrow"`
+	Issuer string   `msgpack:"issuer"`
+}
+
+// fundsSnapshot holds the spice the address received and spent in the vertices truncated up to the round,
+// together with the locks that are not unlocked at the creation time of the latest of those vertices.
+// Snapshot is valid for the balance at any time that is not before the snapshot time.
+type fundsSnapshot struct {
+	At       time.Time       `msgpack:"at"`
+	Round    uint64          `msgpack:"round"`
+	In       spice.Melange   `msgpack:"in"`
+	Out      spice.Melange   `msgpack:"out"`
+	Locks    []lockedFunds   `msgpack:"locks"`
+	Releases []escrowRelease `msgpack:"releases"`
+}
+
+func (fs *fundsSnapshot) encode() ([]byte, error) {
+	return msgpack.Marshal(*fs)
+}
+
+func decodeFundsSnapshot(buf []byte) (fundsSnapshot, error) {
+	var fs fundsSnapshot
+	err := msgpackv2.Unmarshal(buf, &fs)
+	return fs, err
+}
+
+func (fs *fundsSnapshot) fundsLocks() fundsLocks {
+	fl := newFundsLocks()
+	for _, lf := range fs.Locks {
+		fl.lock(lf)
+	}
+	for _, r := range fs.Releases {
+		fl.release(r.Escrow, r.Issuer)
+	}
+	return fl
+}
+
+func fundsSnapshotAddressPrefix(address string) []byte {
+	prefix := make([]byte, 0, len(fundsSnapshotPrefix)+len(address)+1)
+	prefix = append(prefix, fundsSnapshotPrefix...)
+	return append(prefix, addressIndexPrefix(address)...)
+}
+
+func fundsSnapshotKey(address string, at time.Time, round uint64) []byte {
+	key := fundsSnapshotAddressPrefix(address)
+	key = binary.BigEndian.AppendUint64(key, uint64(at.UnixNano()))
+	return binary.BigEndian.AppendUint64(key, round)
+}
+
+func isFundsSnapshotKey(key []byte) bool {
+	return hasPrefix(key, fundsSnapshotPrefix)
+}
+
+// roundFunds are the funds of the address in the vertices truncated in the single round.
+type roundFunds struct {
+	at    time.Time
+	in    spice.Melange
+	out   spice.Melange
+	locks fundsLocks
+}
+
+// next returns the address snapshot following the previous one with the round funds added.
+func (rf *roundFunds) next(prev fundsSnapshot, round uint64) (fundsSnapshot, error) {
+	fs := fundsSnapshot{At: prev.At, Round: round, In: prev.In, Out: prev.Out}
+	if rf.at.After(fs.At) {
+		fs.At = rf.at
+	}
+	if err := fs.In.Supply(rf.in); err != nil {
+		return fundsSnapshot{}, errors.Join(ErrUnexpected, err)
+	}
+	if err := fs.Out.Supply(rf.out); err != nil {
+		return fundsSnapshot{}, errors.Join(ErrUnexpected, err)
+	}
+
+	fl := prev.fundsLocks()
+	for _, m := range rf.locks.locked {
+		for _, lf := range m {
+			fl.lock(lf)
+		}
+	}
+	for h, issuers := range rf.locks.released {
+		for issuer := range issuers {
+			fl.release(h, issuer)
+		}
+	}
+	fl.prune(fs.At)
+	for _, m := range fl.locked {
+		for _, lf := range m {
+			fs.Locks = append(fs.Locks, lf)
+		}
+	}
+	for h, issuers := range fl.released {
+		for issuer := range issuers {
+			fs.Releases = append(fs.Releases, escrowRelease{Escrow: h, Issuer: issuer})
+		}
+	}
+	return fs, nil
+}
+
+// fundsSnapshotRound collects the funds of the addresses touched by the vertices truncated in the same round.
+type fundsSnapshotRound struct {
+	round     uint64
+	addresses map[string]*roundFunds
+}
+
+func newFundsSnapshotRound(round uint64) fundsSnapshotRound {
+	return fundsSnapshotRound{round: round, addresses: make(map[string]*roundFunds)}
+}
+
+func (sr *fundsSnapshotRound) nextVertex(vrx *Vertex) error {
+	if vrx == nil {
+		return errors.Join(ErrUnexpected, errors.New("next vertex cannot be nil"))
+	}
+	for _, address := range vertexAddresses(vrx) {
+		rf, ok := sr.addresses[address]
+		if !ok {
+			rf = &roundFunds{locks: newFundsLocks()}
+			sr.addresses[address] = rf
+		}
+		if err := pourFunds(address, *vrx, &rf.in, &rf.out, &rf.locks); err != nil {
+			return err
+		}
+		if vrx.CreatedAt.After(rf.at) {
+			rf.at = vrx.CreatedAt
+		}
+	}
+	return nil
+}
+
+// snapshotTruncatedVertex adds the truncated vertex to the snapshot round and marks its addresses index entries with the round,
+// so the vertex is not counted twice when historical balance starts from the snapshot.
+func (ab *AccountingBook) snapshotTruncatedVertex(sr *fundsSnapshotRound, vrx *Vertex) error {
+	if err := sr.nextVertex(vrx); err != nil {
+		return err
+	}
+	return ab.saveVertexInAddressesIndexAtRound(vrx, sr.round)
+}
+
+// nextFundsSnapshotRound returns the round following the last saved snapshot round.
+func (ab *AccountingBook) nextFundsSnapshotRound() (uint64, error) {
+	var round uint64
+	err := ab.verticesDB.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(fundsSnapshotRoundKey)
+		if err != nil {
+			return err
+		}
+		return item.Value(func(v []byte) error {
+			if len(v) != 8 {
+				return ErrUnexpected
+			}
+			round = binary.BigEndian.Uint64(v)
+			return nil
+		})
+	})
+	switch err {
+	case nil, badger.ErrKeyNotFound:
+		return round + 1, nil
+	default:
+		return 0, err
+	}
+}
+
+// saveFundsSnapshots saves the snapshots of the addresses touched in the round, each following the previous snapshot of the address,
+// together with the round number.
+func saveFundsSnapshots(txn *badger.Txn, sr *fundsSnapshotRound) error {
+	for address, rf := range sr.addresses {
+		prev, _, err := readFundsSnapshot(txn, address, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		fs, err := rf.next(prev, sr.round)
+		if err != nil {
+			return err
+		}
+		buf, err := fs.encode()
+		if err != nil {
+			return err
+		}
+		if err := txn.Set(fundsSnapshotKey(address, fs.At, fs.Round), buf); err != nil {
+			return err
+		}
+	}
+	return txn.Set(fundsSnapshotRoundKey, binary.BigEndian.AppendUint64(nil, sr.round))
+}
+
+// readFundsSnapshotFromStorage reads the latest snapshot of the address funds that is valid at the given time.
+// Returns false if there is no such snapshot.
+func (ab *AccountingBook) readFundsSnapshotFromStorage(address string, at time.Time) (fundsSnapshot, bool, error) {
+	var fs fundsSnapshot
+	var ok bool
+	if err := ab.verticesDB.View(func(txn *badger.Txn) error {
+		var err error
+		fs, ok, err = readFundsSnapshot(txn, address, uint64(at.UnixNano()))
+		return err
+	}); err != nil {
+		return fundsSnapshot{}, false, err
+	}
+	return fs, ok, nil
+}
+
+func readFundsSnapshot(txn *badger.Txn, address string, untilNano uint64) (fundsSnapshot, bool, error) {
+	prefix := fundsSnapshotAddressPrefix(address)
+	iter := txn.NewIterator(badger.IteratorOptions{Reverse: true, Prefix: prefix})
+	defer iter.Close()
+
+	seek := binary.BigEndian.AppendUint64(append([]byte{}, prefix...), untilNano)
+	seek = binary.BigEndian.AppendUint64(seek, math.MaxUint64)
+	iter.Seek(seek)
+	if !iter.ValidForPrefix(prefix) {
+		return fundsSnapshot{}, false, nil
+	}
+	var fs fundsSnapshot
+	if err := iter.Item().Value(func(v []byte) error {
+		var err error
+		fs, err = decodeFundsSnapshot(v)
+		return err
+	}); err != nil {
+		return fundsSnapshot{}, false, err
+	}
+	return fs, true, nil
+}
@@ -2,6 +2,7 @@ package accountant
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"os"
@@ -19,6 +20,10 @@ const (
 	lastVertexKey = "last_vertex"
 )
 
+const addressesIndexBackfilledKey = "addresses_index_backfilled"
+
+const addressIndexSeparator = byte('|')
+
 func createBadgerDB(ctx context.Context, path string, l logger.Logger, detectConflicts bool) (*badger.DB, error) {
 	var opt badger.Options
 	switch path {
@@ -107,6 +112,57 @@ func (ab *AccountingBook) removeTrxInVertex(trxHash []byte) error {
 	})
 }
 
+// checkTrxsInVertexExist checks if any of the vertex transactions is already mapped to a vertex.
+func (ab *AccountingBook) checkTrxsInVertexExist(vrx *Vertex) (bool, error) {
+	for _, h := range vrx.TransactionsHashes() {
+		ok, err := ab.checkTrxInVertexExists(h[:])
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// saveTrxsInVertex maps all the vertex transactions to the vertex in a single database transaction.
+// Mapping already pointing to the same vertex is not an error, so the vertex may be replayed.
+func (ab *AccountingBook) saveTrxsInVertex(vrx *Vertex) error {
+	return ab.trxsToVertxDB.Update(func(txn *badger.Txn) error {
+		hashes := vrx.TransactionsHashes()
+		for i := range hashes {
+			h := hashes[i][:]
+			item, err := txn.Get(h)
+			if err == nil {
+				if err := item.Value(func(v []byte) error {
+					if string(v) != string(vrx.Hash[:]) {
+						return ErrTrxInVertexAlreadyExists
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := txn.SetEntry(badger.NewEntry(h, vrx.Hash[:])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// removeTrxsInVertex removes the mapping of all the vertex transactions.
+func (ab *AccountingBook) removeTrxsInVertex(vrx *Vertex) error {
+	return ab.trxsToVertxDB.Update(func(txn *badger.Txn) error {
+		hashes := vrx.TransactionsHashes()
+		for i := range hashes {
+			if err := txn.Delete(hashes[i][:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ab *AccountingBook) readTrxVertex(trxHash []byte) (Vertex, error) {
 	var vrxHash []byte
 	err := ab.trxsToVertxDB.View(func(txn *badger.Txn) error {
@@ -132,15 +188,13 @@ func (ab *AccountingBook) readTrxVertex(trxHash []byte) (Vertex, error) {
 	return ab.readVertex(vrxHash)
 }
 
-func (ab *AccountingBook) saveFundsToStorage(address string, s spice.Melange) error {
+func saveFunds(txn *badger.Txn, address string, s spice.Melange) error {
 	buf, err := s.Encode()
 	if err != nil {
 		return err
 	}
-	return ab.verticesDB.Update(func(txn *badger.Txn) error {
-		txn.Delete([]byte(address)) // to avoid conflicts
-		return txn.SetEntry(badger.NewEntry([]byte(address), buf))
-	})
+	txn.Delete([]byte(address)) // to avoid conflicts
+	return txn.SetEntry(badger.NewEntry([]byte(address), buf))
 }
 
 func (ab *AccountingBook) readAddressFundsFromStorage(address string) (spice.Melange, error) {
@@ -188,7 +242,7 @@ func (ab *AccountingBook) forEachfundFromStorage(set func(address string, s *spi
 			}
 			item := iter.Item()
 			k := item.Key()
-			if len(k) == 32 {
+			if len(k) == 32 || isFundsLockKey(k) || isIssuerNonceKey(k) || isFundsSnapshotKey(k) {
 				continue
 			}
 			if err := item.Value(func(v []byte) error {
@@ -305,7 +359,7 @@ func (ab *AccountingBook) readVertexHashContainingTrxHashFromStorage(hash [32]by
 	return vertexHash, nil
 }
 
-func (ab *AccountingBook) readTransactionFromStorage(vertexHash []byte) (transaction.Transaction, error) {
+func (ab *AccountingBook) readTransactionFromStorage(vertexHash []byte, trxHash [32]byte) (transaction.Transaction, error) {
 	var trx transaction.Transaction
 	if err := ab.verticesDB.View(func(txn *badger.Txn) error {
 		item, err := txn.Get(vertexHash)
@@ -317,15 +371,18 @@ func (ab *AccountingBook) readTransactionFromStorage(vertexHash []byte) (transac
 			return ErrEntityNotFound
 		}
 
-		item.Value(func(val []byte) error {
+		return item.Value(func(val []byte) error {
 			vrx, err := decodeVertex(val)
 			if err != nil {
 				return errors.Join(ErrUnexpected, err)
 			}
-			trx = vrx.Transaction
+			t, ok := vrx.transaction(trxHash)
+			if !ok {
+				return ErrEntityNotFound
+			}
+			trx = t
 			return nil
 		})
-		return nil
 	}); err != nil {
 		return trx, err
 	}
@@ -349,3 +406,239 @@ func (ab *AccountingBook) checkVertexExistInStorage(vrxHash []byte) (bool, error
 	}
 	return true, nil
 }
+
+func addressIndexPrefix(address string) []byte {
+	prefix := make([]byte, 0, len(address)+1)
+	prefix = append(prefix, []byte(address)...)
+	return append(prefix, addressIndexSeparator)
+}
+
+func addressIndexKey(address string, createdAt time.Time, vrxHash [32]byte) []byte {
+	key := addressIndexPrefix(address)
+	key = binary.BigEndian.AppendUint64(key, uint64(createdAt.UnixNano()))
+	return append(key, vrxHash[:]...)
+}
+
+func vertexAddresses(vrx *Vertex) []string {
+	addresses := []string{vrx.Transaction.IssuerAddress}
+	seen := map[string]struct{}{vrx.Transaction.IssuerAddress: {}}
+	for _, trx := range vrx.Transactions() {
+		if trx.ReceiverAddress == "" {
+			continue
+		}
+		if _, ok := seen[trx.ReceiverAddress]; ok {
+			continue
+		}
+		seen[trx.ReceiverAddress] = struct{}{}
+		addresses = append(addresses, trx.ReceiverAddress)
+	}
+	if !vrx.Fee.Empty() {
+		if _, ok := seen[vrx.SignerPublicAddress]; !ok {
+			addresses = append(addresses, vrx.SignerPublicAddress)
+		}
+	}
+	return addresses
+}
+
+// addressIndexEntry is the vertex hash indexed for the address
+// together with the funds snapshot round the vertex has been truncated in, or zero if vertex is not in any snapshot.
+type addressIndexEntry struct {
+	hash  [32]byte
+	round uint64
+}
+
+func decodeAddressIndexEntry(v []byte) (addressIndexEntry, error) {
+	switch len(v) {
+	case 32:
+		return addressIndexEntry{hash: [32]byte(v)}, nil
+	case 40:
+		return addressIndexEntry{hash: [32]byte(v[:32]), round: binary.BigEndian.Uint64(v[32:])}, nil
+	default:
+		return addressIndexEntry{}, fmt.Errorf("indexed vertex hash has wrong length, expected [ 32 ] or [ 40 ] got [ %v ]", len(v))
+	}
+}
+
+func (ab *AccountingBook) saveVertexInAddressesIndex(vrx *Vertex) error {
+	return ab.saveVertexInAddressesIndexAtRound(vrx, 0)
+}
+
+// saveVertexInAddressesIndexAtRound indexes the vertex for its addresses marking it with the funds snapshot round,
+// zero round marks the vertex that is not in any snapshot.
+func (ab *AccountingBook) saveVertexInAddressesIndexAtRound(vrx *Vertex, round uint64) error {
+	value := vrx.Hash[:]
+	if round != 0 {
+		value = binary.BigEndian.AppendUint64(append([]byte{}, vrx.Hash[:]...), round)
+	}
+	return ab.addressesIndexDB.Update(func(txn *badger.Txn) error {
+		for _, address := range vertexAddresses(vrx) {
+			if err := txn.SetEntry(badger.NewEntry(addressIndexKey(address, vrx.CreatedAt, vrx.Hash), value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ab *AccountingBook) removeVertexFromAddressesIndex(vrx *Vertex) error {
+	return ab.addressesIndexDB.Update(func(txn *badger.Txn) error {
+		for _, address := range vertexAddresses(vrx) {
+			if err := txn.Delete(addressIndexKey(address, vrx.CreatedAt, vrx.Hash)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillAddressesIndex indexes the vertices stored before the addresses index existed, so the history of the existing ledger is complete.
+// It runs only once, the finished backfill is marked in the addresses index.
+func (ab *AccountingBook) backfillAddressesIndex(ctx context.Context) error {
+	err := ab.addressesIndexDB.View(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(addressesIndexBackfilledKey))
+		return err
+	})
+	switch err {
+	case nil:
+		return nil
+	case badger.ErrKeyNotFound:
+	default:
+		return err
+	}
+
+	var indexed int
+	if err := forEachVertexInDB(ctx, ab.verticesDB, func(vrx *Vertex) error {
+		indexed++
+		return ab.saveVertexInAddressesIndex(vrx)
+	}); err != nil {
+		return err
+	}
+	if indexed > 0 {
+		ab.log.Info(fmt.Sprintf("Addresses index backfilled with [ %v ] stored vertices.", indexed))
+	}
+
+	return ab.addressesIndexDB.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(badger.NewEntry([]byte(addressesIndexBackfilledKey), []byte{1}))
+	})
+}
+
+func (ab *AccountingBook) readVerticesHashesForAddressFromIndex(address string, offset, limit int) ([][32]byte, error) {
+	hashes := make([][32]byte, 0, limit)
+	prefix := addressIndexPrefix(address)
+	if err := ab.addressesIndexDB.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.IteratorOptions{
+			PrefetchSize:   min(limit, prefetch),
+			PrefetchValues: true,
+			Prefix:         prefix,
+		})
+		if iter == nil {
+			return errors.New("cannot create iterator")
+		}
+		defer iter.Close()
+		var skipped int
+		for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			if len(hashes) == limit {
+				return nil
+			}
+			if err := iter.Item().Value(func(v []byte) error {
+				entry, err := decodeAddressIndexEntry(v)
+				if err != nil {
+					return err
+				}
+				hashes = append(hashes, entry.hash)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		ab.log.Error(fmt.Sprintf("addresses index failed when reading vertices hashes for address [ %s ], %s", address, err))
+		return nil, ErrUnexpected
+	}
+	return hashes, nil
+}
+
+func (ab *AccountingBook) readAddressIndexEntriesUntilFromIndex(address string, until time.Time) ([]addressIndexEntry, error) {
+	entries := make([]addressIndexEntry, 0, prefetch)
+	prefix := addressIndexPrefix(address)
+	untilNano := uint64(until.UnixNano())
+	if err := ab.addressesIndexDB.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.IteratorOptions{
+			PrefetchSize:   prefetch,
+			PrefetchValues: true,
+			Prefix:         prefix,
+		})
+		if iter == nil {
+			return errors.New("cannot create iterator")
+		}
+		defer iter.Close()
+		for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
+			key := iter.Item().Key()
+			if len(key) < len(prefix)+8 {
+				return fmt.Errorf("indexed key has wrong length [ %v ]", len(key))
+			}
+			if binary.BigEndian.Uint64(key[len(prefix):len(prefix)+8]) > untilNano {
+				return nil
+			}
+			if err := iter.Item().Value(func(v []byte) error {
+				entry, err := decodeAddressIndexEntry(v)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, entry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		ab.log.Error(fmt.Sprintf("addresses index failed when reading vertices hashes for address [ %s ] until [ %s ], %s", address, until, err))
+		return nil, ErrUnexpected
+	}
+	return entries, nil
+}
+
+func forEachVertexInDB(ctx context.Context, db *badger.DB, perform func(vrx *Vertex) error) error {
+	return db.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.IteratorOptions{
+			PrefetchSize:   prefetch,
+			PrefetchValues: true,
+		})
+		if iter == nil {
+			return errors.New("cannot create iterator")
+		}
+		defer iter.Close()
+		for iter.Rewind(); iter.Valid(); iter.Next() {
+			select {
+			case <-ctx.Done():
+				return ErrStreamingProcessStopped
+			default:
+			}
+			item := iter.Item()
+			k := item.Key()
+			if len(k) != 32 {
+				continue
+			}
+			var vrx Vertex
+			if err := item.Value(func(v []byte) error {
+				var err error
+				vrx, err = decodeVertex(v)
+				return err
+			}); err != nil {
+				return errors.Join(ErrVertexCorrupted, err)
+			}
+			if vrx.Hash != [32]byte(k) {
+				return errors.Join(ErrVertexCorrupted, fmt.Errorf("vertex [ %v ] stored under key [ %v ]", vrx.Hash, k))
+			}
+			if err := perform(&vrx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
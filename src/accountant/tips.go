@@ -0,0 +1,188 @@
+package accountant
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/heimdalr/dag"
+)
+
+// Tip selection strategies that can be set in the Config.
+const (
+	TipSelectionFirst         = "first"
+	TipSelectionUniformRandom = "uniform_random"
+	TipSelectionMCMC          = "mcmc"
+	TipSelectionOldestFirst   = "oldest_first"
+)
+
+const (
+	mcmcDefaultAlpha = 0.1
+	mcmcWalkDepth    = 10
+	mcmcWalks        = 2
+)
+
+// CumulativeWeight returns the number of vertices approving the vertex of given hash directly or indirectly.
+type CumulativeWeight func(h [32]byte) uint64
+
+// TipSelector orders the DAG leaves so the preferred parents for a new leaf come first.
+// Leaves are validated in the returned order and the first two valid leaves become the parents.
+// SelectTips is called while the AccountingBook holds the lock, so the DAG shall not be modified.
+type TipSelector interface {
+	SelectTips(g *dag.DAG, leaves []*Vertex, weight CumulativeWeight) []*Vertex
+}
+
+// NewTipSelector creates the TipSelector for the strategy set in the Config.
+// When the strategy is not set the leaves are taken in the order the DAG returns them.
+func NewTipSelector(cfg Config) (TipSelector, error) {
+	switch cfg.TipSelection {
+	case "", TipSelectionFirst:
+		return firstTipSelector{}, nil
+	case TipSelectionUniformRandom:
+		return uniformRandomTipSelector{}, nil
+	case TipSelectionOldestFirst:
+		return oldestFirstTipSelector{}, nil
+	case TipSelectionMCMC:
+		alpha := cfg.TipSelectionAlpha
+		if alpha <= 0 {
+			alpha = mcmcDefaultAlpha
+		}
+		return mcmcTipSelector{alpha: alpha, depth: mcmcWalkDepth}, nil
+	default:
+		return nil, ErrUnknownTipSelection
+	}
+}
+
+type firstTipSelector struct{}
+
+// SelectTips returns leaves in the order they are given.
+func (firstTipSelector) SelectTips(_ *dag.DAG, leaves []*Vertex, _ CumulativeWeight) []*Vertex {
+	return leaves
+}
+
+type uniformRandomTipSelector struct{}
+
+// SelectTips returns leaves in random order, each leaf having the same probability to be selected.
+func (uniformRandomTipSelector) SelectTips(_ *dag.DAG, leaves []*Vertex, _ CumulativeWeight) []*Vertex {
+	rand.Shuffle(len(leaves), func(i, j int) { leaves[i], leaves[j] = leaves[j], leaves[i] })
+	return leaves
+}
+
+type oldestFirstTipSelector struct{}
+
+// SelectTips returns leaves starting from the one created the earliest, so the tips waiting the longest get confirmed first.
+func (oldestFirstTipSelector) SelectTips(_ *dag.DAG, leaves []*Vertex, _ CumulativeWeight) []*Vertex {
+	sort.SliceStable(leaves, func(i, j int) bool {
+		if leaves[i].CreatedAt.Equal(leaves[j].CreatedAt) {
+			return leaves[i].Weight < leaves[j].Weight
+		}
+		return leaves[i].CreatedAt.Before(leaves[j].CreatedAt)
+	})
+	return leaves
+}
+
+// mcmcTipSelector selects tips performing the weighted random walk towards the leaves.
+// The walk starts from the vertex at the given depth below a random leaf and at each step moves to the child
+// with probability proportional to exp(alpha * cumulative weight), where cumulative weight is the number of vertices approving the child.
+// Cumulative weight is tracked by the AccountingBook up to the final approvers threshold, so the step costs only reading the children weights.
+// High alpha makes the walk prefer heavy branches, low alpha makes it closer to the uniform random selection.
+type mcmcTipSelector struct {
+	alpha float64
+	depth int
+}
+
+// SelectTips returns leaves reached by random walks first followed by the remaining leaves in random order.
+func (s mcmcTipSelector) SelectTips(g *dag.DAG, leaves []*Vertex, weight CumulativeWeight) []*Vertex {
+	if len(leaves) < 2 {
+		return leaves
+	}
+
+	byHash := make(map[[32]byte]*Vertex, len(leaves))
+	for _, leaf := range leaves {
+		byHash[leaf.Hash] = leaf
+	}
+
+	selected := make([]*Vertex, 0, len(leaves))
+	for i := 0; i < mcmcWalks; i++ {
+		tip := s.walk(g, weight, s.start(g, leaves[rand.Intn(len(leaves))]))
+		if leaf, ok := byHash[tip.Hash]; ok {
+			selected = append(selected, leaf)
+			delete(byHash, tip.Hash)
+		}
+	}
+
+	rest := make([]*Vertex, 0, len(byHash))
+	for _, leaf := range leaves {
+		if _, ok := byHash[leaf.Hash]; ok {
+			rest = append(rest, leaf)
+		}
+	}
+	rand.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+
+	return append(selected, rest...)
+}
+
+func (s mcmcTipSelector) start(g *dag.DAG, leaf *Vertex) *Vertex {
+	current := leaf
+	for i := 0; i < s.depth; i++ {
+		parentHash := current.LeftParentHash
+		if rand.Intn(2) == 1 {
+			parentHash = current.RightParentHash
+		}
+		item, err := g.GetVertex(string(parentHash[:]))
+		if err != nil {
+			return current
+		}
+		parent, ok := item.(*Vertex)
+		if !ok || parent == nil {
+			return current
+		}
+		current = parent
+	}
+	return current
+}
+
+func (s mcmcTipSelector) walk(g *dag.DAG, weight CumulativeWeight, current *Vertex) *Vertex {
+	for {
+		children, err := g.GetChildren(string(current.Hash[:]))
+		if err != nil || len(children) == 0 {
+			return current
+		}
+
+		candidates := make([]*Vertex, 0, len(children))
+		weights := make([]float64, 0, len(children))
+		var maxWeight float64
+		for _, item := range children {
+			child, ok := item.(*Vertex)
+			if !ok || child == nil {
+				continue
+			}
+			w := float64(weight(child.Hash) + 1)
+			if w > maxWeight {
+				maxWeight = w
+			}
+			candidates = append(candidates, child)
+			weights = append(weights, w)
+		}
+		if len(candidates) == 0 {
+			return current
+		}
+
+		var total float64
+		for i, w := range weights {
+			weights[i] = math.Exp(s.alpha * (w - maxWeight))
+			total += weights[i]
+		}
+
+		next := candidates[len(candidates)-1]
+		r := rand.Float64() * total
+		for i, w := range weights {
+			if r < w {
+				next = candidates[i]
+				break
+			}
+			r -= w
+		}
+		current = next
+	}
+}
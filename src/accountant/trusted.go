@@ -0,0 +1,190 @@
+package accountant
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	msgpackv2 "github.com/shamaton/msgpack/v2"
+	"github.com/vmihailenco/msgpack"
+)
+
+// Trusted nodes audit actions.
+const (
+	AuditActionAddTrustedNode    = "add_trusted_node"
+	AuditActionRemoveTrustedNode = "remove_trusted_node"
+)
+
+// Audit log entries are stored in the trusted nodes repository next to the trusted node addresses,
+// so the change and its audit entry are written in the same transaction.
+// Prefix contains the character that is not used in the wallet public address encoding.
+var auditLogPrefix = []byte("audit_")
+
+var (
+	ErrTrustedNodeAlreadyExists = errors.New("trusted node already exists")
+	ErrTrustedNodeNotFound      = errors.New("trusted node not found")
+)
+
+// AuditEntry is an append only record of the change in the trusted nodes repository.
+type AuditEntry struct {
+	CreatedAt    time.Time `msgpack:"created_at"`
+	Action       string    `msgpack:"action"`
+	NodeAddress  string    `msgpack:"node_address"`
+	AdminAddress string    `msgpack:"admin_address"`
+	Sequence     uint64    `msgpack:"sequence"`
+}
+
+func (e *AuditEntry) encode() ([]byte, error) {
+	return msgpack.Marshal(*e)
+}
+
+func decodeAuditEntry(buf []byte) (AuditEntry, error) {
+	var e AuditEntry
+	err := msgpackv2.Unmarshal(buf, &e)
+	return e, err
+}
+
+func auditLogKey(sequence uint64) []byte {
+	key := make([]byte, len(auditLogPrefix)+8)
+	copy(key, auditLogPrefix)
+	binary.BigEndian.PutUint64(key[len(auditLogPrefix):], sequence)
+	return key
+}
+
+func isAuditLogKey(key []byte) bool {
+	return len(key) == len(auditLogPrefix)+8 && string(key[:len(auditLogPrefix)]) == string(auditLogPrefix)
+}
+
+// readLastAuditSequence reads the sequence of the latest audit entry, or zero if the audit log is empty.
+func (ab *AccountingBook) readLastAuditSequence() (uint64, error) {
+	var sequence uint64
+	err := ab.trustedNodesDB.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		opts.PrefetchValues = false
+		opts.Prefix = auditLogPrefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		it.Seek(auditLogKey(^uint64(0)))
+		if !it.Valid() {
+			return nil
+		}
+		key := it.Item().Key()
+		if !isAuditLogKey(key) {
+			return ErrUnexpected
+		}
+		sequence = binary.BigEndian.Uint64(key[len(auditLogPrefix):])
+		return nil
+	})
+	return sequence, err
+}
+
+// AddTrustedNode adds trusted node public address to the trusted nodes public address repository.
+// Change is recorded in the audit log together with the address of the admin requesting it.
+func (ab *AccountingBook) AddTrustedNode(adminAddress, trustedNodePublicAddress string) error {
+	return ab.changeTrustedNodes(AuditActionAddTrustedNode, adminAddress, trustedNodePublicAddress)
+}
+
+// RemoveTrustedNode removes trusted node public address from trusted nodes public address repository.
+// Change is recorded in the audit log together with the address of the admin requesting it.
+func (ab *AccountingBook) RemoveTrustedNode(adminAddress, trustedNodePublicAddress string) error {
+	return ab.changeTrustedNodes(AuditActionRemoveTrustedNode, adminAddress, trustedNodePublicAddress)
+}
+
+func (ab *AccountingBook) changeTrustedNodes(action, adminAddress, trustedNodePublicAddress string) error {
+	if trustedNodePublicAddress == "" || isAuditLogKey([]byte(trustedNodePublicAddress)) {
+		return ErrTrustedNodeNotFound
+	}
+
+	ab.auditMux.Lock()
+	defer ab.auditMux.Unlock()
+
+	entry := AuditEntry{
+		CreatedAt:    time.Now(),
+		Action:       action,
+		NodeAddress:  trustedNodePublicAddress,
+		AdminAddress: adminAddress,
+		Sequence:     ab.auditSequence + 1,
+	}
+	buf, err := entry.encode()
+	if err != nil {
+		return errors.Join(ErrUnexpected, err)
+	}
+
+	if err := ab.trustedNodesDB.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(trustedNodePublicAddress))
+		switch {
+		case err == nil && action == AuditActionAddTrustedNode:
+			return ErrTrustedNodeAlreadyExists
+		case errors.Is(err, badger.ErrKeyNotFound) && action == AuditActionRemoveTrustedNode:
+			return ErrTrustedNodeNotFound
+		case err != nil && !errors.Is(err, badger.ErrKeyNotFound):
+			return err
+		}
+
+		switch action {
+		case AuditActionAddTrustedNode:
+			err = txn.Set([]byte(trustedNodePublicAddress), []byte{})
+		default:
+			err = txn.Delete([]byte(trustedNodePublicAddress))
+		}
+		if err != nil {
+			return err
+		}
+		return txn.Set(auditLogKey(entry.Sequence), buf)
+	}); err != nil {
+		return err
+	}
+
+	ab.auditSequence = entry.Sequence
+	return nil
+}
+
+// ReadTrustedNodes reads all the trusted nodes public addresses.
+func (ab *AccountingBook) ReadTrustedNodes() ([]string, error) {
+	addresses := make([]string, 0)
+	err := ab.trustedNodesDB.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			key := it.Item().Key()
+			if isAuditLogKey(key) {
+				continue
+			}
+			addresses = append(addresses, string(key))
+		}
+		return nil
+	})
+	return addresses, err
+}
+
+// ReadAuditLog reads the trusted nodes audit log entries starting from the given sequence in the order they were recorded.
+func (ab *AccountingBook) ReadAuditLog(from uint64, limit int) ([]AuditEntry, error) {
+	if limit <= 0 {
+		return nil, ErrWrongPaginationParameters
+	}
+	entries := make([]AuditEntry, 0, limit)
+	err := ab.trustedNodesDB.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = auditLogPrefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(auditLogKey(from)); it.Valid() && len(entries) < limit; it.Next() {
+			if err := it.Item().Value(func(val []byte) error {
+				entry, err := decodeAuditEntry(val)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, entry)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
@@ -1,10 +1,12 @@
 package accountant
 
 import (
-	"bytes"
-	"encoding/binary"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/bartossh/Computantis/src/inclusion"
+	"github.com/bartossh/Computantis/src/spice"
 	"github.com/bartossh/Computantis/src/transaction"
 
 	msgpackv2 "github.com/shamaton/msgpack/v2"
@@ -15,34 +17,70 @@ func calcNewWeight(leftWeight, rightWeight uint64) uint64 {
 	return max(leftWeight, rightWeight) + 1
 }
 
+// MaxBatchSize is the maximum number of transactions a single vertex carries.
+const MaxBatchSize = 64
+
+var (
+	ErrBatchIsEmpty        = errors.New("batch has no transactions")
+	ErrBatchTooLarge       = fmt.Errorf("batch exceeds %v transactions", MaxBatchSize)
+	ErrBatchIssuerMismatch = errors.New("batch transactions shall have the same issuer")
+	ErrBatchDuplicatedTrx  = errors.New("batch contains duplicated transaction")
+)
+
 // Vertex is a Direct Acyclic Graph vertex that creates a AccountingBook inner graph.
+// Vertex carries the Transaction and optionally the Batch of further transactions of the same issuer,
+// the vertex signs the Merkle root of all the transactions hashes.
 type Vertex struct {
-	SignerPublicAddress string                  `msgpack:"signer_public_address"`
-	CreatedAt           time.Time               `msgpack:"created_at"`
-	Signature           []byte                  `msgpack:"signature"`
-	Transaction         transaction.Transaction `msgpack:"transaction"`
-	Hash                [32]byte                `msgpack:"hash"`
-	LeftParentHash      [32]byte                `msgpack:"left_parent_hash"`
-	RightParentHash     [32]byte                `msgpack:"right_parent_hash"`
-	Weight              uint64                  `msgpack:"weight"`
+	SignerPublicAddress string                    `msgpack:"signer_public_address"`
+	CreatedAt           time.Time                 `msgpack:"created_at"`
+	Signature           []byte                    `msgpack:"signature"`
+	Transaction         transaction.Transaction   `msgpack:"transaction"`
+	Batch               []transaction.Transaction `msgpack:"batch"`
+	Hash                [32]byte                  `msgpack:"hash"`
+	LeftParentHash      [32]byte                  `msgpack:"left_parent_hash"`
+	RightParentHash     [32]byte                  `msgpack:"right_parent_hash"`
+	Weight              uint64                    `msgpack:"weight"`
+	Fee                 spice.Melange             `msgpack:"fee"`
 }
 
 // NewVertex creates new Vertex but first validates transaction legitimacy.
 // It is assumed that the transaction is verified.
+// Fee is charged from the transaction issuer and paid to the vertex signer.
 func NewVertex(
 	trx transaction.Transaction,
 	leftParentHash, rightParentHash [32]byte,
-	weight uint64, signer Signer,
+	weight uint64, fee spice.Melange, signer Signer,
 ) (Vertex, error) {
+	return NewBatchVertex([]transaction.Transaction{trx}, leftParentHash, rightParentHash, weight, fee, signer)
+}
+
+// NewBatchVertex creates new Vertex carrying the batch of transactions of the same issuer.
+// It is assumed that the transactions are verified.
+func NewBatchVertex(
+	trxs []transaction.Transaction,
+	leftParentHash, rightParentHash [32]byte,
+	weight uint64, fee spice.Melange, signer Signer,
+) (Vertex, error) {
+	if len(trxs) == 0 {
+		return Vertex{}, ErrBatchIsEmpty
+	}
 	candidate := Vertex{
 		SignerPublicAddress: signer.Address(),
 		CreatedAt:           time.Now(),
 		Signature:           nil,
-		Transaction:         trx,
+		Transaction:         trxs[0],
+		Batch:               trxs[1:],
 		Hash:                [32]byte{},
 		LeftParentHash:      leftParentHash,
 		RightParentHash:     rightParentHash,
 		Weight:              weight,
+		Fee:                 fee,
+	}
+	if len(candidate.Batch) == 0 {
+		candidate.Batch = nil
+	}
+	if err := candidate.validateBatch(); err != nil {
+		return Vertex{}, err
 	}
 
 	candidate.sign(signer)
@@ -50,15 +88,68 @@ func NewVertex(
 	return candidate, nil
 }
 
+// Transactions returns all the transactions carried by the vertex, starting with the Transaction.
+func (v *Vertex) Transactions() []*transaction.Transaction {
+	trxs := make([]*transaction.Transaction, 0, len(v.Batch)+1)
+	trxs = append(trxs, &v.Transaction)
+	for i := range v.Batch {
+		trxs = append(trxs, &v.Batch[i])
+	}
+	return trxs
+}
+
+// TransactionsHashes returns hashes of all the transactions carried by the vertex, starting with the Transaction hash.
+func (v *Vertex) TransactionsHashes() [][32]byte {
+	hashes := make([][32]byte, 0, len(v.Batch)+1)
+	for _, trx := range v.Transactions() {
+		hashes = append(hashes, trx.Hash)
+	}
+	return hashes
+}
+
+// transaction returns the transaction of given hash carried by the vertex.
+func (v *Vertex) transaction(hash [32]byte) (transaction.Transaction, bool) {
+	for _, trx := range v.Transactions() {
+		if trx.Hash == hash {
+			return *trx, true
+		}
+	}
+	return transaction.Transaction{}, false
+}
+
+// addressTransactions returns the transactions carried by the vertex that given address appears in as issuer or receiver.
+func (v *Vertex) addressTransactions(address string) []transaction.Transaction {
+	var trxs []transaction.Transaction
+	for _, trx := range v.Transactions() {
+		if trx.ReceiverAddress == address || trx.IssuerAddress == address {
+			trxs = append(trxs, *trx)
+		}
+	}
+	return trxs
+}
+
+func (v *Vertex) validateBatch() error {
+	if len(v.Batch) == 0 {
+		return nil
+	}
+	if len(v.Batch)+1 > MaxBatchSize {
+		return ErrBatchTooLarge
+	}
+	hashes := make(map[[32]byte]struct{}, len(v.Batch)+1)
+	for _, trx := range v.Transactions() {
+		if trx.IssuerAddress != v.Transaction.IssuerAddress {
+			return ErrBatchIssuerMismatch
+		}
+		if _, ok := hashes[trx.Hash]; ok {
+			return ErrBatchDuplicatedTrx
+		}
+		hashes[trx.Hash] = struct{}{}
+	}
+	return nil
+}
+
 func (v *Vertex) initData() []byte {
-	blockData := make([]byte, 0, 16)
-	blockData = binary.LittleEndian.AppendUint64(blockData, uint64(v.CreatedAt.UnixNano()))
-	blockData = binary.LittleEndian.AppendUint64(blockData, uint64(v.Weight))
-	return bytes.Join([][]byte{
-		v.Transaction.Hash[:], v.LeftParentHash[:], v.RightParentHash[:], blockData,
-	},
-		[]byte{},
-	)
+	return inclusion.VertexMessage(inclusion.MerkleRoot(v.TransactionsHashes()), v.LeftParentHash, v.RightParentHash, v.CreatedAt, v.Weight, v.Fee)
 }
 
 func (v *Vertex) sign(signer Signer) {
@@ -66,15 +157,21 @@ func (v *Vertex) sign(signer Signer) {
 	v.Hash, v.Signature = signer.Sign(data)
 }
 
-func (v *Vertex) verify(verifier signatureVerifier) error {
-	switch len(v.Transaction.ReceiverSignature) != 0 {
-	case true:
-		if err := v.Transaction.VerifyIssuerReceiver(verifier); err != nil {
-			return err
-		}
-	default:
-		if err := v.Transaction.VerifyIssuer(verifier); err != nil {
-			return err
+// Verify verifies the vertex transactions signatures and the vertex signature.
+func (v *Vertex) Verify(verifier signatureVerifier) error {
+	if err := v.validateBatch(); err != nil {
+		return err
+	}
+	for _, trx := range v.Transactions() {
+		switch len(trx.ReceiverSignature) != 0 {
+		case true:
+			if err := trx.VerifyIssuerReceiver(verifier); err != nil {
+				return err
+			}
+		default:
+			if err := trx.VerifyIssuer(verifier); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -82,6 +179,33 @@ func (v *Vertex) verify(verifier signatureVerifier) error {
 	return verifier.Verify(data, v.Signature[:], v.Hash, v.SignerPublicAddress)
 }
 
+// spends returns true if the vertex moves any spice from the transaction issuer, either transferred or paid as a fee.
+func (v *Vertex) spends() bool {
+	if !v.Fee.Empty() {
+		return true
+	}
+	for _, trx := range v.Transactions() {
+		if trx.IsSpiceTransfer() {
+			return true
+		}
+	}
+	return false
+}
+
+// spent returns the spice moved from the transaction issuer, the transferred spice of all the transactions together with the fee.
+func (v *Vertex) spent() (spice.Melange, error) {
+	s := v.Fee.Clone()
+	for _, trx := range v.Transactions() {
+		if !trx.IsSpiceTransfer() {
+			continue
+		}
+		if err := s.Supply(trx.Spice); err != nil {
+			return spice.Melange{}, errors.Join(ErrUnexpected, err)
+		}
+	}
+	return s, nil
+}
+
 func (v *Vertex) encode() ([]byte, error) {
 	buf, err := msgpack.Marshal(*v)
 	if err != nil {
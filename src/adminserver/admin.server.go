@@ -0,0 +1,272 @@
+package adminserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/grpcsecured"
+	"github.com/bartossh/Computantis/src/logger"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/versioning"
+)
+
+const maxAuditLogLimit = 1000
+
+var (
+	ErrWrongPortSpecified    = errors.New("port must be between 1 and 65535")
+	ErrAdminAddressNotSet    = errors.New("admin address is not set")
+	ErrRequestIsEmpty        = errors.New("request is empty")
+	ErrVerification          = errors.New("verification failed, forbidden")
+	ErrProcessing            = errors.New("processing request failed")
+	ErrWrongLimitSpecified   = fmt.Errorf("limit must be between 1 and %v", maxAuditLogLimit)
+	ErrTrustedNodeNotChanged = errors.New("trusted node not changed")
+)
+
+type verifier interface {
+	Verify(message, signature []byte, hash [32]byte, address string) error
+}
+
+type trustedNodesManager interface {
+	Address() string
+	AddTrustedNode(adminAddress, trustedNodePublicAddress string) error
+	RemoveTrustedNode(adminAddress, trustedNodePublicAddress string) error
+	ReadTrustedNodes() ([]string, error)
+	ReadAuditLog(from uint64, limit int) ([]accountant.AuditEntry, error)
+}
+
+// RandomDataProvideValidator provides random binary data for signing to prove identity and
+// the validator of data being valid and not expired. Consumed data is no longer valid.
+type RandomDataProvideValidator interface {
+	ProvideData(address string) []byte
+	ValidateData(address string, data []byte) bool
+	ConsumeData(address string, data []byte) bool
+}
+
+// Config contains configuration of the admin server.
+type Config struct {
+	AdminAddress string `yaml:"admin_address"` // Public address of the wallet that is allowed to manage the node.
+	Cert         string `yaml:"certificate"`   // PEM certificate.
+	Key          string `yaml:"key"`           // PEM key.
+	Port         int    `yaml:"port"`          // Port to listen on.
+}
+
+type server struct {
+	protobufcompiled.UnimplementedAdminAPIServer
+	randDataProv RandomDataProvideValidator
+	log          logger.Logger
+	verifier     verifier
+	acc          trustedNodesManager
+	adminAddress string
+}
+
+// TrustedNodeChangeMessage creates the message that admin signs to add or remove the trusted node.
+// The data is the random data obtained from the admin server, so signed request cannot be replayed.
+func TrustedNodeChangeMessage(data []byte, action, nodeAddress string) []byte {
+	msg := make([]byte, 0, len(data)+len(action)+len(nodeAddress))
+	msg = append(msg, data...)
+	msg = append(msg, []byte(action)...)
+	msg = append(msg, []byte(nodeAddress)...)
+	return msg
+}
+
+// Run initializes routing and runs the admin server. To stop the server cancel the context.
+// It blocks until the context is canceled.
+// Random data provider shall not be shared with other servers, so the admin data is not rotated by other requests.
+func Run(
+	ctx context.Context, c Config, pv RandomDataProvideValidator, log logger.Logger, v verifier, acc trustedNodesManager,
+) error {
+	var err error
+	ctxx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err = validateConfig(&c); err != nil {
+		return err
+	}
+
+	s := &server{
+		randDataProv: pv,
+		log:          log,
+		verifier:     v,
+		acc:          acc,
+		adminAddress: c.AdminAddress,
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%v", c.Port))
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	grpcServer, err := grpcsecured.NewTLSServer(c.Cert, c.Key)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	protobufcompiled.RegisterAdminAPIServer(grpcServer, s)
+
+	go func() {
+		err = grpcServer.Serve(lis)
+		if err != nil {
+			s.log.Fatal(fmt.Sprintf("Node [ %s ] cannot start admin server on port [ %v ], %s", s.acc.Address(), c.Port, err))
+			cancel()
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 50) // just wait so the server can start
+
+	defer grpcServer.GracefulStop()
+
+	<-ctxx.Done()
+
+	return err
+}
+
+func validateConfig(c *Config) error {
+	if c.Port == 0 || c.Port > 65535 {
+		return ErrWrongPortSpecified
+	}
+
+	if c.AdminAddress == "" {
+		return ErrAdminAddressNotSet
+	}
+
+	return nil
+}
+
+// Alive returns alive information such as wallet public address API version and API header of running server.
+func (s *server) Alive(ctx context.Context, _ *emptypb.Empty) (*protobufcompiled.AliveData, error) {
+	return &protobufcompiled.AliveData{
+		PublicAddress: s.acc.Address(),
+		ApiVersion:    versioning.ApiVersion,
+		ApiHeader:     versioning.Header,
+	}, nil
+}
+
+// Data returns random data the admin shall sign to prove the identity.
+func (s *server) Data(ctx context.Context, in *protobufcompiled.Address) (*protobufcompiled.DataBlob, error) {
+	if in == nil || in.Public == "" {
+		s.log.Error("empty request on admin data endpoint")
+		return nil, ErrRequestIsEmpty
+	}
+	if in.Public != s.adminAddress {
+		s.log.Error(fmt.Sprintf("admin data endpoint requested by not admin address: %s", in.Public))
+		return nil, ErrVerification
+	}
+
+	return &protobufcompiled.DataBlob{Blob: s.randDataProv.ProvideData(in.Public)}, nil
+}
+
+// TrustedNodes returns public addresses of all the trusted nodes.
+func (s *server) TrustedNodes(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.Addresses, error) {
+	if err := s.verifyAdmin(in, in.GetData(), s.randDataProv.ValidateData); err != nil {
+		s.log.Error(fmt.Sprintf("trusted nodes endpoint, %s", err))
+		return nil, ErrVerification
+	}
+
+	addresses, err := s.acc.ReadTrustedNodes()
+	if err != nil {
+		s.log.Error(fmt.Sprintf("trusted nodes endpoint failed to read trusted nodes, %s", err))
+		return nil, ErrProcessing
+	}
+
+	return &protobufcompiled.Addresses{Array: addresses}, nil
+}
+
+// AddTrustedNode adds the node to the trusted nodes. The change is recorded in the audit log.
+func (s *server) AddTrustedNode(ctx context.Context, in *protobufcompiled.TrustedNodeChange) (*emptypb.Empty, error) {
+	return s.changeTrustedNode(in, accountant.AuditActionAddTrustedNode, s.acc.AddTrustedNode)
+}
+
+// RemoveTrustedNode removes the node from the trusted nodes. The change is recorded in the audit log.
+func (s *server) RemoveTrustedNode(ctx context.Context, in *protobufcompiled.TrustedNodeChange) (*emptypb.Empty, error) {
+	return s.changeTrustedNode(in, accountant.AuditActionRemoveTrustedNode, s.acc.RemoveTrustedNode)
+}
+
+func (s *server) changeTrustedNode(
+	in *protobufcompiled.TrustedNodeChange, action string, change func(adminAddress, trustedNodePublicAddress string) error,
+) (*emptypb.Empty, error) {
+	if in == nil || in.Signed == nil || in.NodeAddress == "" {
+		s.log.Error(fmt.Sprintf("%s endpoint received empty request", action))
+		return nil, ErrRequestIsEmpty
+	}
+	// Data is consumed when validated so the signed request cannot be replayed.
+	message := TrustedNodeChangeMessage(in.Signed.Data, action, in.NodeAddress)
+	if err := s.verifyAdmin(in.Signed, message, s.randDataProv.ConsumeData); err != nil {
+		s.log.Error(fmt.Sprintf("%s endpoint, %s", action, err))
+		return nil, ErrVerification
+	}
+
+	if err := change(in.Signed.Address, in.NodeAddress); err != nil {
+		s.log.Error(fmt.Sprintf("%s endpoint failed for node address [ %s ], %s", action, in.NodeAddress, err))
+		if errors.Is(err, accountant.ErrTrustedNodeAlreadyExists) || errors.Is(err, accountant.ErrTrustedNodeNotFound) {
+			return nil, errors.Join(ErrTrustedNodeNotChanged, err)
+		}
+		return nil, ErrProcessing
+	}
+
+	s.log.Info(fmt.Sprintf("%s endpoint, admin [ %s ] changed node address [ %s ].", action, in.Signed.Address, in.NodeAddress))
+
+	return &emptypb.Empty{}, nil
+}
+
+// AuditLog returns trusted nodes audit log entries starting from the given sequence.
+func (s *server) AuditLog(ctx context.Context, in *protobufcompiled.AuditLogQuery) (*protobufcompiled.AuditEntries, error) {
+	if in == nil || in.Signed == nil {
+		s.log.Error("audit log endpoint received empty request")
+		return nil, ErrRequestIsEmpty
+	}
+	if err := s.verifyAdmin(in.Signed, in.Signed.Data, s.randDataProv.ValidateData); err != nil {
+		s.log.Error(fmt.Sprintf("audit log endpoint, %s", err))
+		return nil, ErrVerification
+	}
+	if in.Limit == 0 || in.Limit > maxAuditLogLimit {
+		return nil, ErrWrongLimitSpecified
+	}
+
+	entries, err := s.acc.ReadAuditLog(in.From, int(in.Limit))
+	if err != nil {
+		s.log.Error(fmt.Sprintf("audit log endpoint failed to read audit log, %s", err))
+		return nil, ErrProcessing
+	}
+
+	result := &protobufcompiled.AuditEntries{Array: make([]*protobufcompiled.AuditEntry, 0, len(entries)), Len: uint64(len(entries))}
+	for _, e := range entries {
+		result.Array = append(result.Array, &protobufcompiled.AuditEntry{
+			Sequence:     e.Sequence,
+			Action:       e.Action,
+			NodeAddress:  e.NodeAddress,
+			AdminAddress: e.AdminAddress,
+			CreatedAt:    uint64(e.CreatedAt.UnixNano()),
+		})
+	}
+
+	return result, nil
+}
+
+// verifyAdmin checks that the request is signed by the admin with the valid random data and the message matches the signed hash.
+// Random data is validated with given validate function after the signature is verified, so only the admin can consume it.
+func (s *server) verifyAdmin(in *protobufcompiled.SignedHash, message []byte, validate func(address string, data []byte) bool) error {
+	if in == nil {
+		return ErrRequestIsEmpty
+	}
+	if in.Address != s.adminAddress {
+		return fmt.Errorf("address [ %s ] is not an admin", in.Address)
+	}
+	if len(in.Hash) != 32 {
+		return fmt.Errorf("wrong hash size for address [ %s ]", in.Address)
+	}
+	if err := s.verifier.Verify(message, in.Signature, [32]byte(in.Hash), in.Address); err != nil {
+		return fmt.Errorf("failed to verify signature for address [ %s ], %w", in.Address, err)
+	}
+	if ok := validate(in.Address, in.Data); !ok {
+		return fmt.Errorf("failed to validate data for address [ %s ]", in.Address)
+	}
+	return nil
+}
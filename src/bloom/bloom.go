@@ -0,0 +1,95 @@
+package bloom
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	hashesCount   = 7   // number of hash functions optimal for bitsPerItem.
+	bitsPerItem   = 9.6 // bits per item giving about 1% of false positives.
+	minBits       = 64
+	headerSize    = 1 + 8 // hashes count and seed.
+	MaxFilterSize = 1 << 20
+)
+
+var (
+	ErrFilterIsInvalid  = errors.New("bloom filter is invalid")
+	ErrFilterIsTooLarge = errors.New("bloom filter is too large")
+)
+
+// Filter is a Bloom filter of 32 bytes hashes.
+// It compactly summarises the set of hashes, so the peer can tell which of its hashes the set is missing.
+// Filter answers false positives but never false negatives. Seed changes the false positives,
+// so the missed hash is likely found when filter is created with another seed.
+type Filter struct {
+	bits   []byte
+	hashes uint8
+	seed   uint64
+}
+
+// New creates the empty filter sized for n hashes with about one percent of false positives.
+func New(n int, seed uint64) *Filter {
+	m := int(math.Ceil(float64(n) * bitsPerItem))
+	if m < minBits {
+		m = minBits
+	}
+	size := min((m+7)/8, MaxFilterSize-headerSize)
+	return &Filter{bits: make([]byte, size), hashes: hashesCount, seed: seed}
+}
+
+// Add adds the hash to the filter.
+func (f *Filter) Add(h [32]byte) {
+	h1, h2 := f.baseHashes(h)
+	m := uint64(len(f.bits) * 8)
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		idx := (h1 + i*h2) % m
+		f.bits[idx/8] |= 1 << (idx % 8)
+	}
+}
+
+// Has returns true if the hash is possibly in the filter and false if it is for sure not in the filter.
+func (f *Filter) Has(h [32]byte) bool {
+	h1, h2 := f.baseHashes(h)
+	m := uint64(len(f.bits) * 8)
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		idx := (h1 + i*h2) % m
+		if f.bits[idx/8]&(1<<(idx%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode encodes the filter as the hashes count byte, little-endian seed and the filter bits.
+func (f *Filter) Encode() []byte {
+	buf := make([]byte, 0, headerSize+len(f.bits))
+	buf = append(buf, f.hashes)
+	buf = binary.LittleEndian.AppendUint64(buf, f.seed)
+	return append(buf, f.bits...)
+}
+
+// Decode decodes the filter encoded with Encode.
+func Decode(buf []byte) (*Filter, error) {
+	if len(buf) > MaxFilterSize {
+		return nil, ErrFilterIsTooLarge
+	}
+	if len(buf) <= headerSize || buf[0] == 0 {
+		return nil, ErrFilterIsInvalid
+	}
+	return &Filter{
+		bits:   append([]byte{}, buf[headerSize:]...),
+		hashes: buf[0],
+		seed:   binary.LittleEndian.Uint64(buf[1:headerSize]),
+	}, nil
+}
+
+// baseHashes derives two independent hashes of the seeded hash used for double hashing.
+func (f *Filter) baseHashes(h [32]byte) (uint64, uint64) {
+	buf := make([]byte, 0, 8+len(h))
+	buf = binary.LittleEndian.AppendUint64(buf, f.seed)
+	d := sha256.Sum256(append(buf, h[:]...))
+	return binary.LittleEndian.Uint64(d[:8]), binary.LittleEndian.Uint64(d[8:16]) | 1
+}
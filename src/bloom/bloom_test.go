@@ -0,0 +1,57 @@
+package bloom
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func hashOf(i int) [32]byte {
+	return sha256.Sum256(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+}
+
+func TestFilter(t *testing.T) {
+	const n = 1000
+	f := New(n, 42)
+	for i := 0; i < n; i++ {
+		f.Add(hashOf(i))
+	}
+	for i := 0; i < n; i++ {
+		assert.Assert(t, f.Has(hashOf(i)))
+	}
+
+	var falsePositives int
+	for i := n; i < 2*n; i++ {
+		if f.Has(hashOf(i)) {
+			falsePositives++
+		}
+	}
+	assert.Assert(t, falsePositives < n/20, "false positives: %v", falsePositives)
+
+	decoded, err := Decode(f.Encode())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decoded.Encode(), f.Encode())
+	for i := 0; i < n; i++ {
+		assert.Assert(t, decoded.Has(hashOf(i)))
+	}
+}
+
+func TestFilterSeed(t *testing.T) {
+	a, b := New(10, 1), New(10, 2)
+	for i := 0; i < 10; i++ {
+		a.Add(hashOf(i))
+		b.Add(hashOf(i))
+	}
+	assert.Assert(t, string(a.Encode()[headerSize:]) != string(b.Encode()[headerSize:]))
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode(nil)
+	assert.ErrorIs(t, err, ErrFilterIsInvalid)
+	_, err = Decode(make([]byte, headerSize+8))
+	assert.ErrorIs(t, err, ErrFilterIsInvalid)
+	_, err = Decode(make([]byte, MaxFilterSize+1))
+	assert.ErrorIs(t, err, ErrFilterIsTooLarge)
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -25,6 +26,7 @@ const (
 const (
 	prefixTrx     = "trx"
 	prefixAddress = "address"
+	prefixLocked  = "locked"
 )
 
 var (
@@ -222,27 +224,101 @@ func (h *Hippocampus) ReadTransactions(address string) ([]transaction.Transactio
 	return trxs, errs
 }
 
-// SaveBalance saves balance in to the mem cache.
-func (h *Hippocampus) SaveBalance(a string, s spice.Melange) error {
-	buf, err := s.Encode()
+// PurgeExpired removes awaited transactions that expired at the given time according to the validity
+// together with the issuer and receiver address references. Returns the number of removed transactions.
+func (h *Hippocampus) PurgeExpired(validity transaction.Validity, now time.Time) (int, error) {
+	var expired []transaction.Transaction
+	var errs error
+	it := h.mem.Iterator()
+	for it.SetNext() {
+		entry, err := it.Value()
+		if err != nil {
+			errs = err
+			continue
+		}
+		if !strings.HasPrefix(entry.Key(), prefixTrx+"-") {
+			continue
+		}
+		trx, err := transaction.Decode(entry.Value())
+		if err != nil {
+			errs = err
+			continue
+		}
+		if validity.Expired(&trx, now) {
+			expired = append(expired, trx)
+		}
+	}
+
+	var purged int
+	for _, trx := range expired {
+		if _, err := h.RemoveAwaitedTransaction(trx.Hash, trx.ReceiverAddress); err != nil && !errors.Is(err, ErrTransactionNotFound) {
+			errs = err
+			continue
+		}
+		purged++
+	}
+
+	return purged, errs
+}
+
+// RunPurge purges expired awaited transactions with given interval until the context is done.
+func (h *Hippocampus) RunPurge(ctx context.Context, validity transaction.Validity, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h.PurgeExpired(validity, now)
+		}
+	}
+}
+
+// SaveBalance saves available and locked balance in to the mem cache.
+func (h *Hippocampus) SaveBalance(a string, available, locked spice.Melange) error {
+	buf, err := available.Encode()
 	if err != nil {
 		return err
 	}
+	lockedBuf, err := locked.Encode()
+	if err != nil {
+		return err
+	}
+	if err := h.mem.Set(encodeLockedKey(a), lockedBuf); err != nil {
+		return err
+	}
 	return h.mem.Set(a, buf)
 }
 
-// ReadBalance reads balance from the mem cache.
-func (h *Hippocampus) ReadBalance(a string) (spice.Melange, error) {
+// ReadBalance reads available and locked balance from the mem cache.
+func (h *Hippocampus) ReadBalance(a string) (spice.Melange, spice.Melange, error) {
 	b, err := h.mem.Get(a)
 	if err != nil {
-		return spice.Melange{}, err
+		return spice.Melange{}, spice.Melange{}, err
 	}
-	return spice.Decode(b)
+	lb, err := h.mem.Get(encodeLockedKey(a))
+	if err != nil {
+		return spice.Melange{}, spice.Melange{}, err
+	}
+	available, err := spice.Decode(b)
+	if err != nil {
+		return spice.Melange{}, spice.Melange{}, err
+	}
+	locked, err := spice.Decode(lb)
+	if err != nil {
+		return spice.Melange{}, spice.Melange{}, err
+	}
+	return available, locked, nil
 }
 
-// RemoveBalance removes balance from the cache.
+// RemoveBalance removes available and locked balance from the cache.
 func (h *Hippocampus) RemoveBalance(a string) error {
-	return h.mem.Delete(a)
+	err := h.mem.Delete(a)
+	if errLocked := h.mem.Delete(encodeLockedKey(a)); err == nil {
+		err = errLocked
+	}
+	return err
 }
 
 // Close closes the cache in a safe way allowing all the goroutines to finish their jobs and cleaning the heap.
@@ -266,6 +342,10 @@ func encodeAddressKey(src string) string {
 	return fmt.Sprintf("%s-%s", prefixAddress, src)
 }
 
+func encodeLockedKey(src string) string {
+	return fmt.Sprintf("%s-%s", prefixLocked, src)
+}
+
 func decodeAddressKey(src string) (string, error) {
 	parts := strings.Split(src, "-")
 	if len(parts) != 2 {
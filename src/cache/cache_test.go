@@ -205,3 +205,47 @@ func TestRemoveFromCacheFailureWrongAddress(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, len(trxs), trxNum)
 }
+
+func TestPurgeExpiredFromCacheSuccess(t *testing.T) {
+	w, err := wallet.New()
+	assert.NilError(t, err)
+	wr, err := wallet.New()
+	assert.NilError(t, err)
+
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+
+	hippo, err := New(maxEntrySize, maxCacheSizeMB)
+	assert.NilError(t, err)
+
+	trxNum := 10
+	for i := 0; i < trxNum; i++ {
+		trx, err := createRandomTransaction(&w, &wr, r)
+		assert.NilError(t, err)
+
+		err = hippo.SaveAwaitedTransaction(&trx)
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, hippo.SaveBalance(w.Address(), spice.New(1, 0), spice.New(2, 0)))
+
+	validity := transaction.Validity{Expiration: 60, ClockSkew: 10}
+
+	purged, err := hippo.PurgeExpired(validity, time.Now().Add(time.Minute))
+	assert.NilError(t, err)
+	assert.Equal(t, purged, 0)
+
+	purged, err = hippo.PurgeExpired(validity, time.Now().Add(time.Minute*2))
+	assert.NilError(t, err)
+	assert.Equal(t, purged, trxNum)
+
+	trxs, _ := hippo.ReadTransactions(wr.Address())
+	assert.Equal(t, len(trxs), 0)
+
+	balance, locked, err := hippo.ReadBalance(w.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance, spice.New(1, 0))
+	assert.Equal(t, locked, spice.New(2, 0))
+
+	assert.NilError(t, hippo.RemoveBalance(w.Address()))
+	_, _, err = hippo.ReadBalance(w.Address())
+	assert.Assert(t, err != nil)
+}
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/adminserver"
 	"github.com/bartossh/Computantis/src/aeswrapper"
 	"github.com/bartossh/Computantis/src/cache"
 	"github.com/bartossh/Computantis/src/configuration"
+	"github.com/bartossh/Computantis/src/dagfile"
 	"github.com/bartossh/Computantis/src/dataprovider"
 	"github.com/bartossh/Computantis/src/fileoperations"
 	"github.com/bartossh/Computantis/src/gossip"
@@ -44,10 +46,12 @@ const (
 
 const gossipTimeout = time.Second * 5
 
+const awaitedTrxPurgeInterval = time.Minute
+
 func main() {
 	logo.Display()
 
-	var file string
+	var file, backupDir, dagFile string
 	configurator := func() (configuration.Configuration, error) {
 		if file == "" {
 			return configuration.Configuration{}, errors.New("please specify configuration file path with -c <path to file>")
@@ -79,6 +83,49 @@ func main() {
 			run(cfg)
 			return nil
 		},
+		Commands: []*cli.Command{
+			{
+				Name:    "restore",
+				Aliases: []string{"r"},
+				Usage:   "Restores vertices storage from the truncate backup files, verifying each vertex signature.",
+				Action: func(_ *cli.Context) error {
+					cfg, err := configurator()
+					if err != nil {
+						return err
+					}
+					return restore(cfg, backupDir)
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "dir",
+						Aliases:     []string{"d"},
+						Usage:       "Directory containing the truncate backup files. When empty the accountant backup directory from configuration is used.",
+						Destination: &backupDir,
+					},
+				},
+			},
+			{
+				Name:    "export",
+				Aliases: []string{"e"},
+				Usage:   "Exports vertices from the storage to the DAG file that can be loaded by the node with load_dag_file gossip configuration.",
+				Action: func(_ *cli.Context) error {
+					cfg, err := configurator()
+					if err != nil {
+						return err
+					}
+					return export(cfg, dagFile)
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "file",
+						Aliases:     []string{"f"},
+						Usage:       "Path to the DAG `FILE` to export vertices to.",
+						Destination: &dagFile,
+						Required:    true,
+					},
+				},
+			},
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -136,7 +183,7 @@ func run(cfg configuration.Configuration) {
 		return
 	}
 
-	acc, err := accountant.NewAccountingBook(ctx, cfg.Accountant, &verifier, &wlt, &log)
+	tele, err := telemetry.Run(ctx, cancel, 2112)
 	if err != nil {
 		log.Error(err.Error())
 		time.Sleep(time.Second)
@@ -144,7 +191,7 @@ func run(cfg configuration.Configuration) {
 		return
 	}
 
-	tele, err := telemetry.Run(ctx, cancel, 2112)
+	acc, err := accountant.NewAccountingBook(ctx, cfg.Accountant, &verifier, &wlt, tele, &log)
 	if err != nil {
 		log.Error(err.Error())
 		time.Sleep(time.Second)
@@ -162,6 +209,7 @@ func run(cfg configuration.Configuration) {
 		return
 	}
 	defer hippo.Close()
+	go hippo.RunPurge(ctx, cfg.Transaction, awaitedTrxPurgeInterval)
 
 	flash, err := cache.NewFlash()
 	if err != nil {
@@ -190,8 +238,11 @@ func run(cfg configuration.Configuration) {
 		return
 	}
 
+	cfg.Gossip.Validity = cfg.Transaction
+	cfg.NotaryServer.Validity = cfg.Transaction
+
 	go func() {
-		err = gossip.RunGRPC(ctx, cfg.Gossip, &log, gossipTimeout, &wlt, &verifier, acc, hippo, flash, juggler)
+		err = gossip.RunGRPC(ctx, cfg.Gossip, &log, gossipTimeout, &wlt, &verifier, acc, hippo, flash, juggler, tele)
 		if err != nil {
 			log.Error(err.Error())
 			time.Sleep(time.Second)
@@ -200,6 +251,17 @@ func run(cfg configuration.Configuration) {
 		}
 	}()
 
+	if cfg.AdminServer.AdminAddress != "" {
+		go func() {
+			adminDataProvider := dataprovider.New(ctx, cfg.DataProvider)
+			if err := adminserver.Run(ctx, cfg.AdminServer, adminDataProvider, &log, &verifier, acc); err != nil {
+				log.Error(err.Error())
+				time.Sleep(time.Second)
+				c <- os.Interrupt
+			}
+		}()
+	}
+
 	err = notaryserver.Run(ctx, cfg.NotaryServer, pub, dataProvider, tele, &log, &verifier, acc, hippo, flash, juggler)
 	if err != nil {
 		log.Error(err.Error())
@@ -207,3 +269,94 @@ func run(cfg configuration.Configuration) {
 	}
 	time.Sleep(time.Second)
 }
+
+func restore(cfg configuration.Configuration, backupDir string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	callbackOnErr := func(err error) {
+		fmt.Println("Error with logger: ", err)
+	}
+
+	callbackOnFatal := func(err error) {
+		panic(fmt.Sprintf("Error with logger: %s", err))
+	}
+
+	log := logging.New(callbackOnErr, callbackOnFatal, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	h := fileoperations.New(cfg.FileOperator, aeswrapper.New())
+	wlt, err := h.ReadWallet()
+	if err != nil {
+		return err
+	}
+
+	tele, err := telemetry.Run(ctx, cancel, 2112)
+	if err != nil {
+		return err
+	}
+
+	// Journal is not replayed when restoring, so the DAG stays empty, and it is kept untouched
+	// to be replayed on top of the restored storage when the node starts.
+	cfg.Accountant.JournalDBPath = ""
+	acc, err := accountant.NewAccountingBook(ctx, cfg.Accountant, &verifier, &wlt, tele, &log)
+	if err != nil {
+		return err
+	}
+
+	if backupDir == "" {
+		backupDir = cfg.Accountant.BackupDir
+	}
+	backups, err := accountant.ListBackups(backupDir)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return fmt.Errorf("no backup files found in directory [ %s ]", backupDir)
+	}
+
+	if err := acc.RestoreFromBackups(ctx, backups); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Restored vertices storage from [ %v ] backup files.\n", len(backups))
+	return nil
+}
+
+func export(cfg configuration.Configuration, dagFile string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	callbackOnErr := func(err error) {
+		fmt.Println("Error with logger: ", err)
+	}
+
+	callbackOnFatal := func(err error) {
+		panic(fmt.Sprintf("Error with logger: %s", err))
+	}
+
+	log := logging.New(callbackOnErr, callbackOnFatal, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	h := fileoperations.New(cfg.FileOperator, aeswrapper.New())
+	wlt, err := h.ReadWallet()
+	if err != nil {
+		return err
+	}
+
+	tele, err := telemetry.Run(ctx, cancel, 2112)
+	if err != nil {
+		return err
+	}
+
+	acc, err := accountant.NewAccountingBook(ctx, cfg.Accountant, &verifier, &wlt, tele, &log)
+	if err != nil {
+		return err
+	}
+
+	n, err := dagfile.ExportToFile(ctx, dagFile, acc)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Exported [ %v ] vertices to DAG file [ %s ].\n", n, dagFile)
+	return nil
+}
@@ -323,7 +323,7 @@ func runTransactionOps(cfg fileoperations.Config, nodeURL string) error {
 		case "Check balance":
 			spinnerInfo, _ := pterm.DefaultSpinner.Start("Checking balance ...")
 			time.Sleep(getPause())
-			melange, err := c.ReadBalance(ctx)
+			balance, err := c.ReadBalance(ctx)
 			if err != nil {
 				spinnerInfo.Stop()
 				printError(fmt.Errorf("cannot read balance due to, %w", err))
@@ -335,7 +335,7 @@ func runTransactionOps(cfg fileoperations.Config, nodeURL string) error {
 				printError(fmt.Errorf("cannot read wallet address due to, %w", err))
 				continue
 			}
-			spinnerInfo.Info(fmt.Sprintf("Account [ %s ] balance is [ %v ]", addr, melange.String()))
+			spinnerInfo.Info(fmt.Sprintf("Account [ %s ] balance is [ %v ], locked [ %v ]", addr, balance.Available.String(), balance.Locked.String()))
 			printSuccess()
 		case "Read Transactions":
 			spinnerInfo, _ := pterm.DefaultSpinner.Start("Reading transactions ...")
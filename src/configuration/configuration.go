@@ -7,12 +7,14 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/adminserver"
 	"github.com/bartossh/Computantis/src/dataprovider"
 	"github.com/bartossh/Computantis/src/emulator"
 	"github.com/bartossh/Computantis/src/fileoperations"
 	"github.com/bartossh/Computantis/src/gossip"
 	"github.com/bartossh/Computantis/src/natsclient"
 	"github.com/bartossh/Computantis/src/notaryserver"
+	"github.com/bartossh/Computantis/src/transaction"
 	"github.com/bartossh/Computantis/src/walletapi"
 	"github.com/bartossh/Computantis/src/webhooksserver"
 	"github.com/bartossh/Computantis/src/zincaddapter"
@@ -23,6 +25,7 @@ import (
 type Configuration struct {
 	NotaryServer   notaryserver.Config   `yaml:"notary_server"`
 	Gossip         gossip.Config         `yaml:"gossip_server"`
+	AdminServer    adminserver.Config    `yaml:"admin_server"`
 	Accountant     accountant.Config     `yaml:"accountant"`
 	Nats           natsclient.Config     `yaml:"nats"`
 	FileOperator   fileoperations.Config `yaml:"file_operator"`
@@ -31,6 +34,7 @@ type Configuration struct {
 	WebhooksServer webhooksserver.Config `yaml:"webhooks_server"`
 	Emulator       emulator.Config       `yaml:"emulator"`
 	Client         walletapi.Config      `yaml:"client"`
+	Transaction    transaction.Validity  `yaml:"transaction_validity"`
 	IsProfiling    bool                  `yaml:"is_profiling"` // Indicates if node server is running in profiling mode and will create `default.pgo` file.
 }
 
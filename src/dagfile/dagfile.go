@@ -0,0 +1,173 @@
+package dagfile
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/vertextransformers"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+const hashLength = 32
+
+var (
+	ErrVertexMalformed     = errors.New("vertex is malformed")
+	ErrVertexRejected      = errors.New("vertex rejected, signature verification failed")
+	ErrImportProcessFailed = errors.New("import process failed")
+)
+
+// Verifier verifies the signature of the message.
+type Verifier interface {
+	Verify(message, signature []byte, hash [32]byte, address string) error
+}
+
+// Streamer streams the DAG vertices and the vertices moved to the storage.
+type Streamer interface {
+	StreamStoredVertices(ctx context.Context) <-chan *accountant.Vertex
+	StreamDAG(ctx context.Context) <-chan *accountant.Vertex
+}
+
+// Loader loads the DAG from the stream of vertices and discards the loaded DAG.
+type Loader interface {
+	LoadDag(cancelF context.CancelCauseFunc, cVrx <-chan *accountant.Vertex)
+	DiscardDag() error
+}
+
+// Export writes the stored vertices followed by the DAG vertices to the writer as a stream of length prefixed protobuf vertices.
+// Each vertex is written once. Returns the number of vertices written.
+func Export(ctx context.Context, w io.Writer, s Streamer) (int, error) {
+	bw := bufio.NewWriter(w)
+	written := make(map[[hashLength]byte]struct{})
+
+	ctxx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, stream := range []func(context.Context) <-chan *accountant.Vertex{s.StreamStoredVertices, s.StreamDAG} {
+		for vrx := range stream(ctxx) {
+			if _, ok := written[vrx.Hash]; ok {
+				continue
+			}
+			if _, err := protodelim.MarshalTo(bw, vertextransformers.VrxToProtoVrx(vrx)); err != nil {
+				return len(written), err
+			}
+			written[vrx.Hash] = struct{}{}
+		}
+		if err := ctx.Err(); err != nil {
+			return len(written), err
+		}
+	}
+
+	return len(written), bw.Flush()
+}
+
+// Import reads the stream of length prefixed protobuf vertices from the reader and loads them in to the DAG.
+// Vertices are loaded as they are read, each after its signature is verified. A single invalid vertex rejects the whole import,
+// the vertices loaded before it are discarded. Returns the number of vertices loaded.
+func Import(ctx context.Context, r io.Reader, l Loader, v Verifier) (int, error) {
+	chVrx := make(chan *accountant.Vertex, 1000)
+	ctxx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.LoadDag(cancel, chVrx)
+	}()
+
+	n, err := feed(ctxx, bufio.NewReader(r), v, chVrx)
+	close(chVrx)
+	<-done
+
+	cause := context.Cause(ctxx)
+	if err != nil {
+		if !errors.Is(cause, accountant.ErrDagIsLoaded) {
+			if errDiscard := l.DiscardDag(); errDiscard != nil {
+				return 0, errors.Join(err, errDiscard)
+			}
+		}
+		return 0, err
+	}
+	if cause != nil {
+		return n, errors.Join(ErrImportProcessFailed, cause)
+	}
+	return n, nil
+}
+
+// feed reads the vertices from the reader and sends them to the loader until the reader is drained or the loading stops.
+func feed(ctx context.Context, r *bufio.Reader, v Verifier, chVrx chan<- *accountant.Vertex) (int, error) {
+	var n int
+	for {
+		vrx, err := read(r, v, n)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		select {
+		case <-ctx.Done():
+			return n, nil
+		case chVrx <- vrx:
+			n++
+		}
+	}
+}
+
+func read(r *bufio.Reader, v Verifier, position int) (*accountant.Vertex, error) {
+	var vg protobufcompiled.Vertex
+	if err := protodelim.UnmarshalFrom(r, &vg); err != nil {
+		return nil, err
+	}
+	if err := validate(&vg); err != nil {
+		return nil, fmt.Errorf("vertex at position [ %v ], %w", position, err)
+	}
+	vrx := vertextransformers.ProtoVrxToVrx(&vg)
+	if err := vrx.Verify(v); err != nil {
+		return nil, errors.Join(ErrVertexRejected, fmt.Errorf("vertex at position [ %v ], %w", position, err))
+	}
+	return &vrx, nil
+}
+
+func validate(vg *protobufcompiled.Vertex) error {
+	for _, h := range [][]byte{vg.Hash, vg.LeftParentHash, vg.RightParentHash} {
+		if len(h) != hashLength {
+			return ErrVertexMalformed
+		}
+	}
+	for _, trx := range append([]*protobufcompiled.Transaction{vg.Transaction}, vg.Batch...) {
+		if trx == nil || trx.Spice == nil || len(trx.Hash) != hashLength {
+			return ErrVertexMalformed
+		}
+	}
+	return nil
+}
+
+// ExportToFile creates the file at given path and exports the DAG to it.
+func ExportToFile(ctx context.Context, path string, s Streamer) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := Export(ctx, f, s)
+	if err != nil {
+		f.Close()
+		return n, err
+	}
+	return n, f.Close()
+}
+
+// ImportFromFile imports the DAG from the file at given path.
+func ImportFromFile(ctx context.Context, path string, l Loader, v Verifier) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return Import(ctx, f, l, v)
+}
@@ -0,0 +1,138 @@
+package dagfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/logging"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/telemetry/telemetrytest"
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/bartossh/Computantis/src/vertextransformers"
+	"github.com/bartossh/Computantis/src/wallet"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"gotest.tools/v3/assert"
+)
+
+func TestExportImport(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := accountant.NewAccountingBook(ctx, accountant.Config{NonceLegacy: true}, verifier, &signer, &telemetrytest.Mock{}, l)
+	assert.NilError(t, err)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	_, err = ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	numberOfTransactions := 10
+	leaves := make([]accountant.Vertex, 0, numberOfTransactions)
+	for i := 0; i < numberOfTransactions; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Spice supply %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+		assert.NilError(t, err)
+		leaf, err := ab.CreateLeaf(ctx, &trx)
+		assert.NilError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	path := filepath.Join(t.TempDir(), "dag.bin")
+	n, err := ExportToFile(ctx, path, ab)
+	assert.NilError(t, err)
+	assert.Equal(t, n, numberOfTransactions+1)
+
+	imported, err := accountant.NewAccountingBook(ctx, accountant.Config{NonceLegacy: true}, verifier, &signer, &telemetrytest.Mock{}, l)
+	assert.NilError(t, err)
+	n, err = ImportFromFile(ctx, path, imported, verifier)
+	assert.NilError(t, err)
+	assert.Equal(t, n, numberOfTransactions+1)
+	assert.Equal(t, imported.DagLoaded(), true)
+
+	for _, leaf := range leaves {
+		vrx, err := imported.ReadVertex(ctx, leaf.Hash)
+		assert.NilError(t, err)
+		assert.Equal(t, vrx.Transaction.Hash, leaf.Transaction.Hash)
+	}
+
+	balance, err := imported.CalculateBalance(ctx, receiver.Address())
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Spice, spice.New(100, 0))
+
+	_, err = ImportFromFile(ctx, path, imported, verifier)
+	assert.ErrorIs(t, err, ErrImportProcessFailed)
+}
+
+func TestImportMalformed(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{})
+	verifier := wallet.NewVerifier()
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	ab, err := accountant.NewAccountingBook(ctx, accountant.Config{NonceLegacy: true}, verifier, &signer, &telemetrytest.Mock{}, l)
+	assert.NilError(t, err)
+
+	var buf bytes.Buffer
+	_, err = protodelim.MarshalTo(&buf, &protobufcompiled.Vertex{Hash: []byte{1, 2, 3}})
+	assert.NilError(t, err)
+
+	_, err = Import(ctx, &buf, ab, verifier)
+	assert.ErrorIs(t, err, ErrVertexMalformed)
+	assert.Equal(t, ab.DagLoaded(), false)
+
+	genesisReceiver, err := wallet.New()
+	assert.NilError(t, err)
+	genesis, err := ab.CreateGenesis("GENESIS", spice.New(1000, 0), []byte{}, genesisReceiver.Address())
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	trx, err := transaction.New("Spice supply", spice.New(10, 0), []byte{}, receiver.Address(), &genesisReceiver)
+	assert.NilError(t, err)
+	leaf, err := ab.CreateLeaf(ctx, &trx)
+	assert.NilError(t, err)
+
+	forged := vertextransformers.VrxToProtoVrx(&leaf)
+	forged.Weight++
+	buf.Reset()
+	for _, vg := range []*protobufcompiled.Vertex{vertextransformers.VrxToProtoVrx(&genesis), forged} {
+		_, err = protodelim.MarshalTo(&buf, vg)
+		assert.NilError(t, err)
+	}
+
+	imported, err := accountant.NewAccountingBook(ctx, accountant.Config{NonceLegacy: true}, verifier, &signer, &telemetrytest.Mock{}, l)
+	assert.NilError(t, err)
+	n, err := Import(ctx, &buf, imported, verifier)
+	assert.ErrorIs(t, err, ErrVertexRejected)
+	assert.Equal(t, n, 0)
+	assert.Equal(t, imported.DagLoaded(), false)
+
+	// Genesis loaded before the forged vertex is discarded.
+	trxs, err := imported.ReadTransactionsByAddress(ctx, genesisReceiver.Address(), 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(trxs), 0)
+}
@@ -92,3 +92,22 @@ func (c *Cache) ValidateData(address string, data []byte) bool {
 
 	return bytes.Equal(data, d.raw)
 }
+
+// ConsumeData checks if data is stored for given address and is not expired and removes it,
+// so the same data is valid only once even if validated concurrently.
+func (c *Cache) ConsumeData(address string, data []byte) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	d, ok := c.data[address]
+	if !ok {
+		return false
+	}
+	delete(c.data, address)
+
+	if d.timestamp < time.Now().UnixNano() {
+		return false
+	}
+
+	return bytes.Equal(data, d.raw)
+}
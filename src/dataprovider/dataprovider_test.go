@@ -53,3 +53,19 @@ func TestGenerateValidateFailTimePassed(t *testing.T) {
 
 	assert.False(t, ok)
 }
+
+func TestGenerateConsumeOnce(t *testing.T) {
+	address := "somerandomaddressthatisvalid"
+
+	c := New(context.Background(), Config{Longevity: 60})
+	d := c.ProvideData(address)
+
+	ok := c.ConsumeData(address, d)
+	assert.True(t, ok)
+
+	ok = c.ConsumeData(address, d)
+	assert.False(t, ok)
+
+	ok = c.ValidateData(address, d)
+	assert.False(t, ok)
+}
@@ -0,0 +1,175 @@
+package gossip
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/vertextransformers"
+)
+
+const (
+	defaultGossipTTL    = 6
+	defaultPullInterval = time.Second * 10
+)
+
+type peer struct {
+	address string
+	node    nodeData
+}
+
+// gossipTTL returns the number of hops the gossip originated by the node travels.
+func (c Config) gossipTTL() uint32 {
+	if c.GossipTTL <= 0 {
+		return defaultGossipTTL
+	}
+	return uint32(c.GossipTTL)
+}
+
+// pullInterval returns the interval between anti-entropy pulls of the random peer leaves.
+func (c Config) pullInterval() time.Duration {
+	if c.PullInterval <= 0 {
+		return defaultPullInterval
+	}
+	return time.Duration(c.PullInterval) * time.Second
+}
+
+// nextHopTTL returns the TTL the received gossip is forwarded with, zero if the gossip shall not be forwarded.
+// Received TTL is capped by the node TTL, so peers cannot amplify the gossip.
+// Zero TTL is sent by the peers not supporting TTL, so it is treated as not set and the node TTL is used.
+func (g *gossiper) nextHopTTL(ttl uint32) uint32 {
+	if ttl == 0 {
+		ttl = g.ttl
+	}
+	ttl = min(ttl, g.ttl)
+	if ttl <= 1 {
+		return 0
+	}
+	return ttl - 1
+}
+
+// availablePeers returns the peers that didn't gossip the message yet.
+// Suspected peers are skipped until they answer the Alive probe again. Caller shall hold the nodes lock.
+func (g *gossiper) availablePeers(set map[string]*protobufcompiled.Gossiper) []peer {
+	peers := make([]peer, 0, len(g.nodes))
+	for addr, nd := range g.nodes {
+		if _, ok := set[addr]; ok || g.isSuspected(nd) {
+			continue
+		}
+		peers = append(peers, peer{address: addr, node: nd})
+	}
+	return peers
+}
+
+// selectPeers returns up to fan-out random available peers, or all of them if fan-out is not limited.
+// Caller shall hold the nodes lock.
+func (g *gossiper) selectPeers(set map[string]*protobufcompiled.Gossiper) []peer {
+	peers := g.availablePeers(set)
+	if g.fanOut <= 0 || len(peers) <= g.fanOut {
+		return peers
+	}
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+	return peers[:g.fanOut]
+}
+
+// GetLeaves returns hashes of the accountant DAG leaves to the peer pulling them to find vertices it misses.
+func (g *gossiper) GetLeaves(_ context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.VertexHashes, error) {
+	if in == nil {
+		return nil, ErrNilSignedHash
+	}
+	if len(in.Hash) != 32 {
+		return nil, ErrInvalidSignature
+	}
+	if err := g.verifier.Verify(in.Data, in.Signature, [32]byte(in.Hash), in.Address); err != nil {
+		g.log.Error(fmt.Sprintf("get leaves endpoint failed to verify signature for address: %s, %s", in.Address, err))
+		return nil, ErrInvalidSignature
+	}
+	leaves := g.accounter.ReadLeaves()
+	hashes := make([][]byte, 0, len(leaves))
+	for i := range leaves {
+		hashes = append(hashes, leaves[i][:])
+	}
+	return &protobufcompiled.VertexHashes{Hashes: hashes}, nil
+}
+
+// runLeavesPullProcess periodically pulls the leaves of the random peer and fetches the ones the node misses.
+// It repairs the vertices missed because of the bounded fan-out, the parents of fetched vertices are fetched
+// by the missing parents process.
+func (g *gossiper) runLeavesPullProcess(ctx context.Context) {
+	ticker := time.NewTicker(g.pullInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.pullLeaves(ctx)
+		}
+	}
+}
+
+func (g *gossiper) pullLeaves(ctx context.Context) {
+	g.mux.RLock()
+	peers := g.selectPeers(nil)
+	g.mux.RUnlock()
+	if len(peers) == 0 {
+		return
+	}
+	p := peers[rand.Intn(len(peers))]
+
+	data := binary.LittleEndian.AppendUint64(nil, uint64(time.Now().UnixNano()))
+	digest, signature := g.signer.Sign(data)
+	ctxx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	leaves, err := p.node.client.GetLeaves(ctxx, &protobufcompiled.SignedHash{
+		Address:   g.signer.Address(),
+		Data:      data,
+		Hash:      digest[:],
+		Signature: signature,
+	})
+	if err != nil {
+		g.log.Info(fmt.Sprintf("node [ %s ] pulling leaves from [ %s ] with URL [ %s ] failed, %s.", g.signer.Address(), p.address, p.node.url, err))
+		return
+	}
+
+	var fetched int
+	for _, h := range leaves.Hashes {
+		if len(h) != 32 {
+			continue
+		}
+		if _, err := g.accounter.ReadVertex(ctx, [32]byte(h)); err == nil {
+			continue
+		}
+		if g.fetchVertex(ctx, p.node.client, [32]byte(h)) {
+			fetched++
+		}
+	}
+	if fetched > 0 {
+		g.log.Info(fmt.Sprintf("node [ %s ] pulled [ %v ] missing leaves from [ %s ].", g.signer.Address(), fetched, p.address))
+	}
+}
+
+// fetchVertex fetches the vertex from the peer and adds it to the accountant.
+// Returns true if the peer served the vertex, even if it waits for its parents in the accountant orphan pool.
+func (g *gossiper) fetchVertex(ctx context.Context, client protobufcompiled.GossipAPIClient, h [32]byte) bool {
+	digest, signature := g.signer.Sign(h[:])
+	vrx, err := client.GetVertex(ctx, &protobufcompiled.SignedHash{
+		Address:   g.signer.Address(),
+		Data:      h[:],
+		Hash:      digest[:],
+		Signature: signature,
+	})
+	if err != nil || vrx == nil || !hasTransactions(vrx) {
+		return false
+	}
+	v := vertextransformers.ProtoVrxToVrx(vrx)
+	if err := g.addVertex(ctx, &v); err != nil && !errors.Is(err, accountant.ErrParentDoesNotExists) {
+		g.log.Info(fmt.Sprintf("node [ %s ] adding fetched vertex [ %v ] error: %s.", g.signer.Address(), h, err))
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"gotest.tools/v3/assert"
+)
+
+func TestNextHopTTL(t *testing.T) {
+	g := &gossiper{ttl: 3}
+	for _, c := range []struct {
+		received  uint32
+		forwarded uint32
+	}{
+		{0, 2}, // Peer not supporting TTL.
+		{1, 0},
+		{2, 1},
+		{3, 2},
+		{10, 2},
+	} {
+		assert.Equal(t, g.nextHopTTL(c.received), c.forwarded)
+	}
+}
+
+func TestSelectPeers(t *testing.T) {
+	g := &gossiper{nodes: make(map[string]nodeData), suspectAfter: 2, fanOut: 2}
+	for i := 0; i < 5; i++ {
+		g.nodes[fmt.Sprintf("peer-%v", i)] = nodeData{url: fmt.Sprintf("peer-%v:8080", i)}
+	}
+	g.nodes["suspected"] = nodeData{url: "suspected:8080", failures: 2}
+	set := map[string]*protobufcompiled.Gossiper{"peer-0": {Address: "peer-0"}}
+
+	available := make(map[string]struct{})
+	for _, p := range g.availablePeers(set) {
+		available[p.address] = struct{}{}
+	}
+	assert.Equal(t, len(available), 4)
+	for _, addr := range []string{"peer-0", "suspected"} {
+		_, ok := available[addr]
+		assert.Equal(t, ok, false)
+	}
+
+	selected := g.selectPeers(set)
+	assert.Equal(t, len(selected), 2)
+	assert.Assert(t, selected[0].address != selected[1].address)
+	for _, p := range selected {
+		_, ok := available[p.address]
+		assert.Equal(t, ok, true)
+	}
+
+	g.fanOut = 0
+	assert.Equal(t, len(g.selectPeers(set)), 4)
+}
+
+func TestGetLeavesMalformedHash(t *testing.T) {
+	g := &gossiper{}
+	_, err := g.GetLeaves(context.Background(), &protobufcompiled.SignedHash{Hash: []byte{1, 2, 3}})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -9,10 +9,10 @@ import (
 	"io"
 	"net"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/dagfile"
 	"github.com/bartossh/Computantis/src/grpcsecured"
 	"github.com/bartossh/Computantis/src/logger"
 	"github.com/bartossh/Computantis/src/protobufcompiled"
@@ -21,6 +21,7 @@ import (
 	"github.com/bartossh/Computantis/src/transaction"
 	"github.com/bartossh/Computantis/src/transformers"
 	"github.com/bartossh/Computantis/src/versioning"
+	"github.com/bartossh/Computantis/src/vertextransformers"
 	"golang.org/x/exp/maps"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -44,36 +45,56 @@ const (
 )
 
 const (
-	vertexGossipChCapacity         = 100
-	trxGossipChCappacity           = 100
-	rejectHashChCapacity           = 80
-	totalRetries                   = 10
-	maxVertexParentProcessingCount = 250
+	vertexGossipChCapacity    = 100
+	trxGossipChCappacity      = 100
+	rejectHashChCapacity      = 80
+	totalRetries              = 10
+	missingParentFetchWorkers = 4
 )
 
 type nodeData struct {
-	conn   *grpc.ClientConn
-	client protobufcompiled.GossipAPIClient
-	url    string
+	conn      *grpc.ClientConn
+	client    protobufcompiled.GossipAPIClient
+	url       string
+	failures  int       // Consecutive failed Alive probes.
+	nextProbe time.Time // Time of the next Alive probe, backed off when the node fails.
 }
 
 // Config is a configuration for the gossip node.
 type Config struct {
-	URL             string        `yaml:"url"`
-	GenesisURL      string        `yaml:"genesis_url"`
-	LoadDagURL      string        `yaml:"load_dag_url"`
-	GenesisReceiver string        `yaml:"genesis_receiver"`
-	Cert            string        `yaml:"certificate"`
-	Key             string        `yaml:"key"`
-	CA              string        `yaml:"ca_cert"`
-	GenesisSpice    spice.Melange `yaml:"genesis_spice"`
-	Port            int           `yaml:"port"`
+	URL                 string        `yaml:"url"`
+	GenesisURL          string        `yaml:"genesis_url"` // The first seed node URL.
+	SeedURLs            []string      `yaml:"seed_urls"`   // Seed node URLs the node discovers the network from.
+	PeersFile           string        `yaml:"peers_file"`  // Path to the file the peer table is persisted in, not persisted if empty.
+	LoadDagURL          string        `yaml:"load_dag_url"`
+	LoadDagFile         string        `yaml:"load_dag_file"`
+	GenesisReceiver     string        `yaml:"genesis_receiver"`
+	Cert                string        `yaml:"certificate"`
+	Key                 string        `yaml:"key"`
+	CA                  string        `yaml:"ca_cert"`
+	GenesisSpice        spice.Melange `yaml:"genesis_spice"`
+	Port                int           `yaml:"port"`
+	FanOut              int           `yaml:"fan_out"`               // Number of random peers the gossip is forwarded to per hop, all peers if zero.
+	GossipTTL           int           `yaml:"gossip_ttl"`            // Number of hops the gossip travels, defaults to defaultGossipTTL.
+	PullInterval        int           `yaml:"pull_interval"`         // Seconds between pulls of the random peer leaves, defaults to defaultPullInterval.
+	ReconcileInterval   int           `yaml:"reconcile_interval"`    // Seconds between DAG reconciliations with the random peer, defaults to defaultReconcileInterval.
+	HealthCheckInterval int           `yaml:"health_check_interval"` // Seconds between Alive probes of the peers, defaults to defaultHealthCheckInterval.
+	SuspectAfter        int           `yaml:"suspect_after"`         // Consecutive failed probes after which the peer is suspected, defaults to defaultSuspectAfter.
+	EvictAfter          int           `yaml:"evict_after"`           // Consecutive failed probes after which the peer is evicted, defaults to defaultEvictAfter.
+
+	Validity transaction.Validity `yaml:"-"` // Transaction validity, set from the transaction validity configuration.
 }
 
 func (c Config) verify() error {
 	if c.Port < 0 || c.Port > 65535 {
 		return fmt.Errorf("allowed port range is 0 to 65535, got %v", c.Port)
 	}
+	if c.FanOut < 0 || c.GossipTTL < 0 || c.PullInterval < 0 || c.ReconcileInterval < 0 {
+		return fmt.Errorf("fan out, gossip TTL, pull and reconcile intervals cannot be negative")
+	}
+	if c.HealthCheckInterval < 0 || c.SuspectAfter < 0 || c.EvictAfter < 0 {
+		return fmt.Errorf("health check interval, suspect after and evict after cannot be negative")
+	}
 	return nil
 }
 
@@ -87,7 +108,12 @@ type accounter interface {
 	StreamDAG(ctx context.Context) <-chan *accountant.Vertex
 	LoadDag(cancelF context.CancelCauseFunc, cVrx <-chan *accountant.Vertex)
 	DagLoaded() bool
+	DiscardDag() error
+	MissingParents() <-chan [32]byte
+	Reattached() <-chan *accountant.Vertex
 	ReadVertex(ctx context.Context, h [32]byte) (accountant.Vertex, error)
+	ReadLeaves() [][32]byte
+	ReadDAGHashes() [][32]byte
 }
 
 type piper interface {
@@ -97,26 +123,36 @@ type piper interface {
 
 type gossiper struct {
 	protobufcompiled.UnimplementedGossipAPIServer
-	accounter             accounter
-	verifier              signatureVerifier
-	signer                accountant.Signer
-	log                   logger.Logger
-	trxCache              providers.AwaitedTrxCacheProviderBalanceCacher
-	flash                 providers.FlashbackMemoryHashProviderAddressRemover
-	piper                 piper
-	nodes                 map[string]nodeData
-	url                   string
-	clientOptions         []grpc.DialOption
-	mux                   sync.RWMutex
-	timeout               time.Duration
-	processingParentCount atomic.Int32
+	accounter           accounter
+	verifier            signatureVerifier
+	signer              accountant.Signer
+	log                 logger.Logger
+	trxCache            providers.AwaitedTrxCacheProviderBalanceCacher
+	flash               providers.FlashbackMemoryHashProviderAddressRemover
+	piper               piper
+	nodes               map[string]nodeData
+	url                 string
+	clientOptions       []grpc.DialOption
+	mux                 sync.RWMutex
+	timeout             time.Duration
+	validity            transaction.Validity
+	fanOut              int
+	ttl                 uint32
+	pullInterval        time.Duration
+	reconcileInterval   time.Duration
+	healthCheckInterval time.Duration
+	suspectAfter        int
+	evictAfter          int
+	dead                map[string]string
+	tele                providers.GaugeProvider
+	peersFile           string
 }
 
 // RunGRPC runs the service application that exposes the GRPC API for gossip protocol.
 // To stop server cancel the context.
 func RunGRPC(ctx context.Context, cfg Config, l logger.Logger, t time.Duration, s accountant.Signer,
 	v signatureVerifier, a accounter, trxCache providers.AwaitedTrxCacheProviderBalanceCacher,
-	flash providers.FlashbackMemoryHashProviderAddressRemover, p piper,
+	flash providers.FlashbackMemoryHashProviderAddressRemover, p piper, tele providers.GaugeProvider,
 ) error {
 	if err := cfg.verify(); err != nil {
 		return err
@@ -131,23 +167,56 @@ func RunGRPC(ctx context.Context, cfg Config, l logger.Logger, t time.Duration,
 	}
 
 	g := gossiper{
-		accounter:             a,
-		verifier:              v,
-		signer:                s,
-		log:                   l,
-		trxCache:              trxCache,
-		flash:                 flash,
-		piper:                 p,
-		nodes:                 make(map[string]nodeData),
-		url:                   cfg.URL,
-		clientOptions:         opts,
-		mux:                   sync.RWMutex{},
-		timeout:               t,
-		processingParentCount: atomic.Int32{},
-	}
-
-	switch cfg.LoadDagURL {
-	case "":
+		accounter:           a,
+		verifier:            v,
+		signer:              s,
+		log:                 l,
+		trxCache:            trxCache,
+		flash:               flash,
+		piper:               p,
+		nodes:               make(map[string]nodeData),
+		url:                 cfg.URL,
+		clientOptions:       opts,
+		mux:                 sync.RWMutex{},
+		timeout:             t,
+		validity:            cfg.Validity,
+		fanOut:              cfg.FanOut,
+		ttl:                 cfg.gossipTTL(),
+		pullInterval:        cfg.pullInterval(),
+		reconcileInterval:   cfg.reconcileInterval(),
+		healthCheckInterval: cfg.healthCheckInterval(),
+		suspectAfter:        cfg.suspectAfter(),
+		evictAfter:          cfg.evictAfter(),
+		dead:                make(map[string]string),
+		tele:                tele,
+		peersFile:           cfg.PeersFile,
+	}
+
+	known, err := readPeerTable(cfg.PeersFile)
+	if err != nil {
+		g.log.Error(fmt.Sprintf("reading peer table from [ %s ] failed, %s", cfg.PeersFile, err))
+	}
+	knownURLs := make([]string, 0, len(known))
+	for _, entry := range known {
+		knownURLs = append(knownURLs, entry.URL)
+	}
+
+	switch {
+	case g.accounter.DagLoaded():
+		g.log.Info(fmt.Sprintf("node %s recovered DAG from journal.", g.signer.Address()))
+		if cfg.LoadDagURL != "" {
+			if err := g.updateDag(ctxx, cfg.LoadDagURL); err != nil {
+				g.log.Error(fmt.Sprintf("failed syncing recovered DAG: %s", err))
+			}
+		}
+	case cfg.LoadDagFile != "":
+		n, err := dagfile.ImportFromFile(ctxx, cfg.LoadDagFile, g.accounter, g.verifier)
+		if err != nil {
+			g.log.Error(fmt.Sprintf("failed loading DAG from file [ %s ]: %s", cfg.LoadDagFile, err))
+			return err
+		}
+		g.log.Info(fmt.Sprintf("node %s loaded [ %v ] vertices from DAG file: %s.", g.signer.Address(), n, cfg.LoadDagFile))
+	case cfg.LoadDagURL == "":
 		if _, err := g.accounter.CreateGenesis(
 			"Genesis Vertex",
 			spice.New(cfg.GenesisSpice.Currency, cfg.GenesisSpice.SupplementaryCurrency),
@@ -180,8 +249,24 @@ func RunGRPC(ctx context.Context, cfg Config, l logger.Logger, t time.Duration,
 
 	protobufcompiled.RegisterGossipAPIServer(grpcServer, &g)
 
+	tele.CreateUpdateObservableGauge(connectedPeersTelemetryGauge, "Gossip peers that answer the Alive probes.")
+	tele.CreateUpdateObservableGauge(suspectedPeersTelemetryGauge, "Gossip peers that failed consecutive Alive probes.")
+	tele.CreateUpdateObservableGauge(deadPeersTelemetryGauge, "Gossip peers evicted after failing Alive probes.")
+
 	go g.runTransactionGossipProcess(ctx)
 	go g.runVertexGossipProcess(ctx)
+	for i := 0; i < missingParentFetchWorkers; i++ {
+		go g.runMissingParentFetchProcess(ctx)
+	}
+	go g.runLeavesPullProcess(ctx)
+	go g.runReconcileProcess(ctx)
+	go g.runHealthCheckProcess(ctx)
+	peerTableSaved := make(chan struct{})
+	go func() {
+		defer close(peerTableSaved)
+		g.runPeerTableSaveProcess(ctxx)
+	}()
+	defer func() { <-peerTableSaved }() // Peer table is saved before the connections are closed.
 
 	go func() {
 		err = grpcServer.Serve(lis)
@@ -196,10 +281,10 @@ func RunGRPC(ctx context.Context, cfg Config, l logger.Logger, t time.Duration,
 	defer grpcServer.GracefulStop()
 
 	if err == nil {
-		if err := g.updateNodesConnectionsFromGensisNode(ctx, cfg.GenesisURL); err != nil {
+		if err := g.discoverNodes(ctx, cfg.seedURLs(), knownURLs); err != nil {
 			g.log.Fatal(
-				fmt.Sprintf("updating nodes on genesis URL [ %s ] for node [ %s ] failed, %s",
-					cfg.GenesisURL, g.signer.Address(), err.Error(),
+				fmt.Sprintf("discovering nodes from seed URLs %v for node [ %s ] failed, %s",
+					cfg.seedURLs(), g.signer.Address(), err.Error(),
 				),
 			)
 			cancel()
@@ -222,6 +307,9 @@ func (g *gossiper) Alive(_ context.Context, _ *emptypb.Empty) (*protobufcompiled
 }
 
 func (g *gossiper) Announce(_ context.Context, cd *protobufcompiled.ConnectionData) (*emptypb.Empty, error) {
+	if len(cd.Digest) != 32 {
+		return nil, ErrDiscoveryAttemptSignatureFailed
+	}
 	err := g.validateSignature(cd.PublicAddress, cd.PublicAddress, cd.Url, cd.CreatedAt, cd.Signature, [32]byte(cd.Digest))
 	if err != nil {
 		g.log.Info(fmt.Sprintf("discovery attempt failed, public address [ %s ] with URL [ %s ], %s", cd.PublicAddress, cd.Url, err))
@@ -238,13 +326,16 @@ func (g *gossiper) Announce(_ context.Context, cd *protobufcompiled.ConnectionDa
 		return nil, err
 	}
 
-	g.nodes[cd.PublicAddress] = nd
+	g.setNode(cd.PublicAddress, nd)
 	g.log.Info(fmt.Sprintf("node [ %s ] connected to [ %s ] with URL [ %s ].", g.signer.Address(), cd.PublicAddress, cd.Url))
 
 	return &emptypb.Empty{}, nil
 }
 
 func (g *gossiper) Discover(_ context.Context, cd *protobufcompiled.ConnectionData) (*protobufcompiled.ConnectedNodes, error) {
+	if len(cd.Digest) != 32 {
+		return nil, ErrDiscoveryAttemptSignatureFailed
+	}
 	err := g.validateSignature(cd.PublicAddress, cd.PublicAddress, cd.Url, cd.CreatedAt, cd.Signature, [32]byte(cd.Digest))
 	if err != nil {
 		g.log.Info(fmt.Sprintf("discovery attempt failed, public address [ %s ] with URL [ %s ], %s", cd.PublicAddress, cd.Url, err))
@@ -263,7 +354,7 @@ func (g *gossiper) Discover(_ context.Context, cd *protobufcompiled.ConnectionDa
 		delete(g.nodes, cd.PublicAddress)
 	}
 
-	g.nodes[cd.PublicAddress] = nd
+	g.setNode(cd.PublicAddress, nd)
 	g.log.Info(fmt.Sprintf("node [ %s ] connected to [ %s ] with URL [ %s ].", g.signer.Address(), cd.PublicAddress, cd.Url))
 
 	connected := &protobufcompiled.ConnectedNodes{
@@ -306,7 +397,7 @@ streamLoop:
 		if vrx == nil {
 			break streamLoop
 		}
-		vr := mapAccountantVertexToProtoVertex(vrx)
+		vr := vertextransformers.VrxToProtoVrx(vrx)
 		var retriesCount int
 		var isSend bool
 	sendLoop:
@@ -349,9 +440,11 @@ func (g *gossiper) GossipVrx(ctx context.Context, vg *protobufcompiled.VrxMsgGos
 			g.log.Info(fmt.Sprintf("node [ %s ] adding leaf error: %s.", g.signer.Address(), err))
 			return nil, ErrFailedToProcessGossip
 		}
-		_, err := g.trxCache.RemoveAwaitedTransaction([32]byte(vg.Vertex.Transaction.Hash), vg.Vertex.Transaction.ReceiverAddress)
-		if err != nil {
-			g.log.Info(fmt.Sprintf("node [ %s ] removing trx %v from cache error: %s.", g.signer.Address(), vg.Vertex.Transaction.Hash, err))
+		for _, trx := range vertexTransactions(vg.Vertex) {
+			_, err := g.trxCache.RemoveAwaitedTransaction([32]byte(trx.Hash), trx.ReceiverAddress)
+			if err != nil {
+				g.log.Info(fmt.Sprintf("node [ %s ] removing trx %v from cache error: %s.", g.signer.Address(), trx.Hash, err))
+			}
 		}
 		digest, signature := g.signer.Sign(createGossiperMessageToSign(g.signer.Address(), [32]byte(vg.Vertex.Hash)))
 		set[g.signer.Address()] = &protobufcompiled.Gossiper{
@@ -360,20 +453,24 @@ func (g *gossiper) GossipVrx(ctx context.Context, vg *protobufcompiled.VrxMsgGos
 			Signature: signature,
 		}
 		vg.Gossipers = toSlice(set)
-		g.gossipVertex(ctx, vg, set)
+		if vg.Ttl = g.nextHopTTL(vg.Ttl); vg.Ttl > 0 {
+			g.gossipVertex(ctx, vg, set)
+		}
 
 		go func() {
-			if err := g.flash.RemoveAddress(vg.Vertex.Transaction.IssuerAddress); err != nil {
-				g.log.Error(fmt.Sprintf("confirm endpoint, removing issuer address [ %s ] from flash failed: %s", vg.Vertex.Transaction.IssuerAddress, err))
-			}
-			if err := g.flash.RemoveAddress(vg.Vertex.Transaction.ReceiverAddress); err != nil {
-				g.log.Error(fmt.Sprintf("confirm endpoint, removing receiver address [ %s ] from flash failed: %s", vg.Vertex.Transaction.ReceiverAddress, err))
-			}
-			if err := g.trxCache.RemoveBalance(vg.Vertex.Transaction.IssuerAddress); err != nil {
-				g.log.Error(fmt.Sprintf("confirm endpoint, removing cached balance for address [ %s ] from flash failed: %s", vg.Vertex.Transaction.IssuerAddress, err))
-			}
-			if err := g.trxCache.RemoveBalance(vg.Vertex.Transaction.ReceiverAddress); err != nil {
-				g.log.Error(fmt.Sprintf("confirm endpoint, removing cached balance for address [ %s ] from flash failed: %s", vg.Vertex.Transaction.ReceiverAddress, err))
+			for _, trx := range vertexTransactions(vg.Vertex) {
+				if err := g.flash.RemoveAddress(trx.IssuerAddress); err != nil {
+					g.log.Error(fmt.Sprintf("confirm endpoint, removing issuer address [ %s ] from flash failed: %s", trx.IssuerAddress, err))
+				}
+				if err := g.flash.RemoveAddress(trx.ReceiverAddress); err != nil {
+					g.log.Error(fmt.Sprintf("confirm endpoint, removing receiver address [ %s ] from flash failed: %s", trx.ReceiverAddress, err))
+				}
+				if err := g.trxCache.RemoveBalance(trx.IssuerAddress); err != nil {
+					g.log.Error(fmt.Sprintf("confirm endpoint, removing cached balance for address [ %s ] from flash failed: %s", trx.IssuerAddress, err))
+				}
+				if err := g.trxCache.RemoveBalance(trx.ReceiverAddress); err != nil {
+					g.log.Error(fmt.Sprintf("confirm endpoint, removing cached balance for address [ %s ] from flash failed: %s", trx.ReceiverAddress, err))
+				}
 			}
 		}()
 	}
@@ -408,6 +505,10 @@ func (g *gossiper) GossipTrx(ctx context.Context, tg *protobufcompiled.TrxMsgGos
 			g.log.Error(fmt.Sprintf("transaction gossiper trx %v verification failed, %s", trx.Hash, err))
 			return nil, ErrFailedToProcessGossip
 		}
+		if err := g.validity.Check(&trx, time.Now()); err != nil {
+			g.log.Error(fmt.Sprintf("transaction gossiper trx %v validity check failed, %s", trx.Hash, err))
+			return nil, ErrFailedToProcessGossip
+		}
 		if err := g.trxCache.SaveAwaitedTransaction(&trx); err != nil {
 			g.log.Error(fmt.Sprintf("transaction gossiper trx %v saving failed, %s", trx.Hash, err))
 		}
@@ -433,10 +534,29 @@ func (g *gossiper) GetVertex(ctx context.Context, in *protobufcompiled.SignedHas
 	if err != nil {
 		return nil, err
 	}
-	return mapAccountantVertexToProtoVertex(&vrx), nil
+	return vertextransformers.VrxToProtoVrx(&vrx), nil
 }
 
+// updateDag loads the DAG from the node with the given URL.
+// When the DAG is already loaded only the vertices missing in the local DAG are streamed,
+// unless the node truncated the vertices the local DAG misses, then the local DAG is discarded and the whole DAG is loaded.
 func (g *gossiper) updateDag(ctx context.Context, url string) error {
+	if g.accounter.DagLoaded() {
+		n, err := g.syncDag(ctx, url)
+		switch {
+		case err == nil:
+			g.log.Info(fmt.Sprintf("node %s synced [ %v ] missing vertices from URL: %s.", g.signer.Address(), n, url))
+			return nil
+		case errors.Is(err, ErrDagSyncGap):
+			g.log.Info(fmt.Sprintf("node %s cannot sync DAG from URL: %s, %s, loading the whole DAG.", g.signer.Address(), url, err))
+			if err := g.accounter.DiscardDag(); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+
 	nd, err := g.connectToNode(url)
 	if err != nil {
 		return err
@@ -469,7 +589,7 @@ StreamRcvLoop:
 				errx = err
 				break StreamRcvLoop
 			}
-			vrx := mapProtoVertexToAccountantVertex(vg)
+			vrx := vertextransformers.ProtoVrxToVrx(vg)
 			chVrx <- &vrx
 		}
 	}
@@ -485,26 +605,33 @@ func (g *gossiper) runVertexGossipProcess(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case vrx := <-g.piper.SubscribeToVrx():
-			if vrx == nil {
-				continue
-			}
-			vp := mapAccountantVertexToProtoVertex(vrx)
-			digest, signature := g.signer.Sign(createGossiperMessageToSign(g.signer.Address(), vrx.Hash))
-			gossiper := &protobufcompiled.Gossiper{
-				Address:   g.signer.Address(),
-				Digest:    digest[:],
-				Signature: signature,
-			}
-			vg := &protobufcompiled.VrxMsgGossip{
-				Vertex:    vp,
-				Gossipers: []*protobufcompiled.Gossiper{gossiper},
-			}
-			set := map[string]*protobufcompiled.Gossiper{g.signer.Address(): gossiper}
-			g.gossipVertex(ctx, vg, set)
+			g.gossipCreatedVertex(ctx, vrx)
+		case vrx := <-g.accounter.Reattached():
+			g.gossipCreatedVertex(ctx, vrx)
 		}
 	}
 }
 
+func (g *gossiper) gossipCreatedVertex(ctx context.Context, vrx *accountant.Vertex) {
+	if vrx == nil {
+		return
+	}
+	vp := vertextransformers.VrxToProtoVrx(vrx)
+	digest, signature := g.signer.Sign(createGossiperMessageToSign(g.signer.Address(), vrx.Hash))
+	gossiper := &protobufcompiled.Gossiper{
+		Address:   g.signer.Address(),
+		Digest:    digest[:],
+		Signature: signature,
+	}
+	vg := &protobufcompiled.VrxMsgGossip{
+		Vertex:    vp,
+		Gossipers: []*protobufcompiled.Gossiper{gossiper},
+		Ttl:       g.ttl,
+	}
+	set := map[string]*protobufcompiled.Gossiper{g.signer.Address(): gossiper}
+	g.gossipVertex(ctx, vg, set)
+}
+
 func (g *gossiper) runTransactionGossipProcess(ctx context.Context) {
 	for {
 		select {
@@ -530,13 +657,11 @@ func (g *gossiper) runTransactionGossipProcess(ctx context.Context) {
 	}
 }
 
+// gossipVertex forwards the vertex gossip to fan-out random peers that didn't gossip it yet.
 func (g *gossiper) gossipVertex(ctx context.Context, vg *protobufcompiled.VrxMsgGossip, set map[string]*protobufcompiled.Gossiper) {
 	g.mux.RLock()
 	defer g.mux.RUnlock()
-	for addr, nd := range g.nodes {
-		if _, ok := set[addr]; ok {
-			continue
-		}
+	for _, p := range g.selectPeers(set) {
 		go func(client protobufcompiled.GossipAPIClient, addr, url string) {
 			if _, err := client.GossipVrx(ctx, vg); err != nil {
 				g.log.Error(
@@ -545,17 +670,17 @@ func (g *gossiper) gossipVertex(ctx context.Context, vg *protobufcompiled.VrxMsg
 						addr, url, vg.Vertex.Hash, err),
 				)
 			}
-		}(nd.client, addr, nd.url)
+		}(p.node.client, p.address, p.node.url)
 	}
 }
 
+// gossipTransaction forwards the transaction gossip to all the peers that didn't gossip it yet.
+// Transactions are not bounded by the fan-out, as the anti-entropy pull repairs only the vertices
+// and the awaited transaction missed by the receiver node would never reach the receiver.
 func (g *gossiper) gossipTransaction(ctx context.Context, tg *protobufcompiled.TrxMsgGossip, set map[string]*protobufcompiled.Gossiper) {
 	g.mux.RLock()
 	defer g.mux.RUnlock()
-	for addr, nd := range g.nodes {
-		if _, ok := set[addr]; ok {
-			continue
-		}
+	for _, p := range g.availablePeers(set) {
 		go func(client protobufcompiled.GossipAPIClient, addr, url string) {
 			if _, err := client.GossipTrx(ctx, tg); err != nil {
 				g.log.Error(
@@ -564,7 +689,7 @@ func (g *gossiper) gossipTransaction(ctx context.Context, tg *protobufcompiled.T
 						addr, url, tg.Trx.Hash, err),
 				)
 			}
-		}(nd.client, addr, nd.url)
+		}(p.node.client, p.address, p.node.url)
 	}
 }
 
@@ -577,126 +702,73 @@ func (g *gossiper) closeAllNodesConnections() {
 	maps.Clear(g.nodes)
 }
 
-func (g *gossiper) updateNodesConnectionsFromGensisNode(ctx context.Context, genesisURL string) error {
-	if genesisURL == "" {
-		g.log.Info(fmt.Sprintf("genesis Node URL is not specified. Node [ %s ] runs as Genesis Node.", g.signer.Address()))
-		return nil
-	}
-	conn, err := grpc.Dial(genesisURL, g.clientOptions...)
-	if err != nil {
-		return fmt.Errorf("connection refused: %s", err)
-	}
-	defer func() {
-		if err := conn.Close(); err != nil {
-			g.log.Error(fmt.Sprintf("connection close error: %s", err))
+// runMissingParentFetchProcess fetches from other nodes the parents that vertices in the accountant orphan pool wait for.
+// Fetched parent that misses its own parents becomes an orphan too, so its parents are scheduled by the accountant.
+func (g *gossiper) runMissingParentFetchProcess(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case h := <-g.accounter.MissingParents():
+			g.fetchMissingParent(ctx, h)
 		}
-	}()
-
-	client := protobufcompiled.NewGossipAPIClient(conn)
-	now := uint64(time.Now().UnixNano())
-	data := initConnectionData(g.signer.Address(), g.url, now)
-	digest, signature := g.signer.Sign(data)
-
-	cd := &protobufcompiled.ConnectionData{
-		PublicAddress: g.signer.Address(),
-		Url:           g.url,
-		CreatedAt:     now,
-		Digest:        digest[:],
-		Signature:     signature,
 	}
+}
 
-	result, err := client.Discover(ctx, cd)
-	if err != nil {
-		return fmt.Errorf("result error: %s", err)
+func (g *gossiper) fetchMissingParent(ctx context.Context, h [32]byte) {
+	g.mux.RLock()
+	clients := make([]protobufcompiled.GossipAPIClient, 0, len(g.nodes))
+	for _, nd := range g.nodes {
+		clients = append(clients, nd.client)
 	}
+	g.mux.RUnlock()
 
-	g.mux.Lock()
-	defer g.mux.Unlock()
-
-	for _, n := range result.Connections {
-		if n.PublicAddress == g.signer.Address() || n.Url == g.url {
-			continue
-		}
-		err := g.validateSignature(result.SignerPublicAddress, n.PublicAddress, n.Url, n.CreatedAt, n.Signature, [32]byte(n.Digest))
-		if err != nil {
-			g.log.Warn(
-				fmt.Sprintf("received connection [ %s ] for URL [ %s ] has corrupted signature. Signer [ %s ], %s.",
-					n.PublicAddress, n.Url, result.SignerPublicAddress, err),
-			)
-			continue
-		}
-		nd, err := g.connectToNode(n.Url)
-		if err != nil {
-			g.log.Error(fmt.Sprintf("connection to  [ %s ] for URL [ %s ] failed, %s.", n.PublicAddress, n.Url, err))
-			continue
-		}
-		g.nodes[n.PublicAddress] = nd
-		g.log.Info(fmt.Sprintf("node [ %s ] connected to [ %s ] with URL [ %s ].", g.signer.Address(), n.PublicAddress, n.Url))
-
-		if n.Url == genesisURL {
-			continue
-		}
-		if _, err := nd.client.Announce(ctx, cd); err != nil {
-			g.log.Info(fmt.Sprintf("node [ %s ] connection back to [ %s ] with URL [ %s ] failed.", g.signer.Address(), n.PublicAddress, n.Url))
+	for _, client := range clients {
+		if g.fetchVertex(ctx, client, h) {
+			return
 		}
 	}
-
-	return nil
+	g.log.Info(fmt.Sprintf("node [ %s ] failed to fetch missing parent [ %v ] from [ %v ] nodes.", g.signer.Address(), h, len(clients)))
 }
 
-func (g *gossiper) processLackingParent(ctx context.Context, h [32]byte) {
-	if g.processingParentCount.Load() == maxVertexParentProcessingCount {
-		return
+// sendToAccountant adds the vertex pushed by the peer, its transactions shall be signed with the current message version.
+func (g *gossiper) sendToAccountant(ctx context.Context, vg *protobufcompiled.Vertex) error {
+	if !hasTransactions(vg) {
+		return ErrNilTrx
 	}
-	g.processingParentCount.Add(1)
-
-	digest, signature := g.signer.Sign(h[:])
-	for _, nd := range g.nodes {
-
-		vrx, err := nd.client.GetVertex(ctx, &protobufcompiled.SignedHash{
-			Address:   g.signer.Address(),
-			Data:      h[:],
-			Hash:      digest[:],
-			Signature: signature,
-		})
-		if err != nil || vrx == nil {
-			continue
-		}
-		v := mapProtoVertexToAccountantVertex(vrx)
-		if err := g.accounter.AddLeaf(ctx, &v); err != nil {
-			if errors.Is(err, accountant.ErrParentDoesNotExists) {
-				parentHases := [][32]byte{v.LeftParentHash}
-				if v.LeftParentHash != v.RightParentHash {
-					parentHases = append(parentHases, v.RightParentHash)
-				}
-				for _, parentHash := range parentHases {
-					go g.processLackingParent(ctx, parentHash)
-				}
-			}
+	v := vertextransformers.ProtoVrxToVrx(vg)
+	for _, trx := range v.Transactions() {
+		if err := trx.CheckNewMessageVersion(); err != nil {
+			return err
 		}
-		break
 	}
-	g.processingParentCount.Add(-1)
+	return g.addVertex(ctx, &v)
 }
 
-func (g *gossiper) sendToAccountant(ctx context.Context, vg *protobufcompiled.Vertex) error {
-	if vg.Transaction == nil {
-		return ErrNilTrx
+// addVertex adds the vertex received from the peer to the accountant if its transactions were valid at the vertex creation time.
+// Vertices pushed, pulled, reconciled and synced are all added with addVertex, so none of the paths accepts expired transactions.
+// Message version is not checked, so the vertices signed before the message version change are still pulled and synced.
+func (g *gossiper) addVertex(ctx context.Context, v *accountant.Vertex) error {
+	for _, trx := range v.Transactions() {
+		if err := g.validity.CheckTime(trx, v.CreatedAt); err != nil {
+			return err
+		}
 	}
-	v := mapProtoVertexToAccountantVertex(vg)
-	if err := g.accounter.AddLeaf(ctx, &v); err != nil {
-		if errors.Is(err, accountant.ErrParentDoesNotExists) {
-			parentHashes := [][32]byte{v.LeftParentHash}
-			if v.LeftParentHash != v.RightParentHash {
-				parentHashes = append(parentHashes, v.RightParentHash)
-			}
-			for _, parentHash := range parentHashes {
-				go g.processLackingParent(ctx, parentHash)
-			}
+	return g.accounter.AddLeaf(ctx, v)
+}
+
+// vertexTransactions returns the vertex transaction followed by the batch of transactions the vertex carries.
+func vertexTransactions(vg *protobufcompiled.Vertex) []*protobufcompiled.Transaction {
+	return append([]*protobufcompiled.Transaction{vg.Transaction}, vg.Batch...)
+}
+
+func hasTransactions(vg *protobufcompiled.Vertex) bool {
+	for _, trx := range vertexTransactions(vg) {
+		if trx == nil || trx.Spice == nil {
+			return false
 		}
-		return err
 	}
-	return nil
+	return true
 }
 
 func (g *gossiper) validateSignature(sigAddr, pubAddr, url string, createdAt uint64, signature []byte, hash [32]byte) error {
@@ -749,58 +821,6 @@ func toSlice(m map[string]*protobufcompiled.Gossiper) []*protobufcompiled.Gossip
 	return s
 }
 
-func mapProtoVertexToAccountantVertex(vg *protobufcompiled.Vertex) accountant.Vertex {
-	return accountant.Vertex{
-		SignerPublicAddress: vg.SignerPublicAddress,
-		CreatedAt:           time.Unix(0, int64(vg.CreatedAt)),
-		Signature:           vg.Signature,
-		Transaction: transaction.Transaction{
-			CreatedAt:         time.Unix(0, int64(vg.Transaction.CreatedAt)),
-			IssuerAddress:     vg.Transaction.IssuerAddress,
-			ReceiverAddress:   vg.Transaction.ReceiverAddress,
-			Subject:           vg.Transaction.Subject,
-			Data:              vg.Transaction.Data,
-			IssuerSignature:   vg.Transaction.IssuerSignature,
-			ReceiverSignature: vg.Transaction.ReceiverSignature,
-			Hash:              [32]byte(vg.Transaction.Hash),
-			Spice: spice.Melange{
-				Currency:              vg.Transaction.Spice.Currency,
-				SupplementaryCurrency: vg.Transaction.Spice.SupplementaryCurrency,
-			},
-		},
-		Hash:            [32]byte(vg.Hash),
-		LeftParentHash:  [32]byte(vg.LeftParentHash),
-		RightParentHash: [32]byte(vg.RightParentHash),
-		Weight:          vg.Weight,
-	}
-}
-
-func mapAccountantVertexToProtoVertex(vrx *accountant.Vertex) *protobufcompiled.Vertex {
-	return &protobufcompiled.Vertex{
-		SignerPublicAddress: vrx.SignerPublicAddress,
-		CreatedAt:           uint64(vrx.CreatedAt.UnixNano()),
-		Signature:           vrx.Signature,
-		Transaction: &protobufcompiled.Transaction{
-			Subject:           vrx.Transaction.Subject,
-			Data:              vrx.Transaction.Data,
-			Hash:              vrx.Transaction.Hash[:],
-			CreatedAt:         uint64(vrx.Transaction.CreatedAt.UnixNano()),
-			ReceiverAddress:   vrx.Transaction.ReceiverAddress,
-			IssuerAddress:     vrx.Transaction.IssuerAddress,
-			ReceiverSignature: vrx.Transaction.ReceiverSignature,
-			IssuerSignature:   vrx.Transaction.IssuerSignature,
-			Spice: &protobufcompiled.Spice{
-				Currency:              vrx.Transaction.Spice.Currency,
-				SupplementaryCurrency: vrx.Transaction.Spice.SupplementaryCurrency,
-			},
-		},
-		Hash:            vrx.Hash[:],
-		LeftParentHash:  vrx.LeftParentHash[:],
-		RightParentHash: vrx.RightParentHash[:],
-		Weight:          vrx.Weight,
-	}
-}
-
 func createGossiperMessageToSign(address string, hash [32]byte) []byte {
 	return append([]byte(address), hash[:]...)
 }
@@ -15,11 +15,13 @@ import (
 
 	"github.com/bartossh/Computantis/src/accountant"
 	"github.com/bartossh/Computantis/src/cache"
+	"github.com/bartossh/Computantis/src/grpcsecured"
 	"github.com/bartossh/Computantis/src/logging"
 	"github.com/bartossh/Computantis/src/pipe"
 	"github.com/bartossh/Computantis/src/protobufcompiled"
 	"github.com/bartossh/Computantis/src/spice"
 	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/telemetry/telemetrytest"
 	"github.com/bartossh/Computantis/src/wallet"
 	"gotest.tools/v3/assert"
 )
@@ -81,11 +83,31 @@ func (t *testAccountant) AcceptGenesis(vrx *accountant.Vertex) error {
 	return nil
 }
 
-func (t *testAccountant) StreamDAG(ctx context.Context) (<-chan *accountant.Vertex, <-chan error) {
-	return nil, nil
+func (t *testAccountant) StreamDAG(ctx context.Context) <-chan *accountant.Vertex {
+	return nil
+}
+
+func (t *testAccountant) LoadDag(cancelF context.CancelCauseFunc, cVrx <-chan *accountant.Vertex) {
+}
+
+func (t *testAccountant) MissingParents() <-chan [32]byte {
+	return nil
 }
 
-func (t *testAccountant) LoadDag(ctx context.Context, cancelF context.CancelCauseFunc, cVrx <-chan *accountant.Vertex) {
+func (t *testAccountant) Reattached() <-chan *accountant.Vertex {
+	return nil
+}
+
+func (t *testAccountant) ReadVertex(ctx context.Context, h [32]byte) (accountant.Vertex, error) {
+	return accountant.Vertex{}, accountant.ErrVertexHashNotfound
+}
+
+func (t *testAccountant) ReadLeaves() [][32]byte {
+	return nil
+}
+
+func (t *testAccountant) ReadDAGHashes() [][32]byte {
+	return nil
 }
 
 func (t *testAccountant) CreateGenesis(subject string, spc spice.Melange, data []byte, publicAddress string) (accountant.Vertex, error) {
@@ -96,6 +118,10 @@ func (t *testAccountant) DagLoaded() bool {
 	return true
 }
 
+func (t *testAccountant) DiscardDag() error {
+	return nil
+}
+
 func (t *testAccountant) readCounter() uint64 {
 	return t.counter.Load()
 }
@@ -148,7 +174,7 @@ func TestDiscoverProtocol(t *testing.T) {
 
 			go func() {
 				acc := testAccountant{}
-				err := RunGRPC(ctx, genessisConfig, l, time.Second*1, &w, v, &acc, hippo, flash, juggler)
+				err := RunGRPC(ctx, genessisConfig, l, time.Second*1, &w, v, &acc, hippo, flash, juggler, &telemetrytest.Mock{})
 				assert.NilError(t, err)
 			}()
 
@@ -168,7 +194,7 @@ func TestDiscoverProtocol(t *testing.T) {
 					assert.NilError(t, err)
 					flash, err := cache.NewFlash()
 					assert.NilError(t, err)
-					err = RunGRPC(ctx, cfg, l, time.Second*1, &w, v, &acc, hippo, flash, juggler)
+					err = RunGRPC(ctx, cfg, l, time.Second*1, &w, v, &acc, hippo, flash, juggler, &telemetrytest.Mock{})
 					assert.NilError(t, err)
 				}(cfg)
 			}
@@ -230,7 +256,7 @@ func TestGossipProtocol(t *testing.T) {
 				assert.NilError(t, err)
 				flash, err := cache.NewFlash()
 				assert.NilError(t, err)
-				err = RunGRPC(ctx, genessisConfig, l, time.Second*1, &w, v, &acc, hippo, flash, juggler)
+				err = RunGRPC(ctx, genessisConfig, l, time.Second*1, &w, v, &acc, hippo, flash, juggler, &telemetrytest.Mock{})
 				assert.NilError(t, err)
 				assert.Equal(t, acc.readCounter() >= uint64(vertexRoundsPerNode), true)
 			}()
@@ -257,7 +283,7 @@ func TestGossipProtocol(t *testing.T) {
 					assert.NilError(t, err)
 					flash, err := cache.NewFlash()
 					assert.NilError(t, err)
-					err = RunGRPC(ctx, cfg, l, time.Second*1, &w, v, &acc, hippo, flash, juggler)
+					err = RunGRPC(ctx, cfg, l, time.Second*1, &w, v, &acc, hippo, flash, juggler, &telemetrytest.Mock{})
 					assert.NilError(t, err)                                                 // if fails it means nodes are overloded or are not able to handle connections.
 					assert.Equal(t, acc.readCounter() >= uint64(vertexRoundsPerNode), true) // NOTE: The assertion for test of gossip protoco happens here.
 					// NOTE: we want to each node to receive exactly the amount of propagated certexes per each node.
@@ -268,14 +294,16 @@ func TestGossipProtocol(t *testing.T) {
 			for _, port := range c.nodes {
 				wg.Add(1)
 				go func(port int) {
-					nd, err := connectToNode(fmt.Sprintf("localhost:%v", port))
+					opts, err := grpcsecured.NewTLSClientOptions("", "*")
+					assert.NilError(t, err)
+					nd, err := (&gossiper{clientOptions: opts}).connectToNode(fmt.Sprintf("localhost:%v", port))
 					assert.NilError(t, err)
 					for i := 0; i < vertexRoundsPerNode; i++ {
 						time.Sleep(time.Millisecond)
 						vd := protobufcompiled.VrxMsgGossip{
 							Vertex: &protobufcompiled.Vertex{
-								Hash:       generateData(32), // TODO: generate real hash and Trx data when accountant is implemented
-								CreaterdAt: uint64(time.Now().UnixNano()),
+								Hash:      generateData(32), // TODO: generate real hash and Trx data when accountant is implemented
+								CreatedAt: uint64(time.Now().UnixNano()),
 								Transaction: &protobufcompiled.Transaction{
 									Hash:  generateData(32),
 									Spice: &protobufcompiled.Spice{},
@@ -336,24 +364,24 @@ func TestDAGWithGossip(t *testing.T) {
 			assert.NilError(t, err)
 
 			genessisConfigNode := Config{
-				URL:              fmt.Sprintf("localhost:%v", c.nodes[0]),
-				GenesisURL:       "",
-				Port:             c.nodes[0],
-				GenessisReceiver: genessisReceiver.Address(),
+				URL:             fmt.Sprintf("localhost:%v", c.nodes[0]),
+				GenesisURL:      "",
+				Port:            c.nodes[0],
+				GenesisReceiver: genessisReceiver.Address(),
 			}
 			genessisConfigAccountant := accountant.Config{}
 
 			var accGenesis *accountant.AccountingBook
 			go func() {
 				var err error
-				accGenesis, err = accountant.NewAccountingBook(ctx, genessisConfigAccountant, v, &w, l)
+				accGenesis, err = accountant.NewAccountingBook(ctx, genessisConfigAccountant, v, &w, &telemetrytest.Mock{}, l)
 				assert.NilError(t, err)
 				juggler := pipe.New(100, 100)
 				hippo, err := cache.New(maxEntrySize, maxCacheSizeMB)
 				assert.NilError(t, err)
 				flash, err := cache.NewFlash()
 				assert.NilError(t, err)
-				err = RunGRPC(ctx, genessisConfigNode, l, time.Second*1, &w, v, accGenesis, hippo, flash, juggler)
+				err = RunGRPC(ctx, genessisConfigNode, l, time.Second*1, &w, v, accGenesis, hippo, flash, juggler, &telemetrytest.Mock{})
 				assert.NilError(t, err)
 			}()
 
@@ -373,7 +401,7 @@ func TestDAGWithGossip(t *testing.T) {
 				}
 				w, err := wallet.New()
 				assert.NilError(t, err)
-				acc, err := accountant.NewAccountingBook(ctx, genessisConfigAccountant, v, &w, l)
+				acc, err := accountant.NewAccountingBook(ctx, genessisConfigAccountant, v, &w, &telemetrytest.Mock{}, l)
 				assert.NilError(t, err)
 				go func(cfg Config) {
 					v := wallet.NewVerifier()
@@ -386,7 +414,7 @@ func TestDAGWithGossip(t *testing.T) {
 					assert.NilError(t, err)
 					flash, err := cache.NewFlash()
 					assert.NilError(t, err)
-					err = RunGRPC(ctx, cfg, counterLogger, time.Second*1, &w, v, acc, hippo, flash, juggler)
+					err = RunGRPC(ctx, cfg, counterLogger, time.Second*1, &w, v, acc, hippo, flash, juggler, &telemetrytest.Mock{})
 					assert.NilError(t, err)
 				}(cfg)
 			}
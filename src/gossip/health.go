@@ -0,0 +1,174 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultHealthCheckInterval = time.Second * 10
+	defaultSuspectAfter        = 2
+	defaultEvictAfter          = 6
+	maxProbeBackoff            = time.Minute * 5
+)
+
+const (
+	connectedPeersTelemetryGauge = "gossip_peers_connected"
+	suspectedPeersTelemetryGauge = "gossip_peers_suspected"
+	deadPeersTelemetryGauge      = "gossip_peers_dead"
+)
+
+// healthCheckInterval returns the interval between the Alive probes of the healthy peers.
+func (c Config) healthCheckInterval() time.Duration {
+	if c.HealthCheckInterval <= 0 {
+		return defaultHealthCheckInterval
+	}
+	return time.Duration(c.HealthCheckInterval) * time.Second
+}
+
+// suspectAfter returns the number of consecutive failed probes after which the peer is suspected.
+func (c Config) suspectAfter() int {
+	if c.SuspectAfter <= 0 {
+		return defaultSuspectAfter
+	}
+	return c.SuspectAfter
+}
+
+// evictAfter returns the number of consecutive failed probes after which the peer is evicted as dead.
+func (c Config) evictAfter() int {
+	if c.EvictAfter <= 0 {
+		return defaultEvictAfter
+	}
+	return max(c.EvictAfter, c.suspectAfter())
+}
+
+// setNode sets the node connection, resetting its health, and forgets the node if it was dead.
+// Caller shall hold the nodes lock.
+func (g *gossiper) setNode(address string, nd nodeData) {
+	g.nodes[address] = nd
+	delete(g.dead, address)
+}
+
+// isSuspected returns true if the node failed at least suspect after consecutive probes.
+func (g *gossiper) isSuspected(nd nodeData) bool {
+	return nd.failures >= g.suspectAfter
+}
+
+// probeBackoff returns the time to the next probe of the failing peer, doubled with each consecutive failure.
+func (g *gossiper) probeBackoff(failures int) time.Duration {
+	backoff := g.healthCheckInterval
+	for i := 1; i < failures && backoff < maxProbeBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxProbeBackoff)
+}
+
+// runHealthCheckProcess periodically probes the peers with Alive, reconnects to the failing ones with exponential backoff
+// and evicts the peers that failed evict after consecutive probes, so the gossip mesh heals itself.
+func (g *gossiper) runHealthCheckProcess(ctx context.Context) {
+	ticker := time.NewTicker(g.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			g.checkPeers(ctx, now)
+		}
+	}
+}
+
+func (g *gossiper) checkPeers(ctx context.Context, now time.Time) {
+	g.mux.RLock()
+	due := make([]peer, 0, len(g.nodes))
+	for addr, nd := range g.nodes {
+		if now.Before(nd.nextProbe) {
+			continue
+		}
+		due = append(due, peer{address: addr, node: nd})
+	}
+	g.mux.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, p := range due {
+		wg.Add(1)
+		go func(p peer) {
+			defer wg.Done()
+			g.probePeer(ctx, p, now)
+		}(p)
+	}
+	wg.Wait()
+
+	g.reportPeersHealth()
+}
+
+func (g *gossiper) probePeer(ctx context.Context, p peer, now time.Time) {
+	ctxx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	alive, err := p.node.client.Alive(ctxx, &emptypb.Empty{})
+	if err == nil && alive.PublicAddress != p.address {
+		err = fmt.Errorf("peer answered with public address [ %s ]", alive.PublicAddress)
+	}
+
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	nd, ok := g.nodes[p.address]
+	if !ok || nd.conn != p.node.conn {
+		return // Node reconnected or was removed in the meantime.
+	}
+
+	if err == nil {
+		if g.isSuspected(nd) {
+			g.log.Info(fmt.Sprintf("node [ %s ] peer [ %s ] with URL [ %s ] recovered.", g.signer.Address(), p.address, nd.url))
+		}
+		nd.failures = 0
+		nd.nextProbe = time.Time{}
+		g.nodes[p.address] = nd
+		return
+	}
+
+	nd.failures++
+	if nd.failures >= g.evictAfter {
+		if err := nd.conn.Close(); err != nil {
+			g.log.Error(fmt.Sprintf("closing connection to address [ %s ] with URL [ %s ] failed.", p.address, nd.url))
+		}
+		delete(g.nodes, p.address)
+		g.dead[p.address] = nd.url
+		g.log.Warn(fmt.Sprintf("node [ %s ] evicted dead peer [ %s ] with URL [ %s ] after [ %v ] failed probes, %s.",
+			g.signer.Address(), p.address, nd.url, nd.failures, err))
+		return
+	}
+
+	g.log.Info(fmt.Sprintf("node [ %s ] probe [ %v ] of peer [ %s ] with URL [ %s ] failed, %s.",
+		g.signer.Address(), nd.failures, p.address, nd.url, err))
+	nd.nextProbe = now.Add(g.probeBackoff(nd.failures))
+	reconnected, err := g.connectToNode(nd.url)
+	if err != nil {
+		g.log.Error(fmt.Sprintf("reconnecting to [ %s ] with URL [ %s ] failed, %s.", p.address, nd.url, err))
+		g.nodes[p.address] = nd
+		return
+	}
+	if err := nd.conn.Close(); err != nil {
+		g.log.Error(fmt.Sprintf("closing connection to address [ %s ] with URL [ %s ] failed.", p.address, nd.url))
+	}
+	reconnected.failures, reconnected.nextProbe = nd.failures, nd.nextProbe
+	g.nodes[p.address] = reconnected
+}
+
+func (g *gossiper) reportPeersHealth() {
+	g.mux.RLock()
+	defer g.mux.RUnlock()
+	var suspected int
+	for _, nd := range g.nodes {
+		if g.isSuspected(nd) {
+			suspected++
+		}
+	}
+	g.tele.SetGauge(connectedPeersTelemetryGauge, float64(len(g.nodes)-suspected))
+	g.tele.SetGauge(suspectedPeersTelemetryGauge, float64(suspected))
+	g.tele.SetGauge(deadPeersTelemetryGauge, float64(len(g.dead)))
+}
@@ -0,0 +1,118 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bartossh/Computantis/src/logging"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gotest.tools/v3/assert"
+)
+
+type aliveClient struct {
+	protobufcompiled.GossipAPIClient
+	address string
+	err     error
+}
+
+func (c *aliveClient) Alive(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*protobufcompiled.AliveData, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &protobufcompiled.AliveData{PublicAddress: c.address}, nil
+}
+
+func TestProbeBackoff(t *testing.T) {
+	g := &gossiper{healthCheckInterval: time.Second * 10}
+	for _, c := range []struct {
+		failures int
+		backoff  time.Duration
+	}{
+		{1, time.Second * 10},
+		{2, time.Second * 20},
+		{3, time.Second * 40},
+		{100, maxProbeBackoff},
+	} {
+		assert.Equal(t, g.probeBackoff(c.failures), c.backoff)
+	}
+}
+
+func TestProbePeerSuspectsAndEvicts(t *testing.T) {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	g := &gossiper{
+		signer:              &signer,
+		log:                 logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{}),
+		nodes:               make(map[string]nodeData),
+		dead:                make(map[string]string),
+		clientOptions:       []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		timeout:             time.Second,
+		healthCheckInterval: time.Second,
+		suspectAfter:        2,
+		evictAfter:          3,
+	}
+
+	const address, url = "peer", "localhost:1"
+	conn, err := grpc.Dial(url, g.clientOptions...)
+	assert.NilError(t, err)
+	client := &aliveClient{address: address, err: errors.New("unavailable")}
+	g.nodes[address] = nodeData{conn: conn, client: client, url: url}
+
+	now := time.Now()
+	probe := func() {
+		nd, ok := g.nodes[address]
+		assert.Equal(t, ok, true)
+		// Reconnected node is probed with the fake client again.
+		nd.client = client
+		g.nodes[address] = nd
+		g.probePeer(ctx, peer{address: address, node: nd}, now)
+	}
+
+	probe()
+	assert.Equal(t, g.nodes[address].failures, 1)
+	assert.Equal(t, g.nodes[address].nextProbe, now.Add(time.Second))
+	assert.Equal(t, g.isSuspected(g.nodes[address]), false)
+	assert.Equal(t, len(g.availablePeers(nil)), 1)
+
+	probe()
+	assert.Equal(t, g.nodes[address].failures, 2)
+	assert.Equal(t, g.nodes[address].nextProbe, now.Add(time.Second*2))
+	assert.Equal(t, g.isSuspected(g.nodes[address]), true)
+	assert.Equal(t, len(g.availablePeers(nil)), 0)
+
+	// Peer answering the probe recovers.
+	client.err = nil
+	probe()
+	assert.Equal(t, g.nodes[address].failures, 0)
+	assert.Equal(t, g.isSuspected(g.nodes[address]), false)
+
+	// Peer answering with other address fails the probe.
+	client.address = "other"
+	for i := 0; i < g.evictAfter; i++ {
+		probe()
+	}
+	_, ok := g.nodes[address]
+	assert.Equal(t, ok, false)
+	assert.Equal(t, g.dead[address], url)
+
+	g.setNode(address, nodeData{url: url})
+	_, ok = g.dead[address]
+	assert.Equal(t, ok, false)
+}
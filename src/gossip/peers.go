@@ -0,0 +1,242 @@
+package gossip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+)
+
+const (
+	maxDiscoveryVisits    = 64
+	peerTableSaveInterval = time.Minute
+)
+
+var ErrNoSeedNodeAnswered = errors.New("none of the seed nodes answered the discovery")
+
+// peerEntry is the known peer persisted in the peer table file.
+type peerEntry struct {
+	Address string `json:"address"`
+	URL     string `json:"url"`
+}
+
+// seedURLs returns the genesis URL followed by the seed URLs without duplicates.
+func (c Config) seedURLs() []string {
+	urls := make([]string, 0, len(c.SeedURLs)+1)
+	for _, url := range append([]string{c.GenesisURL}, c.SeedURLs...) {
+		if url == "" || url == c.URL || slices.Contains(urls, url) {
+			continue
+		}
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// readPeerTable reads the peers persisted in the file, missing file holds no peers.
+func readPeerTable(path string) ([]peerEntry, error) {
+	if path == "" {
+		return nil, nil
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var peers []peerEntry
+	if err := json.Unmarshal(buf, &peers); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// savePeerTable persists the connected peers, so the restarted node reconnects to them.
+// File is replaced atomically, so the node stopped while saving keeps the previous peer table.
+func (g *gossiper) savePeerTable() error {
+	if g.peersFile == "" {
+		return nil
+	}
+	g.mux.RLock()
+	peers := make([]peerEntry, 0, len(g.nodes))
+	for addr, nd := range g.nodes {
+		peers = append(peers, peerEntry{Address: addr, URL: nd.url})
+	}
+	g.mux.RUnlock()
+	slices.SortFunc(peers, func(a, b peerEntry) int {
+		switch {
+		case a.Address < b.Address:
+			return -1
+		case a.Address > b.Address:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	buf, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := g.peersFile + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, g.peersFile)
+}
+
+// runPeerTableSaveProcess periodically persists the peer table and persists it once more when the context is done,
+// so the node stopped between the saves keeps its latest peers.
+func (g *gossiper) runPeerTableSaveProcess(ctx context.Context) {
+	if g.peersFile == "" {
+		return
+	}
+	save := func() {
+		if err := g.savePeerTable(); err != nil {
+			g.log.Error(fmt.Sprintf("node [ %s ] saving peer table to [ %s ] failed, %s.", g.signer.Address(), g.peersFile, err))
+		}
+	}
+	ticker := time.NewTicker(peerTableSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			save()
+			return
+		case <-ticker.C:
+			save()
+		}
+	}
+}
+
+func (g *gossiper) connectionData() *protobufcompiled.ConnectionData {
+	now := uint64(time.Now().UnixNano())
+	data := initConnectionData(g.signer.Address(), g.url, now)
+	digest, signature := g.signer.Sign(data)
+	return &protobufcompiled.ConnectionData{
+		PublicAddress: g.signer.Address(),
+		Url:           g.url,
+		CreatedAt:     now,
+		Digest:        digest[:],
+		Signature:     signature,
+	}
+}
+
+// discoverNodes discovers the network starting from the seed URLs and the URLs of the known peers.
+// Each discovered node is asked recursively for its peers, up to maxDiscoveryVisits nodes. Discover call introduces the node
+// to the visited node, and the node announces itself to the discovered nodes that are not visited.
+// Returns error if seeds are given and none of the nodes answered, so the node that is not able to join the network stops.
+func (g *gossiper) discoverNodes(ctx context.Context, seeds, known []string) error {
+	if len(seeds) == 0 && len(known) == 0 {
+		g.log.Info(fmt.Sprintf("seed node URLs are not specified. Node [ %s ] runs as Genesis Node.", g.signer.Address()))
+		return nil
+	}
+
+	cd := g.connectionData()
+	queue := append(append([]string{}, seeds...), known...)
+	visited := make(map[string]struct{}, len(queue))
+	discovered := make(map[string]string) // URL to public address of discovered nodes.
+	var answered int
+	for len(queue) > 0 && len(visited) < maxDiscoveryVisits {
+		url := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[url]; ok || url == g.url {
+			continue
+		}
+		visited[url] = struct{}{}
+
+		result, err := g.discover(ctx, url, cd)
+		if err != nil {
+			g.log.Info(fmt.Sprintf("node [ %s ] discovery on URL [ %s ] failed, %s.", g.signer.Address(), url, err))
+			continue
+		}
+		answered++
+
+		for _, n := range result.Connections {
+			if n.PublicAddress == g.signer.Address() || n.Url == g.url {
+				continue
+			}
+			if len(n.Digest) != 32 {
+				g.log.Warn(
+					fmt.Sprintf("received connection [ %s ] for URL [ %s ] has malformed digest. Signer [ %s ].",
+						n.PublicAddress, n.Url, result.SignerPublicAddress),
+				)
+				continue
+			}
+			err := g.validateSignature(result.SignerPublicAddress, n.PublicAddress, n.Url, n.CreatedAt, n.Signature, [32]byte(n.Digest))
+			if err != nil {
+				g.log.Warn(
+					fmt.Sprintf("received connection [ %s ] for URL [ %s ] has corrupted signature. Signer [ %s ], %s.",
+						n.PublicAddress, n.Url, result.SignerPublicAddress, err),
+				)
+				continue
+			}
+			if _, ok := discovered[n.Url]; ok {
+				continue
+			}
+			discovered[n.Url] = n.PublicAddress
+			g.connectNode(n.PublicAddress, n.Url)
+			queue = append(queue, n.Url)
+		}
+	}
+
+	for url, address := range discovered {
+		if _, ok := visited[url]; ok {
+			continue
+		}
+		g.mux.RLock()
+		nd, ok := g.nodes[address]
+		g.mux.RUnlock()
+		if !ok {
+			continue
+		}
+		if _, err := nd.client.Announce(ctx, cd); err != nil {
+			g.log.Info(fmt.Sprintf("node [ %s ] connection back to [ %s ] with URL [ %s ] failed.", g.signer.Address(), address, url))
+		}
+	}
+
+	if answered == 0 && len(seeds) > 0 {
+		return ErrNoSeedNodeAnswered
+	}
+	return nil
+}
+
+// discover calls Discover on the node with the given URL, introducing the node to it and reading its peers.
+func (g *gossiper) discover(ctx context.Context, url string, cd *protobufcompiled.ConnectionData) (*protobufcompiled.ConnectedNodes, error) {
+	nd, err := g.connectToNode(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := nd.conn.Close(); err != nil {
+			g.log.Error(fmt.Sprintf("connection close error: %s", err))
+		}
+	}()
+	ctxx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	return nd.client.Discover(ctxx, cd)
+}
+
+// connectNode connects to the discovered node if it is not connected yet.
+func (g *gossiper) connectNode(address, url string) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if nd, ok := g.nodes[address]; ok && nd.url == url {
+		return
+	}
+	nd, err := g.connectToNode(url)
+	if err != nil {
+		g.log.Error(fmt.Sprintf("connection to  [ %s ] for URL [ %s ] failed, %s.", address, url, err))
+		return
+	}
+	if old, ok := g.nodes[address]; ok {
+		old.conn.Close()
+	}
+	g.setNode(address, nd)
+	g.log.Info(fmt.Sprintf("node [ %s ] connected to [ %s ] with URL [ %s ].", g.signer.Address(), address, url))
+}
@@ -0,0 +1,210 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bartossh/Computantis/src/logging"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+)
+
+// discoveryPeer answers the discovery with the peers it knows, signing their connection data.
+type discoveryPeer struct {
+	protobufcompiled.UnimplementedGossipAPIServer
+	w          wallet.Wallet
+	url        string
+	peers      []*discoveryPeer
+	malformed  bool // peers connection data are answered with the truncated digest
+	discovered atomic.Int64
+}
+
+func (p *discoveryPeer) connectionData(address, url string) *protobufcompiled.ConnectionData {
+	now := uint64(time.Now().UnixNano())
+	digest, signature := p.w.Sign(initConnectionData(address, url, now))
+	return &protobufcompiled.ConnectionData{PublicAddress: address, Url: url, CreatedAt: now, Digest: digest[:], Signature: signature}
+}
+
+func (p *discoveryPeer) Discover(_ context.Context, _ *protobufcompiled.ConnectionData) (*protobufcompiled.ConnectedNodes, error) {
+	p.discovered.Add(1)
+	connected := &protobufcompiled.ConnectedNodes{
+		SignerPublicAddress: p.w.Address(),
+		Connections:         []*protobufcompiled.ConnectionData{p.connectionData(p.w.Address(), p.url)},
+	}
+	for _, peer := range p.peers {
+		cd := p.connectionData(peer.w.Address(), peer.url)
+		if p.malformed {
+			cd.Digest = cd.Digest[:3]
+		}
+		connected.Connections = append(connected.Connections, cd)
+	}
+	return connected, nil
+}
+
+// discoveryNetwork serves the peers on in memory listeners, so discovery is tested without the network.
+type discoveryNetwork struct {
+	listeners map[string]*bufconn.Listener
+}
+
+func newDiscoveryNetwork(t *testing.T, peers ...*discoveryPeer) *discoveryNetwork {
+	n := &discoveryNetwork{listeners: make(map[string]*bufconn.Listener, len(peers))}
+	for _, p := range peers {
+		lis := bufconn.Listen(1024 * 1024)
+		srv := grpc.NewServer()
+		protobufcompiled.RegisterGossipAPIServer(srv, p)
+		go srv.Serve(lis)
+		t.Cleanup(srv.Stop)
+		n.listeners[p.url] = lis
+	}
+	return n
+}
+
+func (n *discoveryNetwork) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, url string) (net.Conn, error) {
+			lis, ok := n.listeners[url]
+			if !ok {
+				return nil, fmt.Errorf("no node listens on [ %s ]", url)
+			}
+			return lis.DialContext(ctx)
+		}),
+	}
+}
+
+func newDiscoveryPeer(t *testing.T, url string) *discoveryPeer {
+	w, err := wallet.New()
+	assert.NilError(t, err)
+	return &discoveryPeer{w: w, url: url}
+}
+
+func newDiscoveringGossiper(t *testing.T, n *discoveryNetwork) *gossiper {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	return &gossiper{
+		verifier:      wallet.NewVerifier(),
+		signer:        &signer,
+		log:           logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{}),
+		nodes:         make(map[string]nodeData),
+		dead:          make(map[string]string),
+		url:           "joining",
+		clientOptions: n.dialOptions(),
+		timeout:       time.Second,
+	}
+}
+
+func TestDiscoverNodesRecursively(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Each peer knows only the next one, so the joining node finds the last peer only through recursive discovery.
+	peers := make([]*discoveryPeer, 0, 4)
+	for i := 0; i < 4; i++ {
+		peers = append(peers, newDiscoveryPeer(t, fmt.Sprintf("peer-%v", i)))
+	}
+	for i := range peers[:len(peers)-1] {
+		peers[i].peers = []*discoveryPeer{peers[i+1]}
+	}
+	g := newDiscoveringGossiper(t, newDiscoveryNetwork(t, peers...))
+	defer g.closeAllNodesConnections()
+
+	err := g.discoverNodes(ctx, []string{peers[0].url}, []string{peers[1].url})
+	assert.NilError(t, err)
+	assert.Equal(t, len(g.nodes), len(peers))
+	for _, p := range peers {
+		nd, ok := g.nodes[p.w.Address()]
+		assert.Equal(t, ok, true)
+		assert.Equal(t, nd.url, p.url)
+		assert.Equal(t, p.discovered.Load(), int64(1))
+	}
+}
+
+func TestDiscoverNodesNoSeedAnswered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g := newDiscoveringGossiper(t, newDiscoveryNetwork(t))
+	assert.ErrorIs(t, g.discoverNodes(ctx, []string{"unreachable"}, nil), ErrNoSeedNodeAnswered)
+	assert.NilError(t, g.discoverNodes(ctx, nil, []string{"unreachable"}))
+	assert.NilError(t, g.discoverNodes(ctx, nil, nil))
+}
+
+func TestDiscoverNodesSkipsMalformedDigest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peers := []*discoveryPeer{newDiscoveryPeer(t, "peer-0"), newDiscoveryPeer(t, "peer-1")}
+	peers[0].peers = []*discoveryPeer{peers[1]}
+	peers[0].malformed = true
+	g := newDiscoveringGossiper(t, newDiscoveryNetwork(t, peers...))
+	defer g.closeAllNodesConnections()
+
+	err := g.discoverNodes(ctx, []string{peers[0].url}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(g.nodes), 1)
+	_, ok := g.nodes[peers[0].w.Address()]
+	assert.Equal(t, ok, true)
+	assert.Equal(t, peers[1].discovered.Load(), int64(0))
+
+	_, err = g.Discover(ctx, &protobufcompiled.ConnectionData{PublicAddress: peers[1].w.Address(), Url: peers[1].url, Digest: []byte{1, 2, 3}})
+	assert.ErrorIs(t, err, ErrDiscoveryAttemptSignatureFailed)
+	_, err = g.Announce(ctx, &protobufcompiled.ConnectionData{PublicAddress: peers[1].w.Address(), Url: peers[1].url})
+	assert.ErrorIs(t, err, ErrDiscoveryAttemptSignatureFailed)
+}
+
+func TestPeerTableRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peers := []*discoveryPeer{newDiscoveryPeer(t, "peer-0"), newDiscoveryPeer(t, "peer-1")}
+	peers[0].peers = []*discoveryPeer{peers[1]}
+	g := newDiscoveringGossiper(t, newDiscoveryNetwork(t, peers...))
+	defer g.closeAllNodesConnections()
+	assert.NilError(t, g.discoverNodes(ctx, []string{peers[0].url}, nil))
+
+	entries, err := readPeerTable("")
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+	g.peersFile = filepath.Join(t.TempDir(), "peers.json")
+	entries, err = readPeerTable(g.peersFile)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+
+	// Peer table is saved when the save process stops.
+	ctxSave, cancelSave := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.runPeerTableSaveProcess(ctxSave)
+	}()
+	cancelSave()
+	<-done
+
+	entries, err = readPeerTable(g.peersFile)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), len(peers))
+	for i := range entries {
+		if i > 0 {
+			assert.Assert(t, entries[i-1].Address < entries[i].Address)
+		}
+		nd, ok := g.nodes[entries[i].Address]
+		assert.Equal(t, ok, true)
+		assert.Equal(t, entries[i].URL, nd.url)
+	}
+}
@@ -0,0 +1,119 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/bartossh/Computantis/src/bloom"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+)
+
+const (
+	defaultReconcileInterval = time.Second * 30
+	maxReconcileHashes       = 1000
+)
+
+// reconcileInterval returns the interval between anti-entropy reconciliations of the DAG with the random peer.
+func (c Config) reconcileInterval() time.Duration {
+	if c.ReconcileInterval <= 0 {
+		return defaultReconcileInterval
+	}
+	return time.Duration(c.ReconcileInterval) * time.Second
+}
+
+// Reconcile returns hashes of the DAG vertices that are missing in the bloom filter summary of the peer DAG.
+// Hashes are ordered by the vertex weight, so the peer fetching them adds parents before their children.
+func (g *gossiper) Reconcile(_ context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.VertexHashes, error) {
+	if in == nil {
+		return nil, ErrNilSignedHash
+	}
+	if len(in.Hash) != 32 {
+		return nil, ErrInvalidSignature
+	}
+	if err := g.verifier.Verify(in.Data, in.Signature, [32]byte(in.Hash), in.Address); err != nil {
+		g.log.Error(fmt.Sprintf("reconcile endpoint failed to verify signature for address: %s, %s", in.Address, err))
+		return nil, ErrInvalidSignature
+	}
+	filter, err := bloom.Decode(in.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([][]byte, 0)
+	hashes := g.accounter.ReadDAGHashes()
+	for i := range hashes {
+		if filter.Has(hashes[i]) {
+			continue
+		}
+		missing = append(missing, hashes[i][:])
+		if len(missing) == maxReconcileHashes {
+			break
+		}
+	}
+	return &protobufcompiled.VertexHashes{Hashes: missing}, nil
+}
+
+// runReconcileProcess periodically reconciles the DAG with the random peer, so nodes converge even when no vertex is gossiped.
+func (g *gossiper) runReconcileProcess(ctx context.Context) {
+	ticker := time.NewTicker(g.reconcileInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.reconcile(ctx)
+		}
+	}
+}
+
+// reconcile sends the bloom filter summary of the node DAG to the random peer and fetches the vertices the peer reports missing.
+// Filter is seeded randomly each time, so the vertex hidden by the false positive is found in the next reconciliations.
+func (g *gossiper) reconcile(ctx context.Context) {
+	g.mux.RLock()
+	peers := g.selectPeers(nil)
+	g.mux.RUnlock()
+	if len(peers) == 0 {
+		return
+	}
+	p := peers[rand.Intn(len(peers))]
+
+	hashes := g.accounter.ReadDAGHashes()
+	filter := bloom.New(len(hashes), rand.Uint64())
+	for _, h := range hashes {
+		filter.Add(h)
+	}
+	data := filter.Encode()
+	digest, signature := g.signer.Sign(data)
+
+	ctxx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	missing, err := p.node.client.Reconcile(ctxx, &protobufcompiled.SignedHash{
+		Address:   g.signer.Address(),
+		Data:      data,
+		Hash:      digest[:],
+		Signature: signature,
+	})
+	if err != nil {
+		g.log.Info(fmt.Sprintf("node [ %s ] reconciling with [ %s ] with URL [ %s ] failed, %s.", g.signer.Address(), p.address, p.node.url, err))
+		return
+	}
+
+	var fetched int
+	for _, h := range missing.Hashes {
+		if len(h) != 32 {
+			continue
+		}
+		if _, err := g.accounter.ReadVertex(ctx, [32]byte(h)); err == nil {
+			continue
+		}
+		if g.fetchVertex(ctx, p.node.client, [32]byte(h)) {
+			fetched++
+		}
+	}
+	if fetched > 0 {
+		g.log.Info(fmt.Sprintf("node [ %s ] fetched [ %v ] missing vertices reconciling with [ %s ].", g.signer.Address(), fetched, p.address))
+	}
+}
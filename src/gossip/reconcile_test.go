@@ -0,0 +1,15 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"gotest.tools/v3/assert"
+)
+
+func TestReconcileMalformedHash(t *testing.T) {
+	g := &gossiper{}
+	_, err := g.Reconcile(context.Background(), &protobufcompiled.SignedHash{Hash: []byte{1, 2, 3}})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -0,0 +1,161 @@
+package gossip
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/vertextransformers"
+)
+
+const maxSyncKnownHashes = 100_000
+
+var (
+	ErrNilDagSyncRequest  = errors.New("DAG sync request is nil")
+	ErrTooManyKnownHashes = errors.New("DAG sync request has too many known hashes")
+	ErrDagSyncGap         = errors.New("DAG sync gap, parents of the streamed vertices are truncated")
+)
+
+// dagSyncRequestData is the data signed by the node requesting the DAG sync, it binds the signature to the watermark and the known hashes.
+func dagSyncRequestData(weight uint64, known [][]byte) []byte {
+	data := binary.LittleEndian.AppendUint64(make([]byte, 0, 8+len(known)*32), weight)
+	for _, h := range known {
+		data = append(data, h...)
+	}
+	return data
+}
+
+// LoadDagSince streams the DAG vertices that are not lighter than the request weight watermark
+// and are not known to the requesting node. Vertices are streamed ordered by weight, so parents precede their children.
+// Request shall be signed by the requesting node.
+func (g *gossiper) LoadDagSince(in *protobufcompiled.DagSyncRequest, stream protobufcompiled.GossipAPI_LoadDagSinceServer) error {
+	if in == nil {
+		return ErrNilDagSyncRequest
+	}
+	if len(in.Known) > maxSyncKnownHashes {
+		return ErrTooManyKnownHashes
+	}
+	sh := in.SignedHash
+	if sh == nil {
+		return ErrNilSignedHash
+	}
+	if len(sh.Hash) != 32 {
+		return ErrInvalidSignature
+	}
+	if err := g.verifier.Verify(dagSyncRequestData(in.Weight, in.Known), sh.Signature, [32]byte(sh.Hash), sh.Address); err != nil {
+		g.log.Error(fmt.Sprintf("load DAG since endpoint failed to verify signature for address: %s, %s", sh.Address, err))
+		return ErrInvalidSignature
+	}
+	known := make(map[[32]byte]struct{}, len(in.Known))
+	for _, h := range in.Known {
+		if len(h) != 32 {
+			continue
+		}
+		known[[32]byte(h)] = struct{}{}
+	}
+
+	ctx := stream.Context()
+	for _, h := range g.accounter.ReadDAGHashes() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, ok := known[h]; ok {
+			continue
+		}
+		vrx, err := g.accounter.ReadVertex(ctx, h)
+		if err != nil || vrx.Weight < in.Weight {
+			continue
+		}
+		if err := stream.Send(vertextransformers.VrxToProtoVrx(&vrx)); err != nil {
+			g.log.Error(fmt.Sprintf("GRPC streaming DAG since weight [ %v ] failed: %v", in.Weight, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// syncDag streams from the node with the given URL only the vertices missing in the local DAG and adds them to the accountant.
+// The weight watermark is the weight of the lightest known vertex, lighter vertices are already truncated from the local DAG.
+// Vertices waiting in the orphan pool for missing parents are not counted as added.
+// Parents of the first streamed vertices are neither in the local DAG nor streamed before them when the node truncated
+// the vertices the local DAG misses, then ErrDagSyncGap is returned before any vertex is added.
+func (g *gossiper) syncDag(ctx context.Context, url string) (int, error) {
+	hashes := g.accounter.ReadDAGHashes()
+	if len(hashes) > maxSyncKnownHashes {
+		hashes = hashes[len(hashes)-maxSyncKnownHashes:]
+	}
+	var watermark uint64
+	if len(hashes) > 0 {
+		if vrx, err := g.accounter.ReadVertex(ctx, hashes[0]); err == nil {
+			watermark = vrx.Weight
+		}
+	}
+	known := make([][]byte, 0, len(hashes))
+	for i := range hashes {
+		known = append(known, hashes[i][:])
+	}
+
+	nd, err := g.connectToNode(url)
+	if err != nil {
+		return 0, err
+	}
+	defer nd.conn.Close()
+
+	digest, signature := g.signer.Sign(dagSyncRequestData(watermark, known))
+	stream, err := nd.client.LoadDagSince(ctx, &protobufcompiled.DagSyncRequest{
+		Weight: watermark,
+		Known:  known,
+		SignedHash: &protobufcompiled.SignedHash{
+			Address:   g.signer.Address(),
+			Hash:      digest[:],
+			Signature: signature,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var added int
+	streamed := make(map[[32]byte]struct{})
+	for {
+		vg, err := stream.Recv()
+		if err == io.EOF {
+			return added, nil
+		}
+		if err != nil {
+			return added, err
+		}
+		vrx := vertextransformers.ProtoVrxToVrx(vg)
+		if added == 0 && !g.parentsKnown(ctx, &vrx, streamed) {
+			return 0, ErrDagSyncGap
+		}
+		streamed[vrx.Hash] = struct{}{}
+		if !hasTransactions(vg) {
+			continue
+		}
+		switch err := g.addVertex(ctx, &vrx); {
+		case err == nil:
+			added++
+		case errors.Is(err, accountant.ErrParentDoesNotExists):
+		default:
+			g.log.Info(fmt.Sprintf("node [ %s ] adding synced vertex [ %v ] error: %s.", g.signer.Address(), vrx.Hash, err))
+		}
+	}
+}
+
+// parentsKnown returns true if the vertex parents are in the local DAG or have been streamed before the vertex.
+func (g *gossiper) parentsKnown(ctx context.Context, vrx *accountant.Vertex, streamed map[[32]byte]struct{}) bool {
+	for _, h := range [][32]byte{vrx.LeftParentHash, vrx.RightParentHash} {
+		if _, ok := streamed[h]; ok {
+			continue
+		}
+		if _, err := g.accounter.ReadVertex(ctx, h); err != nil {
+			return false
+		}
+	}
+	return true
+}
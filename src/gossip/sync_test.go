@@ -0,0 +1,206 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/logging"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/stdoutwriter"
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/bartossh/Computantis/src/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+)
+
+// syncAccounter holds the DAG vertices ordered by weight, vertex is added only when its parents are in the DAG.
+type syncAccounter struct {
+	accounter
+	hashes   [][32]byte
+	vertices map[[32]byte]accountant.Vertex
+}
+
+func newSyncAccounter(vertices ...accountant.Vertex) *syncAccounter {
+	a := &syncAccounter{vertices: make(map[[32]byte]accountant.Vertex)}
+	for _, vrx := range vertices {
+		a.add(vrx)
+	}
+	return a
+}
+
+func (a *syncAccounter) add(vrx accountant.Vertex) {
+	a.hashes = append(a.hashes, vrx.Hash)
+	a.vertices[vrx.Hash] = vrx
+}
+
+func (a *syncAccounter) AddLeaf(_ context.Context, leaf *accountant.Vertex) error {
+	for _, h := range [][32]byte{leaf.LeftParentHash, leaf.RightParentHash} {
+		if _, ok := a.vertices[h]; !ok {
+			return accountant.ErrParentDoesNotExists
+		}
+	}
+	a.add(*leaf)
+	return nil
+}
+
+func (a *syncAccounter) ReadVertex(_ context.Context, h [32]byte) (accountant.Vertex, error) {
+	vrx, ok := a.vertices[h]
+	if !ok {
+		return accountant.Vertex{}, accountant.ErrVertexHashNotfound
+	}
+	return vrx, nil
+}
+
+func (a *syncAccounter) ReadDAGHashes() [][32]byte {
+	return a.hashes
+}
+
+type dagSinceStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent [][]byte
+}
+
+func (s *dagSinceStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *dagSinceStream) Send(vg *protobufcompiled.Vertex) error {
+	s.sent = append(s.sent, vg.Hash)
+	return nil
+}
+
+// syncVertices creates the chain of vertices, each approving the previous one, with the weight growing along the chain.
+// First vertex approves the given parent.
+func syncVertices(t *testing.T, parent [32]byte, count int) []accountant.Vertex {
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	receiver, err := wallet.New()
+	assert.NilError(t, err)
+	vertices := make([]accountant.Vertex, 0, count)
+	for i := 0; i < count; i++ {
+		trx, err := transaction.New(fmt.Sprintf("Sync %v", i), spice.New(10, 0), []byte{}, receiver.Address(), &signer)
+		assert.NilError(t, err)
+		vrx, err := accountant.NewVertex(trx, parent, parent, uint64(i+1), spice.Melange{}, &signer)
+		assert.NilError(t, err)
+		vertices = append(vertices, vrx)
+		parent = vrx.Hash
+	}
+	return vertices
+}
+
+func newSyncGossiper(t *testing.T, a accounter) *gossiper {
+	callOnLogErr := func(err error) {
+		fmt.Printf("logger failed with error: %s\n", err)
+	}
+	callOnFail := func(err error) {
+		fmt.Printf("Failed with error: %s\n", err)
+	}
+	signer, err := wallet.New()
+	assert.NilError(t, err)
+	return &gossiper{
+		accounter: a,
+		verifier:  wallet.NewVerifier(),
+		signer:    &signer,
+		log:       logging.New(callOnLogErr, callOnFail, &stdoutwriter.Logger{}),
+	}
+}
+
+func signedDagSyncRequest(g *gossiper, weight uint64, known [][]byte) *protobufcompiled.DagSyncRequest {
+	digest, signature := g.signer.Sign(dagSyncRequestData(weight, known))
+	return &protobufcompiled.DagSyncRequest{
+		Weight:     weight,
+		Known:      known,
+		SignedHash: &protobufcompiled.SignedHash{Address: g.signer.Address(), Hash: digest[:], Signature: signature},
+	}
+}
+
+func TestLoadDagSince(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vertices := syncVertices(t, [32]byte{}, 4)
+	g := newSyncGossiper(t, newSyncAccounter(vertices...))
+
+	// Vertices lighter than the watermark and the known ones are not streamed, malformed known hash is ignored.
+	stream := &dagSinceStream{ctx: ctx}
+	err := g.LoadDagSince(signedDagSyncRequest(g, vertices[1].Weight, [][]byte{vertices[2].Hash[:], {1, 2, 3}}), stream)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stream.sent, [][]byte{vertices[1].Hash[:], vertices[3].Hash[:]})
+
+	assert.ErrorIs(t, g.LoadDagSince(nil, stream), ErrNilDagSyncRequest)
+	known := make([][]byte, maxSyncKnownHashes+1)
+	assert.ErrorIs(t, g.LoadDagSince(&protobufcompiled.DagSyncRequest{Known: known}, stream), ErrTooManyKnownHashes)
+
+	// Request shall be signed and the signature shall cover the watermark and the known hashes.
+	stream = &dagSinceStream{ctx: ctx}
+	assert.ErrorIs(t, g.LoadDagSince(&protobufcompiled.DagSyncRequest{}, stream), ErrNilSignedHash)
+	unsigned := signedDagSyncRequest(g, 0, nil)
+	unsigned.SignedHash.Hash = []byte{1, 2, 3}
+	assert.ErrorIs(t, g.LoadDagSince(unsigned, stream), ErrInvalidSignature)
+	tampered := signedDagSyncRequest(g, vertices[3].Weight, nil)
+	tampered.Weight = 0
+	assert.ErrorIs(t, g.LoadDagSince(tampered, stream), ErrInvalidSignature)
+	assert.Equal(t, len(stream.sent), 0)
+}
+
+func TestSyncDag(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vertices := syncVertices(t, [32]byte{}, 4)
+	orphan := syncVertices(t, [32]byte{1}, 1)[0]
+	orphan.Weight = vertices[3].Weight
+	remote := newSyncGossiper(t, newSyncAccounter(append(vertices, orphan)...))
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	protobufcompiled.RegisterGossipAPIServer(srv, remote)
+	go srv.Serve(lis)
+	defer srv.Stop()
+	n := &discoveryNetwork{listeners: map[string]*bufconn.Listener{"remote": lis}}
+
+	// Orphan waiting for the missing parent is not counted as added.
+	local := newSyncAccounter(vertices[:2]...)
+	g := newSyncGossiper(t, local)
+	g.clientOptions = n.dialOptions()
+	added, err := g.syncDag(ctx, "remote")
+	assert.NilError(t, err)
+	assert.Equal(t, added, 2)
+	assert.Equal(t, len(local.vertices), 4)
+
+	// Remote node truncated the vertices the local DAG misses.
+	local = newSyncAccounter(vertices[0])
+	remote.accounter = newSyncAccounter(vertices[2:]...)
+	g.accounter = local
+	added, err = g.syncDag(ctx, "remote")
+	assert.ErrorIs(t, err, ErrDagSyncGap)
+	assert.Equal(t, added, 0)
+	assert.Equal(t, len(local.vertices), 1)
+}
+
+func TestAddVertexChecksValidity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vertices := syncVertices(t, [32]byte{}, 2)
+	local := newSyncAccounter(vertices[0])
+	g := newSyncGossiper(t, local)
+	g.validity = transaction.Validity{Expiration: 60, ClockSkew: 10}
+
+	expired := vertices[1]
+	expired.CreatedAt = expired.Transaction.CreatedAt.Add(time.Hour)
+	assert.ErrorIs(t, g.addVertex(ctx, &expired), transaction.ErrExpiredTransaction)
+	future := vertices[1]
+	future.CreatedAt = future.Transaction.CreatedAt.Add(-time.Hour)
+	assert.ErrorIs(t, g.addVertex(ctx, &future), transaction.ErrTransactionHasAFutureTime)
+	assert.Equal(t, len(local.vertices), 1)
+
+	assert.NilError(t, g.addVertex(ctx, &vertices[1]))
+	assert.Equal(t, len(local.vertices), 2)
+}
@@ -0,0 +1,172 @@
+package inclusion
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+)
+
+var (
+	ErrProofIsEmpty           = errors.New("inclusion proof is empty")
+	ErrTrxNotInVertex         = errors.New("transaction is not in the first vertex of the proof")
+	ErrVertexSignatureInvalid = errors.New("vertex signature is invalid")
+	ErrBrokenChain            = errors.New("vertex is not a child of the previous vertex in the proof")
+	ErrWrongWeight            = errors.New("vertex weight is not greater than previous vertex weight")
+	ErrTrxNotInBatch          = errors.New("transaction is not in the batch")
+	ErrBatchRootMismatch      = errors.New("batch merkle root doesn't match the first vertex transactions root")
+)
+
+type verifier interface {
+	Verify(message, signature []byte, hash [32]byte, address string) error
+}
+
+// Step is a single vertex in the inclusion proof.
+// It holds all the vertex data that are signed by the vertex signer, without the transaction details.
+// TrxHash is the Merkle root of the hashes of the transactions in the vertex, that is the transaction hash
+// for the vertex carrying a single transaction.
+type Step struct {
+	SignerPublicAddress string
+	CreatedAt           time.Time
+	Signature           []byte
+	TrxHash             [32]byte
+	Hash                [32]byte
+	LeftParentHash      [32]byte
+	RightParentHash     [32]byte
+	Weight              uint64
+	Fee                 spice.Melange
+}
+
+// Proof is the proof of transaction inclusion in the DAG.
+// Steps are ordered from the vertex containing the transaction to the leaf of the DAG,
+// each next step is a child of the previous one.
+// Batch holds hashes of all the transactions in the first vertex if it carries a batch of transactions.
+type Proof struct {
+	TrxHash [32]byte
+	Steps   []Step
+	Batch   [][32]byte
+}
+
+// Leaf returns hash of the DAG leaf the proof leads to.
+func (p Proof) Leaf() [32]byte {
+	if len(p.Steps) == 0 {
+		return [32]byte{}
+	}
+	return p.Steps[len(p.Steps)-1].Hash
+}
+
+// Leaf and internal node hashes are prefixed differently, so the internal node cannot be presented as a leaf.
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// MerkleRoot calculates the Merkle root of the given hashes.
+// The root of a single hash is the hash itself, so the vertex carrying a single transaction signs the transaction hash.
+// Odd hash at any level is promoted to the next level, so no two different lists of hashes share the root.
+func MerkleRoot(hashes [][32]byte) [32]byte {
+	switch len(hashes) {
+	case 0:
+		return [32]byte{}
+	case 1:
+		return hashes[0]
+	}
+	level := make([][32]byte, 0, len(hashes))
+	for _, h := range hashes {
+		level = append(level, sha256.Sum256(append([]byte{merkleLeafPrefix}, h[:]...)))
+	}
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i+1 < len(level); i += 2 {
+			next = append(next, merkleNode(level[i], level[i+1]))
+		}
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func merkleNode(left, right [32]byte) [32]byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, merkleNodePrefix)
+	data = append(data, left[:]...)
+	return sha256.Sum256(append(data, right[:]...))
+}
+
+// VertexMessage creates the message that vertex signer signs.
+// Fee is a part of the message only when charged, so the message of the vertex without fee stays unchanged.
+func VertexMessage(trxHash, leftParentHash, rightParentHash [32]byte, createdAt time.Time, weight uint64, fee spice.Melange) []byte {
+	blockData := make([]byte, 0, 32)
+	blockData = binary.LittleEndian.AppendUint64(blockData, uint64(createdAt.UnixNano()))
+	blockData = binary.LittleEndian.AppendUint64(blockData, weight)
+	if !fee.Empty() {
+		blockData = binary.LittleEndian.AppendUint64(blockData, fee.Currency)
+		blockData = binary.LittleEndian.AppendUint64(blockData, fee.SupplementaryCurrency)
+	}
+	return bytes.Join([][]byte{
+		trxHash[:], leftParentHash[:], rightParentHash[:], blockData,
+	},
+		[]byte{},
+	)
+}
+
+// Verify verifies the inclusion proof using only public addresses of vertices signers.
+// Proof is valid if the first vertex contains the transaction, every vertex is signed by its signer
+// and every vertex is a child of the previous one. It is up to the caller to decide if the proof leaf
+// and vertices signers are trusted.
+func Verify(p Proof, v verifier) error {
+	if len(p.Steps) == 0 {
+		return ErrProofIsEmpty
+	}
+	if err := verifyBatch(p); err != nil {
+		return err
+	}
+
+	for i, s := range p.Steps {
+		message := VertexMessage(s.TrxHash, s.LeftParentHash, s.RightParentHash, s.CreatedAt, s.Weight, s.Fee)
+		if err := v.Verify(message, s.Signature, s.Hash, s.SignerPublicAddress); err != nil {
+			return errors.Join(ErrVertexSignatureInvalid, fmt.Errorf("step [ %v ], %w", i, err))
+		}
+		if i == 0 {
+			continue
+		}
+		prev := p.Steps[i-1]
+		if s.LeftParentHash != prev.Hash && s.RightParentHash != prev.Hash {
+			return errors.Join(ErrBrokenChain, fmt.Errorf("step [ %v ]", i))
+		}
+		if s.Weight <= prev.Weight {
+			return errors.Join(ErrWrongWeight, fmt.Errorf("step [ %v ]", i))
+		}
+	}
+
+	return nil
+}
+
+func verifyBatch(p Proof) error {
+	if len(p.Batch) == 0 {
+		if p.Steps[0].TrxHash != p.TrxHash {
+			return ErrTrxNotInVertex
+		}
+		return nil
+	}
+	var found bool
+	for _, h := range p.Batch {
+		if h == p.TrxHash {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrTrxNotInBatch
+	}
+	if MerkleRoot(p.Batch) != p.Steps[0].TrxHash {
+		return ErrBatchRootMismatch
+	}
+	return nil
+}
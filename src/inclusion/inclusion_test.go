@@ -0,0 +1,81 @@
+package inclusion
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"github.com/bartossh/Computantis/src/wallet"
+	"gotest.tools/v3/assert"
+)
+
+func signedStep(w *wallet.Wallet, trxHash, left, right [32]byte, weight uint64) Step {
+	s := Step{
+		SignerPublicAddress: w.Address(),
+		CreatedAt:           time.Now(),
+		TrxHash:             trxHash,
+		LeftParentHash:      left,
+		RightParentHash:     right,
+		Weight:              weight,
+	}
+	s.Hash, s.Signature = w.Sign(VertexMessage(s.TrxHash, s.LeftParentHash, s.RightParentHash, s.CreatedAt, s.Weight, s.Fee))
+	return s
+}
+
+func TestVerify(t *testing.T) {
+	w, err := wallet.New()
+	assert.NilError(t, err)
+	v := wallet.NewVerifier()
+
+	trxHash := sha256.Sum256([]byte("transaction"))
+	first := signedStep(&w, trxHash, [32]byte{1}, [32]byte{2}, 1)
+	second := signedStep(&w, sha256.Sum256([]byte("second")), first.Hash, [32]byte{3}, 2)
+	third := signedStep(&w, sha256.Sum256([]byte("third")), [32]byte{4}, second.Hash, 3)
+
+	proof := Proof{TrxHash: trxHash, Steps: []Step{first, second, third}}
+	assert.NilError(t, Verify(proof, v))
+	assert.Equal(t, proof.Leaf(), third.Hash)
+
+	assert.ErrorIs(t, Verify(Proof{TrxHash: trxHash}, v), ErrProofIsEmpty)
+	assert.ErrorIs(t, Verify(Proof{TrxHash: [32]byte{9}, Steps: proof.Steps}, v), ErrTrxNotInVertex)
+	assert.ErrorIs(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{first, third}}, v), ErrBrokenChain)
+
+	forged := second
+	forged.Weight = 10
+	assert.ErrorIs(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{first, forged}}, v), ErrVertexSignatureInvalid)
+
+	charged := first
+	charged.Fee = spice.New(1, 0)
+	charged.Hash, charged.Signature = w.Sign(VertexMessage(charged.TrxHash, charged.LeftParentHash, charged.RightParentHash, charged.CreatedAt, charged.Weight, charged.Fee))
+	assert.NilError(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{charged}}, v))
+	charged.Fee = spice.New(0, 1)
+	assert.ErrorIs(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{charged}}, v), ErrVertexSignatureInvalid)
+
+	heavy := signedStep(&w, sha256.Sum256([]byte("heavy")), [32]byte{}, [32]byte{}, 5)
+	light := signedStep(&w, sha256.Sum256([]byte("light")), heavy.Hash, [32]byte{}, 5)
+	assert.ErrorIs(t, Verify(Proof{TrxHash: heavy.TrxHash, Steps: []Step{heavy, light}}, v), ErrWrongWeight)
+
+	batch := [][32]byte{sha256.Sum256([]byte("a")), trxHash, sha256.Sum256([]byte("c"))}
+	assert.Equal(t, MerkleRoot(batch[1:2]), trxHash)
+	assert.Assert(t, MerkleRoot(batch) != MerkleRoot(batch[:2]))
+	assert.Assert(t, MerkleRoot(batch) != MerkleRoot(append(batch, batch[2])))
+	batched := signedStep(&w, MerkleRoot(batch), [32]byte{1}, [32]byte{2}, 1)
+	assert.NilError(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{batched}, Batch: batch}, v))
+	assert.ErrorIs(t, Verify(Proof{TrxHash: [32]byte{9}, Steps: []Step{batched}, Batch: batch}, v), ErrTrxNotInBatch)
+	assert.ErrorIs(t, Verify(Proof{TrxHash: trxHash, Steps: []Step{batched}, Batch: batch[1:]}, v), ErrBatchRootMismatch)
+}
+
+func TestMerkleRootDomainSeparation(t *testing.T) {
+	leaf := func(h [32]byte) [32]byte {
+		return sha256.Sum256(append([]byte{merkleLeafPrefix}, h[:]...))
+	}
+	hashes := [][32]byte{sha256.Sum256([]byte("a")), sha256.Sum256([]byte("b")), sha256.Sum256([]byte("c")), sha256.Sum256([]byte("d"))}
+	left := merkleNode(leaf(hashes[0]), leaf(hashes[1]))
+	right := merkleNode(leaf(hashes[2]), leaf(hashes[3]))
+
+	assert.Equal(t, MerkleRoot(hashes), merkleNode(left, right))
+	assert.Assert(t, MerkleRoot(hashes) != MerkleRoot([][32]byte{left, right}))
+	assert.Equal(t, MerkleRoot(hashes[:3]), merkleNode(left, leaf(hashes[2])))
+	assert.Assert(t, MerkleRoot(hashes[:3]) != MerkleRoot([][32]byte{hashes[0], hashes[1], hashes[2], hashes[2]}))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"time"
 
@@ -13,12 +14,14 @@ import (
 	"github.com/bartossh/Computantis/src/accountant"
 	"github.com/bartossh/Computantis/src/cache"
 	"github.com/bartossh/Computantis/src/grpcsecured"
+	"github.com/bartossh/Computantis/src/inclusion"
 	"github.com/bartossh/Computantis/src/logger"
 	"github.com/bartossh/Computantis/src/protobufcompiled"
 	"github.com/bartossh/Computantis/src/providers"
 	"github.com/bartossh/Computantis/src/transaction"
 	"github.com/bartossh/Computantis/src/transformers"
 	"github.com/bartossh/Computantis/src/versioning"
+	"github.com/bartossh/Computantis/src/vertextransformers"
 )
 
 const (
@@ -27,8 +30,11 @@ const (
 	rejectTrxTelemetryHistogram   = "reject_trx_request_duration"
 	awaitedTrxTelemetryHistogram  = "read_awaited_trx_request_duration"
 	readDagTransactionsByAddress  = "read_dag_trx_only"
+	readTransactionsByAddress     = "read_address_trx_history"
 	approvedTrxTelemetryHistogram = "read_approved_trx_request_duration"
 	balanceTelemetryHistogram     = "balance_read_duration"
+	balanceAtTelemetryHistogram   = "historical_balance_read_duration"
+	proofTelemetryHistogram       = "inclusion_proof_read_duration"
 	dataToSignTelemetryHistogram  = "data_to_sign_request_duration"
 )
 
@@ -37,6 +43,8 @@ const (
 	transactionsUpdateTick          = time.Millisecond * 1000
 )
 
+const maxTransactionsPageLimit = 1000 // maximum number of transactions read for the single page of the address history
+
 const rxNewTrxIssuerAddrBufferSize = 800 // this value shall to be slightly bigger then maximum expected transaction throughput
 
 var (
@@ -58,9 +66,14 @@ type verifier interface {
 type accounter interface {
 	Address() string
 	CreateLeaf(ctx context.Context, trx *transaction.Transaction) (accountant.Vertex, error)
-	ReadTransactionByHash(ctx context.Context, hashe [32]byte) (transaction.Transaction, error)
+	ReadTransactionByHash(ctx context.Context, hashe [32]byte) (transaction.Transaction, accountant.Confirmation, error)
 	ReadDAGTransactionsByAddress(ctx context.Context, address string) ([]transaction.Transaction, error)
+	ReadTransactionsByAddress(ctx context.Context, address string, offset, limit int) ([]transaction.Transaction, error)
 	CalculateBalance(ctx context.Context, walletPubAddr string) (accountant.Balance, error)
+	CalculateBalanceAtVertex(ctx context.Context, walletPubAddr string, vrxHash [32]byte) (accountant.Balance, error)
+	CalculateBalanceAtTime(ctx context.Context, walletPubAddr string, at time.Time) (accountant.Balance, error)
+	ReadInclusionProof(ctx context.Context, trxHash [32]byte) (inclusion.Proof, error)
+	NextNonce(issuer string) uint64
 }
 
 // RandomDataProvideValidator provides random binary data for signing to prove identity and
@@ -86,6 +99,8 @@ type Config struct {
 	Key           string `yaml:"key"`             // PEM key.
 	Port          int    `yaml:"port"`            // Port to listen on.
 	DataSizeBytes int    `yaml:"data_size_bytes"` // Size of the data to be stored in the transaction.
+
+	Validity transaction.Validity `yaml:"-"` // Transaction validity, set from the transaction validity configuration.
 }
 
 type server struct {
@@ -102,6 +117,7 @@ type server struct {
 	piper             piper
 	nodePublicURL     string
 	dataSize          int
+	validity          transaction.Validity
 }
 
 // Run initializes routing and runs the server. To stop the server cancel the context.
@@ -137,6 +153,7 @@ func Run(
 		piper:             p,
 		nodePublicURL:     c.NodePublicURL,
 		dataSize:          c.DataSizeBytes,
+		validity:          c.Validity,
 	}
 
 	s.tele.CreateUpdateObservableHistogram(proposeTrxTelemetryHistogram, "Propose trx endpoint request duration in [ ms ].")
@@ -146,6 +163,8 @@ func Run(
 	s.tele.CreateUpdateObservableHistogram(approvedTrxTelemetryHistogram, "Read approved trx endpoint request duration in [ ms ].")
 	s.tele.CreateUpdateObservableHistogram(dataToSignTelemetryHistogram, "Generate data to sign endpoint request duration in [ ms ].")
 	s.tele.CreateUpdateObservableHistogram(balanceTelemetryHistogram, "Calculate balance duration in [ ms ].")
+	s.tele.CreateUpdateObservableHistogram(balanceAtTelemetryHistogram, "Calculate historical balance duration in [ ms ].")
+	s.tele.CreateUpdateObservableHistogram(proofTelemetryHistogram, "Create transaction inclusion proof duration in [ ms ].")
 	s.tele.CreateUpdateObservableHistogram(readDagTransactionsByAddress, "Read wallet transactions from DAG in [ ms ].")
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%v", c.Port))
@@ -257,6 +276,11 @@ func (s *server) Propose(ctx context.Context, in *protobufcompiled.Transaction)
 		return nil, ErrVerification
 	}
 
+	if err := s.validity.Check(&trx, time.Now()); err != nil {
+		s.log.Error(fmt.Sprintf("propose endpoint transaction [ %x ] validity check failed: %s", trx.Hash, err))
+		return nil, err
+	}
+
 	if trx.IsContract() {
 		if len(trx.Data) > s.dataSize {
 			s.log.Error(fmt.Sprintf("propose endpoint invalid transaction data size: %d", len(trx.Data)))
@@ -328,6 +352,11 @@ func (s *server) Confirm(ctx context.Context, in *protobufcompiled.Transaction)
 		return nil, ErrVerification
 	}
 
+	if err := s.validity.Check(&trx, time.Now()); err != nil {
+		s.log.Error(fmt.Sprintf("confirm endpoint transaction [ %x ] validity check failed: %s", trx.Hash, err))
+		return nil, err
+	}
+
 	_, err = s.cache.RemoveAwaitedTransaction(trx.Hash, trx.ReceiverAddress)
 	if err != nil {
 		s.log.Error(
@@ -455,6 +484,24 @@ func (s *server) Waiting(ctx context.Context, in *protobufcompiled.SignedHash) (
 
 // Saved returns saved transactions in the graph.
 func (s *server) Saved(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.Transaction, error) {
+	protoTrx, _, err := s.readSaved(ctx, in)
+	return protoTrx, err
+}
+
+// SavedWithConfidence returns saved transactions in the graph with the confidence of its finality.
+func (s *server) SavedWithConfidence(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.SavedTransaction, error) {
+	protoTrx, confirmation, err := s.readSaved(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufcompiled.SavedTransaction{
+		Transaction: protoTrx,
+		Confidence:  vertextransformers.ConfidenceToProtoConfidence(confirmation.Level),
+		Approvers:   confirmation.Approvers,
+	}, nil
+}
+
+func (s *server) readSaved(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.Transaction, accountant.Confirmation, error) {
 	t := time.Now()
 	defer func() {
 		d := time.Since(t)
@@ -463,25 +510,55 @@ func (s *server) Saved(ctx context.Context, in *protobufcompiled.SignedHash) (*p
 
 	if err := s.verifier.Verify(in.Data, in.Signature, [32]byte(in.Hash), in.Address); err != nil {
 		s.log.Error(fmt.Sprintf("waiting endpoint failed to verify signature for address: %s, %s", in.Address, err))
-		return nil, ErrVerification
+		return nil, accountant.Confirmation{}, ErrVerification
 	}
 
-	trx, err := s.acc.ReadTransactionByHash(ctx, [32]byte(in.Data))
+	trx, confirmation, err := s.acc.ReadTransactionByHash(ctx, [32]byte(in.Data))
 	if err != nil {
 		s.log.Error(fmt.Sprintf("approved transactions endpoint failed to read hash [ %x ] for address: %s, %s", in.Hash, in.Address, err))
-		return nil, ErrProcessing
+		return nil, accountant.Confirmation{}, ErrProcessing
 	}
 
 	if trx.Hash == [32]byte{} {
 		s.log.Error(fmt.Sprintf("approved transactions endpoint failed to read hash [ %x ] for address: %s, %s", in.Hash, in.Address, ErrNoDataPresent))
-		return nil, ErrNoDataPresent
+		return nil, accountant.Confirmation{}, ErrNoDataPresent
 	}
 
 	protoTrx, err := transformers.TrxToProtoTrx(trx)
 	if err != nil {
-		return nil, err
+		return nil, accountant.Confirmation{}, err
+	}
+	return protoTrx, confirmation, nil
+}
+
+// Proof returns the proof of inclusion of the transaction in the DAG, that can be verified with vertices signers public addresses.
+func (s *server) Proof(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.InclusionProof, error) {
+	t := time.Now()
+	defer func() {
+		d := time.Since(t)
+		s.tele.RecordHistogramTime(proofTelemetryHistogram, d)
+	}()
+
+	if len(in.Data) != 32 {
+		return nil, ErrRequestIsEmpty
 	}
-	return protoTrx, nil
+
+	if len(in.Hash) != 32 {
+		return nil, ErrVerification
+	}
+
+	if err := s.verifier.Verify(in.Data, in.Signature, [32]byte(in.Hash), in.Address); err != nil {
+		s.log.Error(fmt.Sprintf("proof endpoint failed to verify signature for address: %s, %s", in.Address, err))
+		return nil, ErrVerification
+	}
+
+	proof, err := s.acc.ReadInclusionProof(ctx, [32]byte(in.Data))
+	if err != nil {
+		s.log.Error(fmt.Sprintf("proof endpoint failed to create proof for hash [ %x ] for address: %s, %s", in.Data, in.Address, err))
+		return nil, ErrProcessing
+	}
+
+	return transformers.ProofToProtoProof(proof)
 }
 
 // Data generates temporary data blob for receiver to sign and proof the its identity.
@@ -502,9 +579,19 @@ func (s *server) Data(ctx context.Context, in *protobufcompiled.Address) (*proto
 	return &protobufcompiled.DataBlob{Blob: d}, nil
 }
 
-// Balance returns balanse for account owner.
+// NextNonce returns the nonce the next transaction of the issuer shall have.
+func (s *server) NextNonce(ctx context.Context, in *protobufcompiled.Address) (*protobufcompiled.Nonce, error) {
+	if in.Public == "" {
+		s.log.Error("empty request on next nonce endpoint")
+		return nil, ErrRequestIsEmpty
+	}
+
+	return &protobufcompiled.Nonce{Nonce: s.acc.NextNonce(in.Public)}, nil
+}
+
+// Balance returns balanse for account owner, locked spice is reported apart from the available spice.
 // TODO: Find better way of requesting balance - sign blob data!
-func (s *server) Balance(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.Spice, error) {
+func (s *server) Balance(ctx context.Context, in *protobufcompiled.SignedHash) (*protobufcompiled.Balance, error) {
 	ok, err := s.flash.HasAddress(in.Address)
 	if err != nil {
 		s.log.Error(fmt.Sprintf("balance endpoint failed to read from flash the address: %s, %s", in.Address, err))
@@ -529,11 +616,8 @@ func (s *server) Balance(ctx context.Context, in *protobufcompiled.SignedHash) (
 		return nil, ErrVerification
 	}
 
-	if s, err := s.cache.ReadBalance(in.Address); err == nil {
-		return &protobufcompiled.Spice{
-			Currency:              s.Currency,
-			SupplementaryCurrency: s.SupplementaryCurrency,
-		}, nil
+	if available, locked, err := s.cache.ReadBalance(in.Address); err == nil {
+		return transformers.BalanceToProtoBalance(available, locked), nil
 	}
 
 	balance, err := s.acc.CalculateBalance(ctx, in.Address)
@@ -543,15 +627,61 @@ func (s *server) Balance(ctx context.Context, in *protobufcompiled.SignedHash) (
 	}
 
 	go func() {
-		if err := s.cache.SaveBalance(in.Address, balance.Spice); err != nil {
+		if err := s.cache.SaveBalance(in.Address, balance.Spice, balance.Locked); err != nil {
 			s.log.Error(fmt.Sprintf("balance endpoint failed to cache balance: %s, %s", in.Address, err))
 		}
 	}()
 
-	return &protobufcompiled.Spice{
-		Currency:              balance.Spice.Currency,
-		SupplementaryCurrency: balance.Spice.SupplementaryCurrency,
-	}, nil
+	return transformers.BalanceToProtoBalance(balance.Spice, balance.Locked), nil
+}
+
+// BalanceAt returns balance for account owner as it was at the given vertex or at the given time.
+// When vertex hash is provided it takes precedence over the creation time.
+func (s *server) BalanceAt(ctx context.Context, in *protobufcompiled.HistoricalBalance) (*protobufcompiled.Balance, error) {
+	if in == nil || in.SignedHash == nil {
+		return nil, ErrRequestIsEmpty
+	}
+	sh := in.SignedHash
+
+	ok, err := s.flash.HasAddress(sh.Address)
+	if err != nil {
+		s.log.Error(fmt.Sprintf("historical balance endpoint failed to read from flash the address: %s, %s", sh.Address, err))
+		return nil, ErrProcessing
+	}
+	if ok {
+		return nil, ErrThrottle
+	}
+
+	t := time.Now()
+	defer func() {
+		d := time.Since(t)
+		s.tele.RecordHistogramTime(balanceAtTelemetryHistogram, d)
+	}()
+
+	if string(sh.Data) != sh.Address || len(sh.Hash) != 32 {
+		return nil, ErrVerification
+	}
+
+	if err := s.verifier.Verify(sh.Data, sh.Signature, [32]byte(sh.Hash), sh.Address); err != nil {
+		s.log.Error(fmt.Sprintf("historical balance endpoint failed to verify signature for address: %s, %s", sh.Address, err))
+		return nil, ErrVerification
+	}
+
+	var balance accountant.Balance
+	switch {
+	case len(in.VertexHash) == 32:
+		balance, err = s.acc.CalculateBalanceAtVertex(ctx, sh.Address, [32]byte(in.VertexHash))
+	case len(in.VertexHash) == 0 && in.CreatedAt != 0:
+		balance, err = s.acc.CalculateBalanceAtTime(ctx, sh.Address, time.Unix(0, int64(in.CreatedAt)))
+	default:
+		return nil, ErrRequestIsEmpty
+	}
+	if err != nil {
+		s.log.Error(fmt.Sprintf("historical balance endpoint failed to read balance for address: %s, %s", sh.Address, err))
+		return nil, ErrProcessing
+	}
+
+	return transformers.BalanceToProtoBalance(balance.Spice, balance.Locked), nil
 }
 
 // TransactionsInDAG returns all the transactions in a DAG that given address appears in as receiver or issuer.
@@ -599,3 +729,59 @@ func (s *server) TransactionsInDAG(ctx context.Context, in *protobufcompiled.Sig
 
 	return result, nil
 }
+
+// TransactionsByAddress returns the page of transactions that given address appears in as receiver or issuer,
+// including the transactions of vertices removed from the DAG by truncation.
+// Offset and limit count transactions, limit is capped at the maximum page size.
+func (s *server) TransactionsByAddress(ctx context.Context, in *protobufcompiled.TransactionsPage) (*protobufcompiled.Transactions, error) {
+	if in == nil || in.SignedHash == nil || in.Limit == 0 {
+		return nil, ErrRequestIsEmpty
+	}
+	sh := in.SignedHash
+
+	ok, err := s.flash.HasAddress(sh.Address)
+	if err != nil {
+		s.log.Error(fmt.Sprintf("transactions by address endpoint failed to read from flash the address: %s, %s", sh.Address, err))
+		return nil, ErrProcessing
+	}
+	if ok {
+		return nil, ErrThrottle
+	}
+
+	t := time.Now()
+	defer func() {
+		d := time.Since(t)
+		s.tele.RecordHistogramTime(readTransactionsByAddress, d)
+	}()
+
+	if ok := s.randDataProv.ValidateData(sh.Address, sh.Data); !ok || len(sh.Hash) != 32 {
+		s.log.Error(fmt.Sprintf("transactions by address endpoint failed to validate data for address: %s", sh.Address))
+		return nil, ErrVerification
+	}
+
+	if err := s.verifier.Verify(sh.Data, sh.Signature, [32]byte(sh.Hash), sh.Address); err != nil {
+		s.log.Error(fmt.Sprintf("transactions by address endpoint failed to verify signature for address: %s, %s", sh.Address, err))
+		return nil, ErrVerification
+	}
+
+	if in.Offset > math.MaxInt {
+		return &protobufcompiled.Transactions{}, nil
+	}
+	trxs, err := s.acc.ReadTransactionsByAddress(ctx, sh.Address, int(in.Offset), int(min(in.Limit, maxTransactionsPageLimit)))
+	if err != nil {
+		s.log.Error(fmt.Sprintf("transactions by address endpoint failed to read transactions for address: %s, %s", sh.Address, err))
+		return nil, ErrProcessing
+	}
+
+	result := &protobufcompiled.Transactions{Array: make([]*protobufcompiled.Transaction, 0, len(trxs)), Len: uint64(len(trxs))}
+	for _, trx := range trxs {
+		protoTrx, err := transformers.TrxToProtoTrx(trx)
+		if err != nil {
+			s.log.Warn(fmt.Sprintf("transactions by address endpoint failed to map trx to protobuf trx for address: %s, %s", sh.Address, err))
+			continue
+		}
+		result.Array = append(result.Array, protoTrx)
+	}
+
+	return result, nil
+}
@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Confidence int32
+
+const (
+	Confidence_PENDING   Confidence = 0
+	Confidence_CONFIRMED Confidence = 1
+	Confidence_FINAL     Confidence = 2
+)
+
+// Enum value maps for Confidence.
+var (
+	Confidence_name = map[int32]string{
+		0: "PENDING",
+		1: "CONFIRMED",
+		2: "FINAL",
+	}
+	Confidence_value = map[string]int32{
+		"PENDING":   0,
+		"CONFIRMED": 1,
+		"FINAL":     2,
+	}
+)
+
+func (x Confidence) Enum() *Confidence {
+	p := new(Confidence)
+	*p = x
+	return p
+}
+
+func (x Confidence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Confidence) Descriptor() protoreflect.EnumDescriptor {
+	return file_computantistypes_proto_enumTypes[0].Descriptor()
+}
+
+func (Confidence) Type() protoreflect.EnumType {
+	return &file_computantistypes_proto_enumTypes[0]
+}
+
+func (x Confidence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Confidence.Descriptor instead.
+func (Confidence) EnumDescriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{0}
+}
+
 type DataBlob struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -358,26 +407,268 @@ func (x *Spice) GetSupplementaryCurrency() uint64 {
 	return 0
 }
 
+type Balance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency              uint64 `protobuf:"varint,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	SupplementaryCurrency uint64 `protobuf:"varint,2,opt,name=supplementary_currency,json=supplementaryCurrency,proto3" json:"supplementary_currency,omitempty"`
+	Locked                *Spice `protobuf:"bytes,3,opt,name=locked,proto3" json:"locked,omitempty"`
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Balance) GetCurrency() uint64 {
+	if x != nil {
+		return x.Currency
+	}
+	return 0
+}
+
+func (x *Balance) GetSupplementaryCurrency() uint64 {
+	if x != nil {
+		return x.SupplementaryCurrency
+	}
+	return 0
+}
+
+func (x *Balance) GetLocked() *Spice {
+	if x != nil {
+		return x.Locked
+	}
+	return nil
+}
+
+type Nonce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonce uint64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *Nonce) Reset() {
+	*x = Nonce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nonce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nonce) ProtoMessage() {}
+
+func (x *Nonce) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nonce.ProtoReflect.Descriptor instead.
+func (*Nonce) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Nonce) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+type HistoricalBalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignedHash *SignedHash `protobuf:"bytes,1,opt,name=signed_hash,json=signedHash,proto3" json:"signed_hash,omitempty"`
+	VertexHash []byte      `protobuf:"bytes,2,opt,name=vertex_hash,json=vertexHash,proto3" json:"vertex_hash,omitempty"`
+	CreatedAt  uint64      `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *HistoricalBalance) Reset() {
+	*x = HistoricalBalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoricalBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricalBalance) ProtoMessage() {}
+
+func (x *HistoricalBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricalBalance.ProtoReflect.Descriptor instead.
+func (*HistoricalBalance) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoricalBalance) GetSignedHash() *SignedHash {
+	if x != nil {
+		return x.SignedHash
+	}
+	return nil
+}
+
+func (x *HistoricalBalance) GetVertexHash() []byte {
+	if x != nil {
+		return x.VertexHash
+	}
+	return nil
+}
+
+func (x *HistoricalBalance) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type TransactionsPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignedHash *SignedHash `protobuf:"bytes,1,opt,name=signed_hash,json=signedHash,proto3" json:"signed_hash,omitempty"`
+	Offset     uint64      `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit      uint64      `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *TransactionsPage) Reset() {
+	*x = TransactionsPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionsPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionsPage) ProtoMessage() {}
+
+func (x *TransactionsPage) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionsPage.ProtoReflect.Descriptor instead.
+func (*TransactionsPage) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TransactionsPage) GetSignedHash() *SignedHash {
+	if x != nil {
+		return x.SignedHash
+	}
+	return nil
+}
+
+func (x *TransactionsPage) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TransactionsPage) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type Transaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Subject           string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
-	Data              []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Hash              []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
-	CreatedAt         uint64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ReceiverAddress   string `protobuf:"bytes,5,opt,name=receiver_address,json=receiverAddress,proto3" json:"receiver_address,omitempty"`
-	IssuerAddress     string `protobuf:"bytes,6,opt,name=issuer_address,json=issuerAddress,proto3" json:"issuer_address,omitempty"`
-	ReceiverSignature []byte `protobuf:"bytes,7,opt,name=receiver_signature,json=receiverSignature,proto3" json:"receiver_signature,omitempty"`
-	IssuerSignature   []byte `protobuf:"bytes,8,opt,name=issuer_signature,json=issuerSignature,proto3" json:"issuer_signature,omitempty"`
-	Spice             *Spice `protobuf:"bytes,9,opt,name=spice,proto3" json:"spice,omitempty"`
+	Subject           string    `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Data              []byte    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Hash              []byte    `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	CreatedAt         uint64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReceiverAddress   string    `protobuf:"bytes,5,opt,name=receiver_address,json=receiverAddress,proto3" json:"receiver_address,omitempty"`
+	IssuerAddress     string    `protobuf:"bytes,6,opt,name=issuer_address,json=issuerAddress,proto3" json:"issuer_address,omitempty"`
+	ReceiverSignature []byte    `protobuf:"bytes,7,opt,name=receiver_signature,json=receiverSignature,proto3" json:"receiver_signature,omitempty"`
+	IssuerSignature   []byte    `protobuf:"bytes,8,opt,name=issuer_signature,json=issuerSignature,proto3" json:"issuer_signature,omitempty"`
+	Spice             *Spice    `protobuf:"bytes,9,opt,name=spice,proto3" json:"spice,omitempty"`
+	Multisig          *Multisig `protobuf:"bytes,10,opt,name=multisig,proto3" json:"multisig,omitempty"`
+	UnlockAt          uint64    `protobuf:"varint,11,opt,name=unlock_at,json=unlockAt,proto3" json:"unlock_at,omitempty"`
+	ArbiterAddress    string    `protobuf:"bytes,12,opt,name=arbiter_address,json=arbiterAddress,proto3" json:"arbiter_address,omitempty"`
+	Releases          []byte    `protobuf:"bytes,13,opt,name=releases,proto3" json:"releases,omitempty"`
+	Nonce             uint64    `protobuf:"varint,14,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Version           uint32    `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *Transaction) Reset() {
 	*x = Transaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_computantistypes_proto_msgTypes[6]
+		mi := &file_computantistypes_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -390,7 +681,7 @@ func (x *Transaction) String() string {
 func (*Transaction) ProtoMessage() {}
 
 func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_computantistypes_proto_msgTypes[6]
+	mi := &file_computantistypes_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -403,7 +694,7 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_computantistypes_proto_rawDescGZIP(), []int{6}
+	return file_computantistypes_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Transaction) GetSubject() string {
@@ -469,32 +760,74 @@ func (x *Transaction) GetSpice() *Spice {
 	return nil
 }
 
-type Transactions struct {
+func (x *Transaction) GetMultisig() *Multisig {
+	if x != nil {
+		return x.Multisig
+	}
+	return nil
+}
+
+func (x *Transaction) GetUnlockAt() uint64 {
+	if x != nil {
+		return x.UnlockAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetArbiterAddress() string {
+	if x != nil {
+		return x.ArbiterAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetReleases() []byte {
+	if x != nil {
+		return x.Releases
+	}
+	return nil
+}
+
+func (x *Transaction) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Transaction) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MemberSignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Array []*Transaction `protobuf:"bytes,1,rep,name=array,proto3" json:"array,omitempty"`
-	Len   uint64         `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`
+	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *Transactions) Reset() {
-	*x = Transactions{}
+func (x *MemberSignature) Reset() {
+	*x = MemberSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_computantistypes_proto_msgTypes[7]
+		mi := &file_computantistypes_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Transactions) String() string {
+func (x *MemberSignature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transactions) ProtoMessage() {}
+func (*MemberSignature) ProtoMessage() {}
 
-func (x *Transactions) ProtoReflect() protoreflect.Message {
-	mi := &file_computantistypes_proto_msgTypes[7]
+func (x *MemberSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,26 +838,381 @@ func (x *Transactions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transactions.ProtoReflect.Descriptor instead.
-func (*Transactions) Descriptor() ([]byte, []int) {
-	return file_computantistypes_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use MemberSignature.ProtoReflect.Descriptor instead.
+func (*MemberSignature) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Transactions) GetArray() []*Transaction {
+func (x *MemberSignature) GetAddress() string {
 	if x != nil {
-		return x.Array
+		return x.Address
 	}
-	return nil
+	return ""
 }
 
-func (x *Transactions) GetLen() uint64 {
+func (x *MemberSignature) GetSignature() []byte {
 	if x != nil {
-		return x.Len
+		return x.Signature
 	}
-	return 0
+	return nil
 }
 
-var File_computantistypes_proto protoreflect.FileDescriptor
+type Multisig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members    []string           `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	Signatures []*MemberSignature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Threshold  uint32             `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+}
+
+func (x *Multisig) Reset() {
+	*x = Multisig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Multisig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Multisig) ProtoMessage() {}
+
+func (x *Multisig) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Multisig.ProtoReflect.Descriptor instead.
+func (*Multisig) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Multisig) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Multisig) GetSignatures() []*MemberSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *Multisig) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type SavedTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Confidence  Confidence   `protobuf:"varint,2,opt,name=confidence,proto3,enum=computantis.Confidence" json:"confidence,omitempty"`
+	Approvers   uint64       `protobuf:"varint,3,opt,name=approvers,proto3" json:"approvers,omitempty"`
+}
+
+func (x *SavedTransaction) Reset() {
+	*x = SavedTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedTransaction) ProtoMessage() {}
+
+func (x *SavedTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedTransaction.ProtoReflect.Descriptor instead.
+func (*SavedTransaction) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SavedTransaction) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *SavedTransaction) GetConfidence() Confidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return Confidence_PENDING
+}
+
+func (x *SavedTransaction) GetApprovers() uint64 {
+	if x != nil {
+		return x.Approvers
+	}
+	return 0
+}
+
+type Transactions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Array []*Transaction `protobuf:"bytes,1,rep,name=array,proto3" json:"array,omitempty"`
+	Len   uint64         `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`
+}
+
+func (x *Transactions) Reset() {
+	*x = Transactions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transactions) ProtoMessage() {}
+
+func (x *Transactions) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transactions.ProtoReflect.Descriptor instead.
+func (*Transactions) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Transactions) GetArray() []*Transaction {
+	if x != nil {
+		return x.Array
+	}
+	return nil
+}
+
+func (x *Transactions) GetLen() uint64 {
+	if x != nil {
+		return x.Len
+	}
+	return 0
+}
+
+type InclusionStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignerPublicAddress string `protobuf:"bytes,1,opt,name=signer_public_address,json=signerPublicAddress,proto3" json:"signer_public_address,omitempty"`
+	CreatedAt           uint64 `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Signature           []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	TransactionHash     []byte `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Hash                []byte `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	LeftParentHash      []byte `protobuf:"bytes,6,opt,name=left_parent_hash,json=leftParentHash,proto3" json:"left_parent_hash,omitempty"`
+	RightParentHash     []byte `protobuf:"bytes,7,opt,name=right_parent_hash,json=rightParentHash,proto3" json:"right_parent_hash,omitempty"`
+	Weight              uint64 `protobuf:"varint,8,opt,name=weight,proto3" json:"weight,omitempty"`
+	Fee                 *Spice `protobuf:"bytes,9,opt,name=fee,proto3" json:"fee,omitempty"`
+}
+
+func (x *InclusionStep) Reset() {
+	*x = InclusionStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InclusionStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InclusionStep) ProtoMessage() {}
+
+func (x *InclusionStep) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InclusionStep.ProtoReflect.Descriptor instead.
+func (*InclusionStep) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *InclusionStep) GetSignerPublicAddress() string {
+	if x != nil {
+		return x.SignerPublicAddress
+	}
+	return ""
+}
+
+func (x *InclusionStep) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *InclusionStep) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *InclusionStep) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *InclusionStep) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *InclusionStep) GetLeftParentHash() []byte {
+	if x != nil {
+		return x.LeftParentHash
+	}
+	return nil
+}
+
+func (x *InclusionStep) GetRightParentHash() []byte {
+	if x != nil {
+		return x.RightParentHash
+	}
+	return nil
+}
+
+func (x *InclusionStep) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *InclusionStep) GetFee() *Spice {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+type InclusionProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionHash []byte           `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Steps           []*InclusionStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Batch           [][]byte         `protobuf:"bytes,3,rep,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (x *InclusionProof) Reset() {
+	*x = InclusionProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_computantistypes_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InclusionProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InclusionProof) ProtoMessage() {}
+
+func (x *InclusionProof) ProtoReflect() protoreflect.Message {
+	mi := &file_computantistypes_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InclusionProof.ProtoReflect.Descriptor instead.
+func (*InclusionProof) Descriptor() ([]byte, []int) {
+	return file_computantistypes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InclusionProof) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *InclusionProof) GetSteps() []*InclusionStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *InclusionProof) GetBatch() [][]byte {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+var File_computantistypes_proto protoreflect.FileDescriptor
 
 var file_computantistypes_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x74, 0x79, 0x70,
@@ -557,37 +1245,133 @@ var file_computantistypes_proto_rawDesc = []byte{
 	0x79, 0x12, 0x35, 0x0a, 0x16, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61,
 	0x72, 0x79, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x04, 0x52, 0x15, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x72, 0x79,
-	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0xc4, 0x02, 0x0a, 0x0b, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63,
-	0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x73,
-	0x73, 0x75, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x73,
-	0x73, 0x75, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x70, 0x69, 0x63, 0x65, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
-	0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x52, 0x05, 0x73, 0x70, 0x69, 0x63, 0x65, 0x22,
-	0x50, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x2e, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6c, 0x65,
-	0x6e, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x62, 0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
-	0x6e, 0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x88, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x35, 0x0a, 0x16, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x72,
+	0x79, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x15, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x72, 0x79, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2a, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x52, 0x06, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x22, 0x1d, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x11, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61,
+	0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0x7a, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x50, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x89,
+	0x04, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x73,
+	0x73, 0x75, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x29, 0x0a, 0x10, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73,
+	0x70, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x52, 0x05,
+	0x73, 0x70, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69,
+	0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x52, 0x08,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x75, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f,
+	0x6e, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x0f, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x08, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x73,
+	0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x10, 0x53, 0x61, 0x76,
+	0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a,
+	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x73,
+	0x22, 0x50, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x2e, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6c,
+	0x65, 0x6e, 0x22, 0xd3, 0x02, 0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x65, 0x70, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x6c, 0x65, 0x66, 0x74, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2a,
+	0x0a, 0x11, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x24, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70,
+	0x69, 0x63, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x29, 0x0a, 0x10, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e,
+	0x74, 0x69, 0x73, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2a, 0x33,
+	0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0b, 0x0a, 0x07,
+	0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e,
+	0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x49, 0x4e, 0x41,
+	0x4c, 0x10, 0x02, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x62, 0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -602,25 +1386,45 @@ func file_computantistypes_proto_rawDescGZIP() []byte {
 	return file_computantistypes_proto_rawDescData
 }
 
-var file_computantistypes_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_computantistypes_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_computantistypes_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_computantistypes_proto_goTypes = []interface{}{
-	(*DataBlob)(nil),     // 0: computantis.DataBlob
-	(*Address)(nil),      // 1: computantis.Address
-	(*Addresses)(nil),    // 2: computantis.Addresses
-	(*AliveData)(nil),    // 3: computantis.AliveData
-	(*SignedHash)(nil),   // 4: computantis.SignedHash
-	(*Spice)(nil),        // 5: computantis.Spice
-	(*Transaction)(nil),  // 6: computantis.Transaction
-	(*Transactions)(nil), // 7: computantis.Transactions
+	(Confidence)(0),           // 0: computantis.Confidence
+	(*DataBlob)(nil),          // 1: computantis.DataBlob
+	(*Address)(nil),           // 2: computantis.Address
+	(*Addresses)(nil),         // 3: computantis.Addresses
+	(*AliveData)(nil),         // 4: computantis.AliveData
+	(*SignedHash)(nil),        // 5: computantis.SignedHash
+	(*Spice)(nil),             // 6: computantis.Spice
+	(*Balance)(nil),           // 7: computantis.Balance
+	(*Nonce)(nil),             // 8: computantis.Nonce
+	(*HistoricalBalance)(nil), // 9: computantis.HistoricalBalance
+	(*TransactionsPage)(nil),  // 10: computantis.TransactionsPage
+	(*Transaction)(nil),       // 11: computantis.Transaction
+	(*MemberSignature)(nil),   // 12: computantis.MemberSignature
+	(*Multisig)(nil),          // 13: computantis.Multisig
+	(*SavedTransaction)(nil),  // 14: computantis.SavedTransaction
+	(*Transactions)(nil),      // 15: computantis.Transactions
+	(*InclusionStep)(nil),     // 16: computantis.InclusionStep
+	(*InclusionProof)(nil),    // 17: computantis.InclusionProof
 }
 var file_computantistypes_proto_depIdxs = []int32{
-	5, // 0: computantis.Transaction.spice:type_name -> computantis.Spice
-	6, // 1: computantis.Transactions.array:type_name -> computantis.Transaction
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6,  // 0: computantis.Balance.locked:type_name -> computantis.Spice
+	5,  // 1: computantis.HistoricalBalance.signed_hash:type_name -> computantis.SignedHash
+	5,  // 2: computantis.TransactionsPage.signed_hash:type_name -> computantis.SignedHash
+	6,  // 3: computantis.Transaction.spice:type_name -> computantis.Spice
+	13, // 4: computantis.Transaction.multisig:type_name -> computantis.Multisig
+	12, // 5: computantis.Multisig.signatures:type_name -> computantis.MemberSignature
+	11, // 6: computantis.SavedTransaction.transaction:type_name -> computantis.Transaction
+	0,  // 7: computantis.SavedTransaction.confidence:type_name -> computantis.Confidence
+	11, // 8: computantis.Transactions.array:type_name -> computantis.Transaction
+	6,  // 9: computantis.InclusionStep.fee:type_name -> computantis.Spice
+	16, // 10: computantis.InclusionProof.steps:type_name -> computantis.InclusionStep
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_computantistypes_proto_init() }
@@ -702,7 +1506,7 @@ func file_computantistypes_proto_init() {
 			}
 		}
 		file_computantistypes_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Transaction); i {
+			switch v := v.(*Balance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -714,6 +1518,90 @@ func file_computantistypes_proto_init() {
 			}
 		}
 		file_computantistypes_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nonce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoricalBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionsPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemberSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Multisig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SavedTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Transactions); i {
 			case 0:
 				return &v.state
@@ -725,19 +1613,44 @@ func file_computantistypes_proto_init() {
 				return nil
 			}
 		}
+		file_computantistypes_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InclusionStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_computantistypes_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_computantistypes_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_computantistypes_proto_goTypes,
 		DependencyIndexes: file_computantistypes_proto_depIdxs,
+		EnumInfos:         file_computantistypes_proto_enumTypes,
 		MessageInfos:      file_computantistypes_proto_msgTypes,
 	}.Build()
 	File_computantistypes_proto = out.File
@@ -26,14 +26,16 @@ type Vertex struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SignerPublicAddress string       `protobuf:"bytes,1,opt,name=signer_public_address,json=signerPublicAddress,proto3" json:"signer_public_address,omitempty"`
-	CreatedAt           uint64       `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Signature           []byte       `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
-	Transaction         *Transaction `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	Hash                []byte       `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
-	LeftParentHash      []byte       `protobuf:"bytes,6,opt,name=left_parent_hash,json=leftParentHash,proto3" json:"left_parent_hash,omitempty"`
-	RightParentHash     []byte       `protobuf:"bytes,7,opt,name=right_parent_hash,json=rightParentHash,proto3" json:"right_parent_hash,omitempty"`
-	Weight              uint64       `protobuf:"varint,8,opt,name=weight,proto3" json:"weight,omitempty"`
+	SignerPublicAddress string         `protobuf:"bytes,1,opt,name=signer_public_address,json=signerPublicAddress,proto3" json:"signer_public_address,omitempty"`
+	CreatedAt           uint64         `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Signature           []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Transaction         *Transaction   `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Hash                []byte         `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	LeftParentHash      []byte         `protobuf:"bytes,6,opt,name=left_parent_hash,json=leftParentHash,proto3" json:"left_parent_hash,omitempty"`
+	RightParentHash     []byte         `protobuf:"bytes,7,opt,name=right_parent_hash,json=rightParentHash,proto3" json:"right_parent_hash,omitempty"`
+	Weight              uint64         `protobuf:"varint,8,opt,name=weight,proto3" json:"weight,omitempty"`
+	Fee                 *Spice         `protobuf:"bytes,9,opt,name=fee,proto3" json:"fee,omitempty"`
+	Batch               []*Transaction `protobuf:"bytes,10,rep,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (x *Vertex) Reset() {
@@ -124,6 +126,20 @@ func (x *Vertex) GetWeight() uint64 {
 	return 0
 }
 
+func (x *Vertex) GetFee() *Spice {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Vertex) GetBatch() []*Transaction {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 type Gossiper struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -194,6 +210,7 @@ type VrxMsgGossip struct {
 
 	Vertex    *Vertex     `protobuf:"bytes,1,opt,name=vertex,proto3" json:"vertex,omitempty"`
 	Gossipers []*Gossiper `protobuf:"bytes,2,rep,name=gossipers,proto3" json:"gossipers,omitempty"`
+	Ttl       uint32      `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
 func (x *VrxMsgGossip) Reset() {
@@ -242,6 +259,13 @@ func (x *VrxMsgGossip) GetGossipers() []*Gossiper {
 	return nil
 }
 
+func (x *VrxMsgGossip) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 type TrxMsgGossip struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -297,6 +321,116 @@ func (x *TrxMsgGossip) GetGossipers() []*Gossiper {
 	return nil
 }
 
+type VertexHashes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *VertexHashes) Reset() {
+	*x = VertexHashes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gossip_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VertexHashes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VertexHashes) ProtoMessage() {}
+
+func (x *VertexHashes) ProtoReflect() protoreflect.Message {
+	mi := &file_gossip_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VertexHashes.ProtoReflect.Descriptor instead.
+func (*VertexHashes) Descriptor() ([]byte, []int) {
+	return file_gossip_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VertexHashes) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type DagSyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weight     uint64      `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Known      [][]byte    `protobuf:"bytes,2,rep,name=known,proto3" json:"known,omitempty"`
+	SignedHash *SignedHash `protobuf:"bytes,3,opt,name=signed_hash,json=signedHash,proto3" json:"signed_hash,omitempty"`
+}
+
+func (x *DagSyncRequest) Reset() {
+	*x = DagSyncRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gossip_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DagSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DagSyncRequest) ProtoMessage() {}
+
+func (x *DagSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossip_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DagSyncRequest.ProtoReflect.Descriptor instead.
+func (*DagSyncRequest) Descriptor() ([]byte, []int) {
+	return file_gossip_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DagSyncRequest) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *DagSyncRequest) GetKnown() [][]byte {
+	if x != nil {
+		return x.Known
+	}
+	return nil
+}
+
+func (x *DagSyncRequest) GetSignedHash() *SignedHash {
+	if x != nil {
+		return x.SignedHash
+	}
+	return nil
+}
+
 type ConnectionData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -312,7 +446,7 @@ type ConnectionData struct {
 func (x *ConnectionData) Reset() {
 	*x = ConnectionData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gossip_proto_msgTypes[4]
+		mi := &file_gossip_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +459,7 @@ func (x *ConnectionData) String() string {
 func (*ConnectionData) ProtoMessage() {}
 
 func (x *ConnectionData) ProtoReflect() protoreflect.Message {
-	mi := &file_gossip_proto_msgTypes[4]
+	mi := &file_gossip_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +472,7 @@ func (x *ConnectionData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectionData.ProtoReflect.Descriptor instead.
 func (*ConnectionData) Descriptor() ([]byte, []int) {
-	return file_gossip_proto_rawDescGZIP(), []int{4}
+	return file_gossip_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ConnectionData) GetPublicAddress() string {
@@ -388,7 +522,7 @@ type ConnectedNodes struct {
 func (x *ConnectedNodes) Reset() {
 	*x = ConnectedNodes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gossip_proto_msgTypes[5]
+		mi := &file_gossip_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -401,7 +535,7 @@ func (x *ConnectedNodes) String() string {
 func (*ConnectedNodes) ProtoMessage() {}
 
 func (x *ConnectedNodes) ProtoReflect() protoreflect.Message {
-	mi := &file_gossip_proto_msgTypes[5]
+	mi := &file_gossip_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +548,7 @@ func (x *ConnectedNodes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectedNodes.ProtoReflect.Descriptor instead.
 func (*ConnectedNodes) Descriptor() ([]byte, []int) {
-	return file_gossip_proto_rawDescGZIP(), []int{5}
+	return file_gossip_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ConnectedNodes) GetSignerPublicAddress() string {
@@ -439,7 +573,7 @@ var file_gossip_proto_rawDesc = []byte{
 	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xb7, 0x02, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x32, 0x0a, 0x15, 0x73,
+	0x22, 0x8d, 0x03, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x32, 0x0a, 0x15, 0x73,
 	0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61, 0x64, 0x64,
 	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e,
 	0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
@@ -458,79 +592,108 @@ var file_gossip_proto_rawDesc = []byte{
 	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x0f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61,
 	0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x5a, 0x0a, 0x08, 0x47, 0x6f,
-	0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x70, 0x0a, 0x0c, 0x56, 0x72, 0x78, 0x4d, 0x73, 0x67,
-	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
-	0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x06, 0x76, 0x65, 0x72,
-	0x74, 0x65, 0x78, 0x12, 0x33, 0x0a, 0x09, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
-	0x6e, 0x74, 0x69, 0x73, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x52, 0x09, 0x67,
-	0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73, 0x22, 0x6f, 0x0a, 0x0c, 0x54, 0x72, 0x78, 0x4d,
-	0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x12, 0x2a, 0x0a, 0x03, 0x74, 0x72, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e,
-	0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x03, 0x74, 0x72, 0x78, 0x12, 0x33, 0x0a, 0x09, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
-	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x52, 0x09,
-	0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x0e,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x0a,
-	0x15, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x69,
-	0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
-	0x6e, 0x74, 0x69, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x32, 0xce, 0x03, 0x0a, 0x09, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x41, 0x50, 0x49, 0x12, 0x39,
-	0x0a, 0x05, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x6c,
-	0x69, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x07, 0x4c, 0x6f, 0x61,
-	0x64, 0x44, 0x61, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x63,
-	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65,
-	0x78, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x08, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
-	0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
-	0x69, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x00,
-	0x12, 0x40, 0x0a, 0x09, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x56, 0x72, 0x78, 0x12, 0x19, 0x2e,
-	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x72, 0x78, 0x4d,
-	0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x54, 0x72, 0x78, 0x12,
-	0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72,
-	0x78, 0x4d, 0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x74, 0x65,
-	0x78, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x22,
-	0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x62, 0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
-	0x6e, 0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x03, 0x66, 0x65,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65,
+	0x12, 0x2e, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x22, 0x5a, 0x0a, 0x08, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x82, 0x01, 0x0a,
+	0x0c, 0x56, 0x72, 0x78, 0x4d, 0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x12, 0x2b, 0x0a,
+	0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74,
+	0x65, 0x78, 0x52, 0x06, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x33, 0x0a, 0x09, 0x67, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x47, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x65, 0x72, 0x52, 0x09, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74,
+	0x6c, 0x22, 0x6f, 0x0a, 0x0c, 0x54, 0x72, 0x78, 0x4d, 0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x12, 0x2a, 0x0a, 0x03, 0x74, 0x72, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x72, 0x78, 0x12, 0x33, 0x0a,
+	0x09, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x47,
+	0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x72, 0x52, 0x09, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65,
+	0x72, 0x73, 0x22, 0x26, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x48, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x78, 0x0a, 0x0e, 0x44, 0x61,
+	0x67, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x05, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x48, 0x61, 0x73, 0x68, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3d, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0x9a, 0x05, 0x0a, 0x09,
+	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x41, 0x50, 0x49, 0x12, 0x39, 0x0a, 0x05, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x07, 0x4c, 0x6f, 0x61, 0x64, 0x44, 0x61, 0x67, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x44, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x44, 0x61, 0x67, 0x53, 0x69, 0x6e, 0x63, 0x65,
+	0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x44,
+	0x61, 0x67, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74,
+	0x65, 0x78, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x08, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x08, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e,
+	0x74, 0x69, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22,
+	0x00, 0x12, 0x40, 0x0a, 0x09, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x56, 0x72, 0x78, 0x12, 0x19,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x72, 0x78,
+	0x4d, 0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x54, 0x72, 0x78,
+	0x12, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54,
+	0x72, 0x78, 0x4d, 0x73, 0x67, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x74,
+	0x65, 0x78, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x13, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x12,
+	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x19, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f,
+	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -545,45 +708,57 @@ func file_gossip_proto_rawDescGZIP() []byte {
 	return file_gossip_proto_rawDescData
 }
 
-var file_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_gossip_proto_goTypes = []interface{}{
 	(*Vertex)(nil),         // 0: computantis.Vertex
 	(*Gossiper)(nil),       // 1: computantis.Gossiper
 	(*VrxMsgGossip)(nil),   // 2: computantis.VrxMsgGossip
 	(*TrxMsgGossip)(nil),   // 3: computantis.TrxMsgGossip
-	(*ConnectionData)(nil), // 4: computantis.ConnectionData
-	(*ConnectedNodes)(nil), // 5: computantis.ConnectedNodes
-	(*Transaction)(nil),    // 6: computantis.Transaction
-	(*emptypb.Empty)(nil),  // 7: google.protobuf.Empty
-	(*SignedHash)(nil),     // 8: computantis.SignedHash
-	(*AliveData)(nil),      // 9: computantis.AliveData
+	(*VertexHashes)(nil),   // 4: computantis.VertexHashes
+	(*DagSyncRequest)(nil), // 5: computantis.DagSyncRequest
+	(*ConnectionData)(nil), // 6: computantis.ConnectionData
+	(*ConnectedNodes)(nil), // 7: computantis.ConnectedNodes
+	(*Transaction)(nil),    // 8: computantis.Transaction
+	(*Spice)(nil),          // 9: computantis.Spice
+	(*SignedHash)(nil),     // 10: computantis.SignedHash
+	(*emptypb.Empty)(nil),  // 11: google.protobuf.Empty
+	(*AliveData)(nil),      // 12: computantis.AliveData
 }
 var file_gossip_proto_depIdxs = []int32{
-	6,  // 0: computantis.Vertex.transaction:type_name -> computantis.Transaction
-	0,  // 1: computantis.VrxMsgGossip.vertex:type_name -> computantis.Vertex
-	1,  // 2: computantis.VrxMsgGossip.gossipers:type_name -> computantis.Gossiper
-	6,  // 3: computantis.TrxMsgGossip.trx:type_name -> computantis.Transaction
-	1,  // 4: computantis.TrxMsgGossip.gossipers:type_name -> computantis.Gossiper
-	4,  // 5: computantis.ConnectedNodes.connections:type_name -> computantis.ConnectionData
-	7,  // 6: computantis.GossipAPI.Alive:input_type -> google.protobuf.Empty
-	7,  // 7: computantis.GossipAPI.LoadDag:input_type -> google.protobuf.Empty
-	4,  // 8: computantis.GossipAPI.Announce:input_type -> computantis.ConnectionData
-	4,  // 9: computantis.GossipAPI.Discover:input_type -> computantis.ConnectionData
-	2,  // 10: computantis.GossipAPI.GossipVrx:input_type -> computantis.VrxMsgGossip
-	3,  // 11: computantis.GossipAPI.GossipTrx:input_type -> computantis.TrxMsgGossip
-	8,  // 12: computantis.GossipAPI.GetVertex:input_type -> computantis.SignedHash
-	9,  // 13: computantis.GossipAPI.Alive:output_type -> computantis.AliveData
-	0,  // 14: computantis.GossipAPI.LoadDag:output_type -> computantis.Vertex
-	7,  // 15: computantis.GossipAPI.Announce:output_type -> google.protobuf.Empty
-	5,  // 16: computantis.GossipAPI.Discover:output_type -> computantis.ConnectedNodes
-	7,  // 17: computantis.GossipAPI.GossipVrx:output_type -> google.protobuf.Empty
-	7,  // 18: computantis.GossipAPI.GossipTrx:output_type -> google.protobuf.Empty
-	0,  // 19: computantis.GossipAPI.GetVertex:output_type -> computantis.Vertex
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	8,  // 0: computantis.Vertex.transaction:type_name -> computantis.Transaction
+	9,  // 1: computantis.Vertex.fee:type_name -> computantis.Spice
+	8,  // 2: computantis.Vertex.batch:type_name -> computantis.Transaction
+	0,  // 3: computantis.VrxMsgGossip.vertex:type_name -> computantis.Vertex
+	1,  // 4: computantis.VrxMsgGossip.gossipers:type_name -> computantis.Gossiper
+	8,  // 5: computantis.TrxMsgGossip.trx:type_name -> computantis.Transaction
+	1,  // 6: computantis.TrxMsgGossip.gossipers:type_name -> computantis.Gossiper
+	10, // 7: computantis.DagSyncRequest.signed_hash:type_name -> computantis.SignedHash
+	6,  // 8: computantis.ConnectedNodes.connections:type_name -> computantis.ConnectionData
+	11, // 9: computantis.GossipAPI.Alive:input_type -> google.protobuf.Empty
+	11, // 10: computantis.GossipAPI.LoadDag:input_type -> google.protobuf.Empty
+	5,  // 11: computantis.GossipAPI.LoadDagSince:input_type -> computantis.DagSyncRequest
+	6,  // 12: computantis.GossipAPI.Announce:input_type -> computantis.ConnectionData
+	6,  // 13: computantis.GossipAPI.Discover:input_type -> computantis.ConnectionData
+	2,  // 14: computantis.GossipAPI.GossipVrx:input_type -> computantis.VrxMsgGossip
+	3,  // 15: computantis.GossipAPI.GossipTrx:input_type -> computantis.TrxMsgGossip
+	10, // 16: computantis.GossipAPI.GetVertex:input_type -> computantis.SignedHash
+	10, // 17: computantis.GossipAPI.GetLeaves:input_type -> computantis.SignedHash
+	10, // 18: computantis.GossipAPI.Reconcile:input_type -> computantis.SignedHash
+	12, // 19: computantis.GossipAPI.Alive:output_type -> computantis.AliveData
+	0,  // 20: computantis.GossipAPI.LoadDag:output_type -> computantis.Vertex
+	0,  // 21: computantis.GossipAPI.LoadDagSince:output_type -> computantis.Vertex
+	11, // 22: computantis.GossipAPI.Announce:output_type -> google.protobuf.Empty
+	7,  // 23: computantis.GossipAPI.Discover:output_type -> computantis.ConnectedNodes
+	11, // 24: computantis.GossipAPI.GossipVrx:output_type -> google.protobuf.Empty
+	11, // 25: computantis.GossipAPI.GossipTrx:output_type -> google.protobuf.Empty
+	0,  // 26: computantis.GossipAPI.GetVertex:output_type -> computantis.Vertex
+	4,  // 27: computantis.GossipAPI.GetLeaves:output_type -> computantis.VertexHashes
+	4,  // 28: computantis.GossipAPI.Reconcile:output_type -> computantis.VertexHashes
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_gossip_proto_init() }
@@ -642,7 +817,7 @@ func file_gossip_proto_init() {
 			}
 		}
 		file_gossip_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectionData); i {
+			switch v := v.(*VertexHashes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -654,6 +829,30 @@ func file_gossip_proto_init() {
 			}
 		}
 		file_gossip_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DagSyncRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gossip_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gossip_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectedNodes); i {
 			case 0:
 				return &v.state
@@ -672,7 +871,7 @@ func file_gossip_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gossip_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,11 +25,14 @@ const _ = grpc.SupportPackageIsVersion7
 type GossipAPIClient interface {
 	Alive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AliveData, error)
 	LoadDag(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (GossipAPI_LoadDagClient, error)
+	LoadDagSince(ctx context.Context, in *DagSyncRequest, opts ...grpc.CallOption) (GossipAPI_LoadDagSinceClient, error)
 	Announce(ctx context.Context, in *ConnectionData, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Discover(ctx context.Context, in *ConnectionData, opts ...grpc.CallOption) (*ConnectedNodes, error)
 	GossipVrx(ctx context.Context, in *VrxMsgGossip, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GossipTrx(ctx context.Context, in *TrxMsgGossip, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetVertex(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Vertex, error)
+	GetLeaves(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*VertexHashes, error)
+	Reconcile(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*VertexHashes, error)
 }
 
 type gossipAPIClient struct {
@@ -81,6 +84,38 @@ func (x *gossipAPILoadDagClient) Recv() (*Vertex, error) {
 	return m, nil
 }
 
+func (c *gossipAPIClient) LoadDagSince(ctx context.Context, in *DagSyncRequest, opts ...grpc.CallOption) (GossipAPI_LoadDagSinceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GossipAPI_ServiceDesc.Streams[1], "/computantis.GossipAPI/LoadDagSince", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gossipAPILoadDagSinceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GossipAPI_LoadDagSinceClient interface {
+	Recv() (*Vertex, error)
+	grpc.ClientStream
+}
+
+type gossipAPILoadDagSinceClient struct {
+	grpc.ClientStream
+}
+
+func (x *gossipAPILoadDagSinceClient) Recv() (*Vertex, error) {
+	m := new(Vertex)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *gossipAPIClient) Announce(ctx context.Context, in *ConnectionData, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/computantis.GossipAPI/Announce", in, out, opts...)
@@ -126,17 +161,38 @@ func (c *gossipAPIClient) GetVertex(ctx context.Context, in *SignedHash, opts ..
 	return out, nil
 }
 
+func (c *gossipAPIClient) GetLeaves(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*VertexHashes, error) {
+	out := new(VertexHashes)
+	err := c.cc.Invoke(ctx, "/computantis.GossipAPI/GetLeaves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gossipAPIClient) Reconcile(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*VertexHashes, error) {
+	out := new(VertexHashes)
+	err := c.cc.Invoke(ctx, "/computantis.GossipAPI/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GossipAPIServer is the server API for GossipAPI service.
 // All implementations must embed UnimplementedGossipAPIServer
 // for forward compatibility
 type GossipAPIServer interface {
 	Alive(context.Context, *emptypb.Empty) (*AliveData, error)
 	LoadDag(*emptypb.Empty, GossipAPI_LoadDagServer) error
+	LoadDagSince(*DagSyncRequest, GossipAPI_LoadDagSinceServer) error
 	Announce(context.Context, *ConnectionData) (*emptypb.Empty, error)
 	Discover(context.Context, *ConnectionData) (*ConnectedNodes, error)
 	GossipVrx(context.Context, *VrxMsgGossip) (*emptypb.Empty, error)
 	GossipTrx(context.Context, *TrxMsgGossip) (*emptypb.Empty, error)
 	GetVertex(context.Context, *SignedHash) (*Vertex, error)
+	GetLeaves(context.Context, *SignedHash) (*VertexHashes, error)
+	Reconcile(context.Context, *SignedHash) (*VertexHashes, error)
 	mustEmbedUnimplementedGossipAPIServer()
 }
 
@@ -150,6 +206,9 @@ func (UnimplementedGossipAPIServer) Alive(context.Context, *emptypb.Empty) (*Ali
 func (UnimplementedGossipAPIServer) LoadDag(*emptypb.Empty, GossipAPI_LoadDagServer) error {
 	return status.Errorf(codes.Unimplemented, "method LoadDag not implemented")
 }
+func (UnimplementedGossipAPIServer) LoadDagSince(*DagSyncRequest, GossipAPI_LoadDagSinceServer) error {
+	return status.Errorf(codes.Unimplemented, "method LoadDagSince not implemented")
+}
 func (UnimplementedGossipAPIServer) Announce(context.Context, *ConnectionData) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Announce not implemented")
 }
@@ -165,6 +224,12 @@ func (UnimplementedGossipAPIServer) GossipTrx(context.Context, *TrxMsgGossip) (*
 func (UnimplementedGossipAPIServer) GetVertex(context.Context, *SignedHash) (*Vertex, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVertex not implemented")
 }
+func (UnimplementedGossipAPIServer) GetLeaves(context.Context, *SignedHash) (*VertexHashes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaves not implemented")
+}
+func (UnimplementedGossipAPIServer) Reconcile(context.Context, *SignedHash) (*VertexHashes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
 func (UnimplementedGossipAPIServer) mustEmbedUnimplementedGossipAPIServer() {}
 
 // UnsafeGossipAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -217,6 +282,27 @@ func (x *gossipAPILoadDagServer) Send(m *Vertex) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _GossipAPI_LoadDagSince_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DagSyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GossipAPIServer).LoadDagSince(m, &gossipAPILoadDagSinceServer{stream})
+}
+
+type GossipAPI_LoadDagSinceServer interface {
+	Send(*Vertex) error
+	grpc.ServerStream
+}
+
+type gossipAPILoadDagSinceServer struct {
+	grpc.ServerStream
+}
+
+func (x *gossipAPILoadDagSinceServer) Send(m *Vertex) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _GossipAPI_Announce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConnectionData)
 	if err := dec(in); err != nil {
@@ -307,6 +393,42 @@ func _GossipAPI_GetVertex_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GossipAPI_GetLeaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipAPIServer).GetLeaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.GossipAPI/GetLeaves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipAPIServer).GetLeaves(ctx, req.(*SignedHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GossipAPI_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipAPIServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.GossipAPI/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipAPIServer).Reconcile(ctx, req.(*SignedHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GossipAPI_ServiceDesc is the grpc.ServiceDesc for GossipAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -338,6 +460,14 @@ var GossipAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVertex",
 			Handler:    _GossipAPI_GetVertex_Handler,
 		},
+		{
+			MethodName: "GetLeaves",
+			Handler:    _GossipAPI_GetLeaves_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _GossipAPI_Reconcile_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -345,6 +475,11 @@ var GossipAPI_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GossipAPI_LoadDag_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "LoadDagSince",
+			Handler:       _GossipAPI_LoadDagSince_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "gossip.proto",
 }
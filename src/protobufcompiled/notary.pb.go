@@ -28,7 +28,7 @@ var file_notary_proto_rawDesc = []byte{
 	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x32, 0xbc, 0x04, 0x0a, 0x09, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x79, 0x41, 0x50, 0x49, 0x12, 0x39,
+	0x32, 0xa3, 0x07, 0x0a, 0x09, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x79, 0x41, 0x50, 0x49, 0x12, 0x39,
 	0x0a, 0x05, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
 	0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x6c,
@@ -52,58 +52,96 @@ var file_notary_proto_rawDesc = []byte{
 	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67,
 	0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
 	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x2e, 0x63, 0x6f,
-	0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x11, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x44, 0x41, 0x47, 0x12,
-	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69,
-	0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75,
-	0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x6e, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48,
+	0x61, 0x73, 0x68, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69,
+	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x2e, 0x63,
+	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x11, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x44, 0x41, 0x47,
 	0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
-	0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x22, 0x00, 0x42,
-	0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61,
-	0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
-	0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x63,
-	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x67, 0x65, 0x1a, 0x19,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x07, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
+	0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a,
+	0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x09, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x41, 0x74, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
+	0x69, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x1a, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
+	0x69, 0x73, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x09,
+	0x4e, 0x65, 0x78, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a,
+	0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x4e, 0x6f,
+	0x6e, 0x63, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x17,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_notary_proto_goTypes = []interface{}{
-	(*emptypb.Empty)(nil), // 0: google.protobuf.Empty
-	(*Transaction)(nil),   // 1: computantis.Transaction
-	(*SignedHash)(nil),    // 2: computantis.SignedHash
-	(*Address)(nil),       // 3: computantis.Address
-	(*AliveData)(nil),     // 4: computantis.AliveData
-	(*Transactions)(nil),  // 5: computantis.Transactions
-	(*DataBlob)(nil),      // 6: computantis.DataBlob
-	(*Spice)(nil),         // 7: computantis.Spice
+	(*emptypb.Empty)(nil),     // 0: google.protobuf.Empty
+	(*Transaction)(nil),       // 1: computantis.Transaction
+	(*SignedHash)(nil),        // 2: computantis.SignedHash
+	(*Address)(nil),           // 3: computantis.Address
+	(*TransactionsPage)(nil),  // 4: computantis.TransactionsPage
+	(*HistoricalBalance)(nil), // 5: computantis.HistoricalBalance
+	(*AliveData)(nil),         // 6: computantis.AliveData
+	(*Transactions)(nil),      // 7: computantis.Transactions
+	(*SavedTransaction)(nil),  // 8: computantis.SavedTransaction
+	(*DataBlob)(nil),          // 9: computantis.DataBlob
+	(*Balance)(nil),           // 10: computantis.Balance
+	(*Nonce)(nil),             // 11: computantis.Nonce
+	(*InclusionProof)(nil),    // 12: computantis.InclusionProof
 }
 var file_notary_proto_depIdxs = []int32{
-	0, // 0: computantis.NotaryAPI.Alive:input_type -> google.protobuf.Empty
-	1, // 1: computantis.NotaryAPI.Propose:input_type -> computantis.Transaction
-	1, // 2: computantis.NotaryAPI.Confirm:input_type -> computantis.Transaction
-	2, // 3: computantis.NotaryAPI.Reject:input_type -> computantis.SignedHash
-	2, // 4: computantis.NotaryAPI.Waiting:input_type -> computantis.SignedHash
-	2, // 5: computantis.NotaryAPI.Saved:input_type -> computantis.SignedHash
-	3, // 6: computantis.NotaryAPI.Data:input_type -> computantis.Address
-	2, // 7: computantis.NotaryAPI.TransactionsInDAG:input_type -> computantis.SignedHash
-	2, // 8: computantis.NotaryAPI.Balance:input_type -> computantis.SignedHash
-	4, // 9: computantis.NotaryAPI.Alive:output_type -> computantis.AliveData
-	0, // 10: computantis.NotaryAPI.Propose:output_type -> google.protobuf.Empty
-	0, // 11: computantis.NotaryAPI.Confirm:output_type -> google.protobuf.Empty
-	0, // 12: computantis.NotaryAPI.Reject:output_type -> google.protobuf.Empty
-	5, // 13: computantis.NotaryAPI.Waiting:output_type -> computantis.Transactions
-	1, // 14: computantis.NotaryAPI.Saved:output_type -> computantis.Transaction
-	6, // 15: computantis.NotaryAPI.Data:output_type -> computantis.DataBlob
-	5, // 16: computantis.NotaryAPI.TransactionsInDAG:output_type -> computantis.Transactions
-	7, // 17: computantis.NotaryAPI.Balance:output_type -> computantis.Spice
-	9, // [9:18] is the sub-list for method output_type
-	0, // [0:9] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: computantis.NotaryAPI.Alive:input_type -> google.protobuf.Empty
+	1,  // 1: computantis.NotaryAPI.Propose:input_type -> computantis.Transaction
+	1,  // 2: computantis.NotaryAPI.Confirm:input_type -> computantis.Transaction
+	2,  // 3: computantis.NotaryAPI.Reject:input_type -> computantis.SignedHash
+	2,  // 4: computantis.NotaryAPI.Waiting:input_type -> computantis.SignedHash
+	2,  // 5: computantis.NotaryAPI.Saved:input_type -> computantis.SignedHash
+	2,  // 6: computantis.NotaryAPI.SavedWithConfidence:input_type -> computantis.SignedHash
+	3,  // 7: computantis.NotaryAPI.Data:input_type -> computantis.Address
+	2,  // 8: computantis.NotaryAPI.TransactionsInDAG:input_type -> computantis.SignedHash
+	4,  // 9: computantis.NotaryAPI.TransactionsByAddress:input_type -> computantis.TransactionsPage
+	2,  // 10: computantis.NotaryAPI.Balance:input_type -> computantis.SignedHash
+	5,  // 11: computantis.NotaryAPI.BalanceAt:input_type -> computantis.HistoricalBalance
+	3,  // 12: computantis.NotaryAPI.NextNonce:input_type -> computantis.Address
+	2,  // 13: computantis.NotaryAPI.Proof:input_type -> computantis.SignedHash
+	6,  // 14: computantis.NotaryAPI.Alive:output_type -> computantis.AliveData
+	0,  // 15: computantis.NotaryAPI.Propose:output_type -> google.protobuf.Empty
+	0,  // 16: computantis.NotaryAPI.Confirm:output_type -> google.protobuf.Empty
+	0,  // 17: computantis.NotaryAPI.Reject:output_type -> google.protobuf.Empty
+	7,  // 18: computantis.NotaryAPI.Waiting:output_type -> computantis.Transactions
+	1,  // 19: computantis.NotaryAPI.Saved:output_type -> computantis.Transaction
+	8,  // 20: computantis.NotaryAPI.SavedWithConfidence:output_type -> computantis.SavedTransaction
+	9,  // 21: computantis.NotaryAPI.Data:output_type -> computantis.DataBlob
+	7,  // 22: computantis.NotaryAPI.TransactionsInDAG:output_type -> computantis.Transactions
+	7,  // 23: computantis.NotaryAPI.TransactionsByAddress:output_type -> computantis.Transactions
+	10, // 24: computantis.NotaryAPI.Balance:output_type -> computantis.Balance
+	10, // 25: computantis.NotaryAPI.BalanceAt:output_type -> computantis.Balance
+	11, // 26: computantis.NotaryAPI.NextNonce:output_type -> computantis.Nonce
+	12, // 27: computantis.NotaryAPI.Proof:output_type -> computantis.InclusionProof
+	14, // [14:28] is the sub-list for method output_type
+	0,  // [0:14] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_notary_proto_init() }
@@ -29,9 +29,14 @@ type NotaryAPIClient interface {
 	Reject(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Waiting(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Transactions, error)
 	Saved(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Transaction, error)
+	SavedWithConfidence(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*SavedTransaction, error)
 	Data(ctx context.Context, in *Address, opts ...grpc.CallOption) (*DataBlob, error)
 	TransactionsInDAG(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Transactions, error)
-	Balance(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Spice, error)
+	TransactionsByAddress(ctx context.Context, in *TransactionsPage, opts ...grpc.CallOption) (*Transactions, error)
+	Balance(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Balance, error)
+	BalanceAt(ctx context.Context, in *HistoricalBalance, opts ...grpc.CallOption) (*Balance, error)
+	NextNonce(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Nonce, error)
+	Proof(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*InclusionProof, error)
 }
 
 type notaryAPIClient struct {
@@ -96,6 +101,15 @@ func (c *notaryAPIClient) Saved(ctx context.Context, in *SignedHash, opts ...grp
 	return out, nil
 }
 
+func (c *notaryAPIClient) SavedWithConfidence(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*SavedTransaction, error) {
+	out := new(SavedTransaction)
+	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/SavedWithConfidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *notaryAPIClient) Data(ctx context.Context, in *Address, opts ...grpc.CallOption) (*DataBlob, error) {
 	out := new(DataBlob)
 	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/Data", in, out, opts...)
@@ -114,8 +128,17 @@ func (c *notaryAPIClient) TransactionsInDAG(ctx context.Context, in *SignedHash,
 	return out, nil
 }
 
-func (c *notaryAPIClient) Balance(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Spice, error) {
-	out := new(Spice)
+func (c *notaryAPIClient) TransactionsByAddress(ctx context.Context, in *TransactionsPage, opts ...grpc.CallOption) (*Transactions, error) {
+	out := new(Transactions)
+	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/TransactionsByAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notaryAPIClient) Balance(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
 	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/Balance", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -123,6 +146,33 @@ func (c *notaryAPIClient) Balance(ctx context.Context, in *SignedHash, opts ...g
 	return out, nil
 }
 
+func (c *notaryAPIClient) BalanceAt(ctx context.Context, in *HistoricalBalance, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
+	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/BalanceAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notaryAPIClient) NextNonce(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Nonce, error) {
+	out := new(Nonce)
+	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/NextNonce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notaryAPIClient) Proof(ctx context.Context, in *SignedHash, opts ...grpc.CallOption) (*InclusionProof, error) {
+	out := new(InclusionProof)
+	err := c.cc.Invoke(ctx, "/computantis.NotaryAPI/Proof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotaryAPIServer is the server API for NotaryAPI service.
 // All implementations must embed UnimplementedNotaryAPIServer
 // for forward compatibility
@@ -133,9 +183,14 @@ type NotaryAPIServer interface {
 	Reject(context.Context, *SignedHash) (*emptypb.Empty, error)
 	Waiting(context.Context, *SignedHash) (*Transactions, error)
 	Saved(context.Context, *SignedHash) (*Transaction, error)
+	SavedWithConfidence(context.Context, *SignedHash) (*SavedTransaction, error)
 	Data(context.Context, *Address) (*DataBlob, error)
 	TransactionsInDAG(context.Context, *SignedHash) (*Transactions, error)
-	Balance(context.Context, *SignedHash) (*Spice, error)
+	TransactionsByAddress(context.Context, *TransactionsPage) (*Transactions, error)
+	Balance(context.Context, *SignedHash) (*Balance, error)
+	BalanceAt(context.Context, *HistoricalBalance) (*Balance, error)
+	NextNonce(context.Context, *Address) (*Nonce, error)
+	Proof(context.Context, *SignedHash) (*InclusionProof, error)
 	mustEmbedUnimplementedNotaryAPIServer()
 }
 
@@ -161,15 +216,30 @@ func (UnimplementedNotaryAPIServer) Waiting(context.Context, *SignedHash) (*Tran
 func (UnimplementedNotaryAPIServer) Saved(context.Context, *SignedHash) (*Transaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Saved not implemented")
 }
+func (UnimplementedNotaryAPIServer) SavedWithConfidence(context.Context, *SignedHash) (*SavedTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavedWithConfidence not implemented")
+}
 func (UnimplementedNotaryAPIServer) Data(context.Context, *Address) (*DataBlob, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Data not implemented")
 }
 func (UnimplementedNotaryAPIServer) TransactionsInDAG(context.Context, *SignedHash) (*Transactions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TransactionsInDAG not implemented")
 }
-func (UnimplementedNotaryAPIServer) Balance(context.Context, *SignedHash) (*Spice, error) {
+func (UnimplementedNotaryAPIServer) TransactionsByAddress(context.Context, *TransactionsPage) (*Transactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionsByAddress not implemented")
+}
+func (UnimplementedNotaryAPIServer) Balance(context.Context, *SignedHash) (*Balance, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Balance not implemented")
 }
+func (UnimplementedNotaryAPIServer) BalanceAt(context.Context, *HistoricalBalance) (*Balance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BalanceAt not implemented")
+}
+func (UnimplementedNotaryAPIServer) NextNonce(context.Context, *Address) (*Nonce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextNonce not implemented")
+}
+func (UnimplementedNotaryAPIServer) Proof(context.Context, *SignedHash) (*InclusionProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Proof not implemented")
+}
 func (UnimplementedNotaryAPIServer) mustEmbedUnimplementedNotaryAPIServer() {}
 
 // UnsafeNotaryAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -291,6 +361,24 @@ func _NotaryAPI_Saved_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotaryAPI_SavedWithConfidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryAPIServer).SavedWithConfidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.NotaryAPI/SavedWithConfidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryAPIServer).SavedWithConfidence(ctx, req.(*SignedHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NotaryAPI_Data_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Address)
 	if err := dec(in); err != nil {
@@ -327,6 +415,24 @@ func _NotaryAPI_TransactionsInDAG_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotaryAPI_TransactionsByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionsPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryAPIServer).TransactionsByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.NotaryAPI/TransactionsByAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryAPIServer).TransactionsByAddress(ctx, req.(*TransactionsPage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NotaryAPI_Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignedHash)
 	if err := dec(in); err != nil {
@@ -345,6 +451,60 @@ func _NotaryAPI_Balance_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotaryAPI_BalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoricalBalance)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryAPIServer).BalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.NotaryAPI/BalanceAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryAPIServer).BalanceAt(ctx, req.(*HistoricalBalance))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotaryAPI_NextNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryAPIServer).NextNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.NotaryAPI/NextNonce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryAPIServer).NextNonce(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotaryAPI_Proof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryAPIServer).Proof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.NotaryAPI/Proof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryAPIServer).Proof(ctx, req.(*SignedHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotaryAPI_ServiceDesc is the grpc.ServiceDesc for NotaryAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -376,6 +536,10 @@ var NotaryAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Saved",
 			Handler:    _NotaryAPI_Saved_Handler,
 		},
+		{
+			MethodName: "SavedWithConfidence",
+			Handler:    _NotaryAPI_SavedWithConfidence_Handler,
+		},
 		{
 			MethodName: "Data",
 			Handler:    _NotaryAPI_Data_Handler,
@@ -384,10 +548,26 @@ var NotaryAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TransactionsInDAG",
 			Handler:    _NotaryAPI_TransactionsInDAG_Handler,
 		},
+		{
+			MethodName: "TransactionsByAddress",
+			Handler:    _NotaryAPI_TransactionsByAddress_Handler,
+		},
 		{
 			MethodName: "Balance",
 			Handler:    _NotaryAPI_Balance_Handler,
 		},
+		{
+			MethodName: "BalanceAt",
+			Handler:    _NotaryAPI_BalanceAt_Handler,
+		},
+		{
+			MethodName: "NextNonce",
+			Handler:    _NotaryAPI_NextNonce_Handler,
+		},
+		{
+			MethodName: "Proof",
+			Handler:    _NotaryAPI_Proof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notary.proto",
@@ -326,7 +326,7 @@ var file_wallet_proto_rawDesc = []byte{
 	0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54,
 	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e,
 	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x32, 0xc4, 0x04, 0x0a, 0x0f, 0x57, 0x61,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x32, 0x94, 0x05, 0x0a, 0x0f, 0x57, 0x61,
 	0x6c, 0x6c, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x50, 0x49, 0x12, 0x39, 0x0a,
 	0x05, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16,
@@ -355,14 +355,19 @@ var file_wallet_proto_rawDesc = []byte{
 	0x61, 0x76, 0x65, 0x64, 0x12, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74,
 	0x69, 0x73, 0x2e, 0x54, 0x72, 0x78, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x6d,
 	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x48, 0x6f, 0x6f,
-	0x6b, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x63, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x53, 0x70, 0x69, 0x63, 0x65, 0x22, 0x00,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65, 0x64, 0x57,
+	0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x54, 0x72, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69,
+	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x12,
+	0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x48, 0x6f, 0x6f, 0x6b, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x00,
 	0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62,
 	0x61, 0x72, 0x74, 0x6f, 0x73, 0x73, 0x68, 0x2f, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x6e,
 	0x74, 0x69, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
@@ -393,6 +398,8 @@ var file_wallet_proto_goTypes = []interface{}{
 	(*emptypb.Empty)(nil),       // 7: google.protobuf.Empty
 	(*Address)(nil),             // 8: computantis.Address
 	(*Transactions)(nil),        // 9: computantis.Transactions
+	(*SavedTransaction)(nil),    // 10: computantis.SavedTransaction
+	(*Balance)(nil),             // 11: computantis.Balance
 }
 var file_wallet_proto_depIdxs = []int32{
 	5,  // 0: computantis.IssueTrx.spice:type_name -> computantis.Spice
@@ -404,19 +411,21 @@ var file_wallet_proto_depIdxs = []int32{
 	3,  // 6: computantis.WalletClientAPI.Reject:input_type -> computantis.TrxHash
 	2,  // 7: computantis.WalletClientAPI.Waiting:input_type -> computantis.NotaryNode
 	3,  // 8: computantis.WalletClientAPI.Saved:input_type -> computantis.TrxHash
-	1,  // 9: computantis.WalletClientAPI.WebHook:input_type -> computantis.CreateWebHook
-	7,  // 10: computantis.WalletClientAPI.Balance:input_type -> google.protobuf.Empty
-	7,  // 11: computantis.WalletClientAPI.Alive:output_type -> google.protobuf.Empty
-	8,  // 12: computantis.WalletClientAPI.WalletPublicAddress:output_type -> computantis.Address
-	7,  // 13: computantis.WalletClientAPI.Issue:output_type -> google.protobuf.Empty
-	7,  // 14: computantis.WalletClientAPI.Approve:output_type -> google.protobuf.Empty
-	7,  // 15: computantis.WalletClientAPI.Reject:output_type -> google.protobuf.Empty
-	9,  // 16: computantis.WalletClientAPI.Waiting:output_type -> computantis.Transactions
-	6,  // 17: computantis.WalletClientAPI.Saved:output_type -> computantis.Transaction
-	7,  // 18: computantis.WalletClientAPI.WebHook:output_type -> google.protobuf.Empty
-	5,  // 19: computantis.WalletClientAPI.Balance:output_type -> computantis.Spice
-	11, // [11:20] is the sub-list for method output_type
-	2,  // [2:11] is the sub-list for method input_type
+	3,  // 9: computantis.WalletClientAPI.SavedWithConfidence:input_type -> computantis.TrxHash
+	1,  // 10: computantis.WalletClientAPI.WebHook:input_type -> computantis.CreateWebHook
+	7,  // 11: computantis.WalletClientAPI.Balance:input_type -> google.protobuf.Empty
+	7,  // 12: computantis.WalletClientAPI.Alive:output_type -> google.protobuf.Empty
+	8,  // 13: computantis.WalletClientAPI.WalletPublicAddress:output_type -> computantis.Address
+	7,  // 14: computantis.WalletClientAPI.Issue:output_type -> google.protobuf.Empty
+	7,  // 15: computantis.WalletClientAPI.Approve:output_type -> google.protobuf.Empty
+	7,  // 16: computantis.WalletClientAPI.Reject:output_type -> google.protobuf.Empty
+	9,  // 17: computantis.WalletClientAPI.Waiting:output_type -> computantis.Transactions
+	6,  // 18: computantis.WalletClientAPI.Saved:output_type -> computantis.Transaction
+	10, // 19: computantis.WalletClientAPI.SavedWithConfidence:output_type -> computantis.SavedTransaction
+	7,  // 20: computantis.WalletClientAPI.WebHook:output_type -> google.protobuf.Empty
+	11, // 21: computantis.WalletClientAPI.Balance:output_type -> computantis.Balance
+	12, // [12:22] is the sub-list for method output_type
+	2,  // [2:12] is the sub-list for method input_type
 	2,  // [2:2] is the sub-list for extension type_name
 	2,  // [2:2] is the sub-list for extension extendee
 	0,  // [0:2] is the sub-list for field type_name
@@ -30,8 +30,9 @@ type WalletClientAPIClient interface {
 	Reject(ctx context.Context, in *TrxHash, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Waiting(ctx context.Context, in *NotaryNode, opts ...grpc.CallOption) (*Transactions, error)
 	Saved(ctx context.Context, in *TrxHash, opts ...grpc.CallOption) (*Transaction, error)
+	SavedWithConfidence(ctx context.Context, in *TrxHash, opts ...grpc.CallOption) (*SavedTransaction, error)
 	WebHook(ctx context.Context, in *CreateWebHook, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Balance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Spice, error)
+	Balance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Balance, error)
 }
 
 type walletClientAPIClient struct {
@@ -105,6 +106,15 @@ func (c *walletClientAPIClient) Saved(ctx context.Context, in *TrxHash, opts ...
 	return out, nil
 }
 
+func (c *walletClientAPIClient) SavedWithConfidence(ctx context.Context, in *TrxHash, opts ...grpc.CallOption) (*SavedTransaction, error) {
+	out := new(SavedTransaction)
+	err := c.cc.Invoke(ctx, "/computantis.WalletClientAPI/SavedWithConfidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletClientAPIClient) WebHook(ctx context.Context, in *CreateWebHook, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/computantis.WalletClientAPI/WebHook", in, out, opts...)
@@ -114,8 +124,8 @@ func (c *walletClientAPIClient) WebHook(ctx context.Context, in *CreateWebHook,
 	return out, nil
 }
 
-func (c *walletClientAPIClient) Balance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Spice, error) {
-	out := new(Spice)
+func (c *walletClientAPIClient) Balance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
 	err := c.cc.Invoke(ctx, "/computantis.WalletClientAPI/Balance", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -134,8 +144,9 @@ type WalletClientAPIServer interface {
 	Reject(context.Context, *TrxHash) (*emptypb.Empty, error)
 	Waiting(context.Context, *NotaryNode) (*Transactions, error)
 	Saved(context.Context, *TrxHash) (*Transaction, error)
+	SavedWithConfidence(context.Context, *TrxHash) (*SavedTransaction, error)
 	WebHook(context.Context, *CreateWebHook) (*emptypb.Empty, error)
-	Balance(context.Context, *emptypb.Empty) (*Spice, error)
+	Balance(context.Context, *emptypb.Empty) (*Balance, error)
 	mustEmbedUnimplementedWalletClientAPIServer()
 }
 
@@ -164,10 +175,13 @@ func (UnimplementedWalletClientAPIServer) Waiting(context.Context, *NotaryNode)
 func (UnimplementedWalletClientAPIServer) Saved(context.Context, *TrxHash) (*Transaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Saved not implemented")
 }
+func (UnimplementedWalletClientAPIServer) SavedWithConfidence(context.Context, *TrxHash) (*SavedTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavedWithConfidence not implemented")
+}
 func (UnimplementedWalletClientAPIServer) WebHook(context.Context, *CreateWebHook) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WebHook not implemented")
 }
-func (UnimplementedWalletClientAPIServer) Balance(context.Context, *emptypb.Empty) (*Spice, error) {
+func (UnimplementedWalletClientAPIServer) Balance(context.Context, *emptypb.Empty) (*Balance, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Balance not implemented")
 }
 func (UnimplementedWalletClientAPIServer) mustEmbedUnimplementedWalletClientAPIServer() {}
@@ -309,6 +323,24 @@ func _WalletClientAPI_Saved_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletClientAPI_SavedWithConfidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrxHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletClientAPIServer).SavedWithConfidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/computantis.WalletClientAPI/SavedWithConfidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletClientAPIServer).SavedWithConfidence(ctx, req.(*TrxHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletClientAPI_WebHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateWebHook)
 	if err := dec(in); err != nil {
@@ -380,6 +412,10 @@ var WalletClientAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Saved",
 			Handler:    _WalletClientAPI_Saved_Handler,
 		},
+		{
+			MethodName: "SavedWithConfidence",
+			Handler:    _WalletClientAPI_SavedWithConfidence_Handler,
+		},
 		{
 			MethodName: "WebHook",
 			Handler:    _WalletClientAPI_WebHook_Handler,
@@ -25,6 +25,12 @@ type GaugeProvider interface {
 	SetToCurrentTimeGauge(name string) bool
 }
 
+// HistogramGaugeProvider compounds histogram and gauge telemetry capabilities.
+type HistogramGaugeProvider interface {
+	HistogramProvider
+	GaugeProvider
+}
+
 // AwaitedTrxCacheProvider provides the cache functionality.
 type AwaitedTrxCacheProvider interface {
 	SaveAwaitedTransaction(trx *transaction.Transaction) error
@@ -34,8 +40,8 @@ type AwaitedTrxCacheProvider interface {
 
 // BalanceCacher is a balance cache provider.
 type BalanceCacher interface {
-	SaveBalance(a string, s spice.Melange) error
-	ReadBalance(a string) (spice.Melange, error)
+	SaveBalance(a string, available, locked spice.Melange) error
+	ReadBalance(a string) (available, locked spice.Melange, err error)
 	RemoveBalance(a string) error
 }
 
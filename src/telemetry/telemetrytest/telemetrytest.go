@@ -0,0 +1,43 @@
+// Package telemetrytest provides telemetry that records nothing, to be used in tests.
+package telemetrytest
+
+import "time"
+
+// Mock implements histogram and gauge telemetry and discards all the measurements.
+type Mock struct{}
+
+func (t *Mock) CreateUpdateObservableHistogram(name, description string) {}
+
+func (t *Mock) RecordHistogramTime(name string, d time.Duration) bool {
+	return true
+}
+
+func (t *Mock) RecordHistogramValue(name string, f float64) bool {
+	return true
+}
+
+func (t *Mock) CreateUpdateObservableGauge(name, description string) {}
+
+func (t *Mock) AddToGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *Mock) RemoveFromGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *Mock) IncrementGauge(name string) bool {
+	return true
+}
+
+func (t *Mock) DecrementGauge(name string) bool {
+	return true
+}
+
+func (t *Mock) SetGauge(name string, f float64) bool {
+	return true
+}
+
+func (t *Mock) SetToCurrentTimeGauge(name string) bool {
+	return true
+}
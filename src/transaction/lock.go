@@ -0,0 +1,143 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/bartossh/Computantis/src/spice"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const escrowReleaseSubject = "escrow release"
+
+var (
+	ErrLockIsEmpty       = errors.New("lock requires unlock time or arbiter address")
+	ErrNotEscrow         = errors.New("transaction is not an escrow")
+	ErrArbiterIsInvalid  = errors.New("signer is not the escrow arbiter")
+	ErrSpiceIsEmpty      = errors.New("locked transaction must transfer spice")
+	ErrReleaseHashIsZero = errors.New("escrow release must refer to the escrow transaction")
+)
+
+// Lock describes conditions that shall be met before the spice received in the transaction becomes spendable by the receiver.
+// Spice locked until the given time becomes available when the transaction spending it is created after that time.
+// Spice locked in escrow becomes available after the arbiter co-signs the escrow release transaction.
+// When both conditions are set both shall be met.
+type Lock struct {
+	UnlockAt       time.Time `json:"unlock_at"       bson:"unlock_at"       db:"unlock_at"       msgpack:"unlock_at"`
+	ArbiterAddress string    `json:"arbiter_address" bson:"arbiter_address" db:"arbiter_address" msgpack:"arbiter_address"`
+}
+
+// IsSet returns true if the lock has any condition.
+func (l Lock) IsSet() bool {
+	return !l.UnlockAt.IsZero() || l.ArbiterAddress != ""
+}
+
+// IsEscrow returns true if the lock requires the arbiter release.
+func (l Lock) IsEscrow() bool {
+	return l.ArbiterAddress != ""
+}
+
+// Unlocked returns true if the lock conditions are met at the given time with the given arbiter release.
+func (l Lock) Unlocked(at time.Time, releasedBy string) bool {
+	if !l.UnlockAt.IsZero() && at.Before(l.UnlockAt) {
+		return false
+	}
+	if l.IsEscrow() && releasedBy != l.ArbiterAddress {
+		return false
+	}
+	return true
+}
+
+// NewLocked creates new transaction signed by the issuer transferring spice that is locked for the receiver.
+func NewLocked(
+	subject string, spice spice.Melange, data []byte, receiverAddress string, lock Lock, issuer Signer,
+) (Transaction, error) {
+	if !lock.IsSet() {
+		return Transaction{}, ErrLockIsEmpty
+	}
+	if spice.Empty() {
+		return Transaction{}, ErrSpiceIsEmpty
+	}
+	if lock.IsEscrow() && len(lock.ArbiterAddress) < minAddressLength {
+		return Transaction{}, ErrAddressIsInvalid
+	}
+	if len(subject) == 0 {
+		return Transaction{}, ErrSubjectIsEmpty
+	}
+	if len(receiverAddress) < minAddressLength {
+		return Transaction{}, ErrAddressIsInvalid
+	}
+
+	trx := Transaction{
+		ID:                primitive.NilObjectID,
+		CreatedAt:         time.Now(),
+		IssuerAddress:     issuer.Address(),
+		ReceiverAddress:   receiverAddress,
+		Subject:           subject,
+		Data:              data,
+		ReceiverSignature: []byte{},
+		Spice:             spice,
+		Lock:              lock,
+		Version:           MessageVersion,
+	}
+	trx.Hash, trx.IssuerSignature = issuer.Sign(trx.GetMessage())
+
+	return trx, nil
+}
+
+// NewEscrowRelease creates the transaction in which the arbiter co-signs the release of the spice locked in the escrow.
+func NewEscrowRelease(escrow *Transaction, arbiter Signer) (Transaction, error) {
+	if escrow == nil {
+		return Transaction{}, ErrNilTransaction
+	}
+	if !escrow.Lock.IsEscrow() {
+		return Transaction{}, ErrNotEscrow
+	}
+	if escrow.Lock.ArbiterAddress != arbiter.Address() {
+		return Transaction{}, ErrArbiterIsInvalid
+	}
+
+	trx := Transaction{
+		ID:                primitive.NilObjectID,
+		CreatedAt:         time.Now(),
+		IssuerAddress:     arbiter.Address(),
+		ReceiverAddress:   escrow.ReceiverAddress,
+		Subject:           escrowReleaseSubject,
+		Data:              []byte{},
+		ReceiverSignature: []byte{},
+		Releases:          escrow.Hash,
+		Version:           MessageVersion,
+	}
+	trx.Hash, trx.IssuerSignature = arbiter.Sign(trx.GetMessage())
+
+	return trx, nil
+}
+
+// IsLocked returns true if the transaction transfers spice that is locked for the receiver.
+func (t Transaction) IsLocked() bool {
+	return t.Lock.IsSet() && t.IsSpiceTransfer()
+}
+
+// IsEscrowRelease returns true if the transaction releases spice locked in the escrow.
+func (t Transaction) IsEscrowRelease() bool {
+	return t.Releases != [32]byte{}
+}
+
+// lockMessage returns the lock and release part of the signed message.
+// It is empty for transactions without lock and release, so their message stays unchanged.
+func (t *Transaction) lockMessage() []byte {
+	if !t.Lock.IsSet() && !t.IsEscrowRelease() {
+		return nil
+	}
+	h := sha256.New()
+	b := make([]byte, 8)
+	if !t.Lock.UnlockAt.IsZero() {
+		binary.LittleEndian.PutUint64(b, uint64(t.Lock.UnlockAt.UnixNano()))
+	}
+	h.Write(b)
+	h.Write([]byte(t.Lock.ArbiterAddress))
+	h.Write(t.Releases[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,128 @@
+package transaction
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Versions of the message the transaction is signed with.
+const (
+	MessageVersionLegacy    uint8 = 0 // unversioned concatenation of the transaction fields, verified for existing ledgers only.
+	MessageVersionCanonical uint8 = 1 // length-prefixed canonical encoding of the transaction fields.
+)
+
+// MessageVersion is the version of the message new transactions are signed with.
+const MessageVersion = MessageVersionCanonical
+
+var (
+	ErrUnknownMessageVersion = errors.New("unknown transaction message version")
+	ErrLegacyMessageVersion  = errors.New("legacy transaction message version is accepted only for transactions already in the ledger")
+)
+
+// GetMessage returns message used for signature validation, encoded according to the transaction message version.
+// Returns nil for the unknown message version.
+// Legacy message is verified for the transactions loaded from the existing ledger,
+// new transactions signed with it are rejected by CheckNewMessageVersion.
+func (t *Transaction) GetMessage() []byte {
+	switch t.Version {
+	case MessageVersionLegacy:
+		return t.legacyMessage()
+	case MessageVersionCanonical:
+		return t.canonicalMessage()
+	default:
+		return nil
+	}
+}
+
+func (t *Transaction) checkMessageVersion() error {
+	if t.Version > MessageVersion {
+		return ErrUnknownMessageVersion
+	}
+	return nil
+}
+
+// CheckNewMessageVersion checks the new transaction is signed with the current message version.
+func (t *Transaction) CheckNewMessageVersion() error {
+	if err := t.checkMessageVersion(); err != nil {
+		return err
+	}
+	if t.Version < MessageVersion {
+		return ErrLegacyMessageVersion
+	}
+	return nil
+}
+
+// canonicalMessage encodes the transaction fields as follows, all integers are little-endian:
+//
+//	version           1 byte
+//	subject           uint32 length | bytes
+//	data              uint32 length | bytes
+//	issuer address    uint32 length | bytes
+//	receiver address  uint32 length | bytes
+//	created at        int64 unix nanoseconds
+//	currency          uint64
+//	supplementary     uint64
+//	unlock at         int64 unix nanoseconds, zero if not set
+//	arbiter address   uint32 length | bytes
+//	releases          32 bytes
+//	nonce             uint64
+//
+// Length prefixes make each encoding decodable to only one set of fields.
+// Any change of the fields or their order requires the new message version.
+func (t *Transaction) canonicalMessage() []byte {
+	size := 1 + 4*5 + 8*5 + len(t.Releases) +
+		len(t.Subject) + len(t.Data) + len(t.IssuerAddress) + len(t.ReceiverAddress) + len(t.Lock.ArbiterAddress)
+	message := make([]byte, 0, size)
+	message = append(message, MessageVersionCanonical)
+	message = appendField(message, []byte(t.Subject))
+	message = appendField(message, t.Data)
+	message = appendField(message, []byte(t.IssuerAddress))
+	message = appendField(message, []byte(t.ReceiverAddress))
+	message = binary.LittleEndian.AppendUint64(message, uint64(t.CreatedAt.UnixNano()))
+	message = binary.LittleEndian.AppendUint64(message, t.Spice.Currency)
+	message = binary.LittleEndian.AppendUint64(message, t.Spice.SupplementaryCurrency)
+	var unlockAt int64
+	if !t.Lock.UnlockAt.IsZero() {
+		unlockAt = t.Lock.UnlockAt.UnixNano()
+	}
+	message = binary.LittleEndian.AppendUint64(message, uint64(unlockAt))
+	message = appendField(message, []byte(t.Lock.ArbiterAddress))
+	message = append(message, t.Releases[:]...)
+	return binary.LittleEndian.AppendUint64(message, t.Nonce)
+}
+
+func appendField(message, field []byte) []byte {
+	message = binary.LittleEndian.AppendUint32(message, uint32(len(field)))
+	return append(message, field...)
+}
+
+// legacyMessage returns the message of transactions signed before the canonical message was introduced.
+func (t *Transaction) legacyMessage() []byte {
+	msgLen := len(t.Subject) + len(t.Data) + len(t.IssuerAddress) + len(t.ReceiverAddress) + 24
+	message := make([]byte, msgLen)
+	n := copy(message, []byte(t.Subject))
+	n += copy(message[n:], t.Data)
+	n += copy(message[n:], []byte(t.IssuerAddress))
+	n += copy(message[n:], []byte(t.ReceiverAddress))
+	b0 := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b0, uint64(t.CreatedAt.UnixNano()))
+	n += copy(message[n:], b0)
+	b1 := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b1, t.Spice.Currency)
+	n += copy(message[n:], b1)
+	b2 := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b2, t.Spice.SupplementaryCurrency)
+	copy(message[n:], b2)
+
+	message = append(message, t.lockMessage()...)
+	return append(message, t.nonceMessage()...)
+}
+
+// nonceMessage returns the nonce part of the signed legacy message.
+// It is empty for transactions without nonce, so their message stays unchanged.
+func (t *Transaction) nonceMessage() []byte {
+	if t.Nonce == 0 {
+		return nil
+	}
+	return binary.LittleEndian.AppendUint64(nil, t.Nonce)
+}
@@ -0,0 +1,195 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/bartossh/Computantis/src/serializer"
+	"github.com/bartossh/Computantis/src/spice"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	multisigAddressVersion = byte(0x01)
+	multisigChecksumLength = 4
+	// MaxMultisigMembers is the maximum number of keys in the multi-signature account.
+	MaxMultisigMembers = 16
+)
+
+var (
+	ErrMultisigInvalidThreshold    = errors.New("multisig threshold must be between 1 and the number of members")
+	ErrMultisigInvalidMembers      = errors.New("multisig members must be unique valid addresses")
+	ErrMultisigAddressMismatch     = errors.New("multisig address doesn't match members and threshold")
+	ErrMultisigNotMember           = errors.New("signer is not the multisig member")
+	ErrMultisigThresholdNotReached = errors.New("multisig valid signatures are below the threshold")
+)
+
+// MemberSignature is the signature of the transaction message made by the multi-signature account member.
+type MemberSignature struct {
+	Address   string `json:"address"   bson:"address"   db:"address"   msgpack:"address"`
+	Signature []byte `json:"signature" bson:"signature" db:"signature" msgpack:"signature"`
+}
+
+// Multisig describes the M-of-N multi-signature account issuing the transaction.
+// The issuer address is derived from the members addresses and the threshold,
+// and the transaction is valid when at least threshold of members signed it.
+type Multisig struct {
+	Members    []string          `json:"members"    bson:"members"    db:"members"    msgpack:"members"`
+	Signatures []MemberSignature `json:"signatures" bson:"signatures" db:"signatures" msgpack:"signatures"`
+	Threshold  uint32            `json:"threshold"  bson:"threshold"  db:"threshold"  msgpack:"threshold"`
+}
+
+// MultisigAddress derives the address of the M-of-N multi-signature account.
+// Members order doesn't matter, the same set of members and threshold always gives the same address.
+func MultisigAddress(threshold uint32, members []string) (string, error) {
+	if len(members) == 0 || len(members) > MaxMultisigMembers {
+		return "", ErrMultisigInvalidMembers
+	}
+	if threshold == 0 || int(threshold) > len(members) {
+		return "", ErrMultisigInvalidThreshold
+	}
+
+	sorted := slices.Clone(members)
+	slices.Sort(sorted)
+	for i, m := range sorted {
+		if len(m) < minAddressLength || (i > 0 && sorted[i-1] == m) {
+			return "", ErrMultisigInvalidMembers
+		}
+	}
+
+	h := sha256.New()
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, threshold)
+	h.Write(b)
+	for _, m := range sorted {
+		binary.LittleEndian.PutUint32(b, uint32(len(m)))
+		h.Write(b)
+		h.Write([]byte(m))
+	}
+
+	versioned := append([]byte{multisigAddressVersion}, h.Sum(nil)...)
+	first := sha256.Sum256(versioned)
+	second := sha256.Sum256(first[:])
+	full := append(versioned, second[:multisigChecksumLength]...)
+
+	return string(serializer.Base58Encode(full)), nil
+}
+
+// NewMultisig creates new transaction issued by the M-of-N multi-signature account.
+// Created transaction has no signatures, members sign it with SignMultisig.
+func NewMultisig(
+	subject string, spice spice.Melange, data []byte, receiverAddress string, threshold uint32, members []string,
+) (Transaction, error) {
+	if len(subject) == 0 {
+		return Transaction{}, ErrSubjectIsEmpty
+	}
+
+	if len(receiverAddress) < minAddressLength {
+		return Transaction{}, ErrAddressIsInvalid
+	}
+
+	issuerAddress, err := MultisigAddress(threshold, members)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	trx := Transaction{
+		ID:                primitive.NilObjectID,
+		CreatedAt:         time.Now(),
+		IssuerAddress:     issuerAddress,
+		ReceiverAddress:   receiverAddress,
+		Subject:           subject,
+		Data:              data,
+		IssuerSignature:   []byte{},
+		ReceiverSignature: []byte{},
+		Spice:             spice,
+		Multisig: Multisig{
+			Members:    slices.Clone(members),
+			Signatures: []MemberSignature{},
+			Threshold:  threshold,
+		},
+		Version: MessageVersion,
+	}
+	trx.Hash = sha256.Sum256(trx.GetMessage())
+
+	return trx, nil
+}
+
+// IsMultisig returns true if the transaction is issued by the multi-signature account.
+func (t Transaction) IsMultisig() bool {
+	return len(t.Multisig.Members) != 0
+}
+
+// SignMultisig signs the multi-signature transaction by the member.
+// Signing again by the same member replaces its signature.
+func (t *Transaction) SignMultisig(member Signer) error {
+	if err := t.checkMessageVersion(); err != nil {
+		return err
+	}
+	if !slices.Contains(t.Multisig.Members, member.Address()) {
+		return ErrMultisigNotMember
+	}
+
+	hash, signature := member.Sign(t.GetMessage())
+	if !bytes.Equal(hash[:], t.Hash[:]) {
+		return ErrTransactionHashIsInvalid
+	}
+
+	for i, s := range t.Multisig.Signatures {
+		if s.Address == member.Address() {
+			t.Multisig.Signatures[i].Signature = signature
+			return nil
+		}
+	}
+	t.Multisig.Signatures = append(t.Multisig.Signatures, MemberSignature{Address: member.Address(), Signature: signature})
+
+	return nil
+}
+
+// verifyMultisig verifies the issuer address is derived from the members and threshold
+// and at least threshold of distinct members signed the transaction.
+func (t *Transaction) verifyMultisig(v Verifier) error {
+	address, err := MultisigAddress(t.Multisig.Threshold, t.Multisig.Members)
+	if err != nil {
+		return err
+	}
+	if address != t.IssuerAddress {
+		return ErrMultisigAddressMismatch
+	}
+
+	message := t.GetMessage()
+	signed := make(map[string]struct{}, len(t.Multisig.Signatures))
+	for _, s := range t.Multisig.Signatures {
+		if _, ok := signed[s.Address]; ok {
+			continue
+		}
+		if !slices.Contains(t.Multisig.Members, s.Address) {
+			continue
+		}
+		if err := v.Verify(message, s.Signature, t.Hash, s.Address); err != nil {
+			continue
+		}
+		signed[s.Address] = struct{}{}
+	}
+	if len(signed) < int(t.Multisig.Threshold) {
+		return ErrMultisigThresholdNotReached
+	}
+
+	return nil
+}
+
+func (m Multisig) equal(other Multisig) bool {
+	if m.Threshold != other.Threshold || !slices.Equal(m.Members, other.Members) || len(m.Signatures) != len(other.Signatures) {
+		return false
+	}
+	for i := range m.Signatures {
+		if m.Signatures[i].Address != other.Signatures[i].Address || !bytes.Equal(m.Signatures[i].Signature, other.Signatures[i].Signature) {
+			return false
+		}
+	}
+	return true
+}
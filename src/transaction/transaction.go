@@ -2,7 +2,6 @@ package transaction
 
 import (
 	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"time"
@@ -15,7 +14,7 @@ import (
 
 const (
 	minAddressLength     = 49
-	ExpirationTimeInDays = 7 // transaction validity expiration time in days. TODO: move to config
+	ExpirationTimeInDays = 7 // default transaction validity expiration time in days, configured with Validity.
 )
 
 var (
@@ -26,6 +25,7 @@ var (
 	ErrSubjectIsEmpty                   = errors.New("subject cannot be empty")
 	ErrAddressIsInvalid                 = errors.New("address is invalid")
 	ErrNilTransaction                   = errors.New("nil transaction")
+	ErrNonceIsZero                      = errors.New("nonce shall be greater than zero")
 )
 
 // TrxAddressesSubscriberCallback is a method or function performing compotation on the transactions addresses.
@@ -55,6 +55,11 @@ type Transaction struct {
 	ReceiverSignature []byte        `json:"receiver_signature" bson:"receiver_signature" db:"receiver_signature"    msgpack:"receiver_signature"`
 	Hash              [32]byte      `json:"hash"               bson:"hash"               db:"hash"                  msgpack:"hash"`
 	Spice             spice.Melange `json:"spice"              bson:"spice"              db:"spice"                 msgpack:"spice"`
+	Multisig          Multisig      `json:"multisig"           bson:"multisig"           db:"-"                     msgpack:"multisig"`
+	Lock              Lock          `json:"lock"               bson:"lock"               db:"-"                     msgpack:"lock"`
+	Releases          [32]byte      `json:"releases"           bson:"releases"           db:"-"                     msgpack:"releases"`
+	Nonce             uint64        `json:"nonce"              bson:"nonce"              db:"-"                     msgpack:"nonce"`
+	Version           uint8         `json:"version"            bson:"version"            db:"-"                     msgpack:"version"`
 }
 
 // New creates new transaction signed by the issuer.
@@ -68,73 +73,74 @@ func New(subject string, spice spice.Melange, data []byte, receiverAddress strin
 	}
 	// TODO: create and use address verifier
 
-	createdAt := time.Now()
-
-	msgLen := len(subject) + len(data) + len(issuer.Address()) + len(receiverAddress) + 24
-	message := make([]byte, msgLen)
-	n := copy(message, []byte(subject))
-	n += copy(message[n:], data)
-	n += copy(message[n:], []byte(issuer.Address()))
-	n += copy(message[n:], []byte(receiverAddress))
-	b0 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b0, uint64(createdAt.UnixNano()))
-	n += copy(message[n:], b0)
-	b1 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b1, spice.Currency)
-	n += copy(message[n:], b1)
-	b2 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b2, spice.SupplementaryCurrency)
-	copy(message[n:], b2)
-
-	hash, signature := issuer.Sign(message)
-
-	return Transaction{
+	trx := Transaction{
 		ID:                primitive.NilObjectID,
-		CreatedAt:         createdAt,
-		Hash:              hash,
+		CreatedAt:         time.Now(),
 		IssuerAddress:     issuer.Address(),
 		ReceiverAddress:   receiverAddress,
 		Subject:           subject,
 		Data:              data,
-		IssuerSignature:   signature,
 		ReceiverSignature: []byte{},
 		Spice:             spice,
-	}, nil
+		Version:           MessageVersion,
+	}
+	trx.Hash, trx.IssuerSignature = issuer.Sign(trx.GetMessage())
+
+	return trx, nil
 }
 
-// Sign verifies issuer signature and signs Transaction by the receiver.
-func (t *Transaction) Sign(receiver Signer, v Verifier) ([32]byte, error) {
-	now := time.Now()
+// NewWithNonce creates new transaction signed by the issuer with the issuer nonce.
+// Nonce shall be greater than the nonce of any previous transaction of the issuer, so the transaction cannot be replayed.
+func NewWithNonce(
+	subject string, spice spice.Melange, data []byte, receiverAddress string, nonce uint64, issuer Signer,
+) (Transaction, error) {
+	if nonce == 0 {
+		return Transaction{}, ErrNonceIsZero
+	}
+	if len(subject) == 0 {
+		return Transaction{}, ErrSubjectIsEmpty
+	}
+	if len(receiverAddress) < minAddressLength {
+		return Transaction{}, ErrAddressIsInvalid
+	}
 
-	if t.CreatedAt.Unix() > now.Unix() {
-		return [32]byte{}, ErrTransactionHasAFutureTime
+	trx := Transaction{
+		ID:                primitive.NilObjectID,
+		CreatedAt:         time.Now(),
+		IssuerAddress:     issuer.Address(),
+		ReceiverAddress:   receiverAddress,
+		Subject:           subject,
+		Data:              data,
+		ReceiverSignature: []byte{},
+		Spice:             spice,
+		Nonce:             nonce,
+		Version:           MessageVersion,
 	}
+	trx.Hash, trx.IssuerSignature = issuer.Sign(trx.GetMessage())
 
-	if addTime(t.CreatedAt).Unix() < now.Unix() {
-		return [32]byte{}, ErrExpiredTransaction
+	return trx, nil
+}
+
+// Sign verifies issuer signature and signs Transaction by the receiver.
+// Transaction shall be valid according to the default Validity.
+func (t *Transaction) Sign(receiver Signer, v Verifier) ([32]byte, error) {
+	return t.SignWithValidity(receiver, v, Validity{})
+}
+
+// SignWithValidity verifies issuer signature and signs Transaction by the receiver.
+// Transaction shall be valid according to the given Validity.
+func (t *Transaction) SignWithValidity(receiver Signer, v Verifier, validity Validity) ([32]byte, error) {
+	if err := validity.Check(t, time.Now()); err != nil {
+		return [32]byte{}, err
 	}
 
 	if receiver.Address() != t.ReceiverAddress {
 		return [32]byte{}, ErrAddressIsInvalid
 	}
 
-	msgLen := len(t.Subject) + len(t.Data) + len(t.IssuerAddress) + len(receiver.Address()) + 24
-	message := make([]byte, msgLen)
-	n := copy(message, []byte(t.Subject))
-	n += copy(message[n:], t.Data)
-	n += copy(message[n:], []byte(t.IssuerAddress))
-	n += copy(message[n:], []byte(receiver.Address()))
-	b0 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b0, uint64(t.CreatedAt.UnixNano()))
-	n += copy(message[n:], b0)
-	b1 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b1, t.Spice.Currency)
-	n += copy(message[n:], b1)
-	b2 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b2, t.Spice.SupplementaryCurrency)
-	copy(message[n:], b2)
-
-	if err := v.Verify(message, t.IssuerSignature, [32]byte(t.Hash), t.IssuerAddress); err != nil {
+	message := t.GetMessage()
+
+	if err := t.VerifyIssuer(v); err != nil {
 		return [32]byte{}, errors.Join(ErrSignatureNotValidOrDataCorrupted, err)
 	}
 
@@ -158,46 +164,33 @@ func (t Transaction) IsSpiceTransfer() bool {
 	return !t.Spice.Empty()
 }
 
-// IsEmpty returns true if transaction doesn't transfers tokens or has no data.
+// IsEmpty returns true if transaction doesn't transfers tokens, has no data and doesn't release the escrow.
 func (t Transaction) IsEmpty() bool {
-	return !t.IsContract() && !t.IsSpiceTransfer()
+	return !t.IsContract() && !t.IsSpiceTransfer() && !t.IsEscrowRelease()
 }
 
+// VerifyIssuer verifies transaction issuer signature.
+// Transaction issued by the multi-signature account requires signatures of at least threshold members.
 func (t *Transaction) VerifyIssuer(v Verifier) error {
+	if err := t.checkMessageVersion(); err != nil {
+		return err
+	}
+	if t.IsMultisig() {
+		return t.verifyMultisig(v)
+	}
 	message := t.GetMessage()
 	return v.Verify(message, t.IssuerSignature, t.Hash, t.IssuerAddress)
 }
 
 // Verify verifies transaction signatures.
 func (t *Transaction) VerifyIssuerReceiver(v Verifier) error {
-	message := t.GetMessage()
-	if err := v.Verify(message, t.IssuerSignature, t.Hash, t.IssuerAddress); err != nil {
+	if err := t.VerifyIssuer(v); err != nil {
 		return err
 	}
+	message := t.GetMessage()
 	return v.Verify(message, t.ReceiverSignature, t.Hash, t.ReceiverAddress)
 }
 
-// GeMessage returns message used for signature validation.
-func (t *Transaction) GetMessage() []byte {
-	msgLen := len(t.Subject) + len(t.Data) + len(t.IssuerAddress) + len(t.ReceiverAddress) + 24
-	message := make([]byte, msgLen)
-	n := copy(message, []byte(t.Subject))
-	n += copy(message[n:], t.Data)
-	n += copy(message[n:], []byte(t.IssuerAddress))
-	n += copy(message[n:], []byte(t.ReceiverAddress))
-	b0 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b0, uint64(t.CreatedAt.UnixNano()))
-	n += copy(message[n:], b0)
-	b1 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b1, t.Spice.Currency)
-	n += copy(message[n:], b1)
-	b2 := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b2, t.Spice.SupplementaryCurrency)
-	copy(message[n:], b2)
-
-	return message
-}
-
 // CompareIssuerData compare transactions from Issuer perspective.
 func (t *Transaction) CompareIssuerData(tx *Transaction) (bool, error) {
 	if t == nil || tx == nil {
@@ -218,6 +211,15 @@ func (t *Transaction) CompareIssuerData(tx *Transaction) (bool, error) {
 	if !bytes.Equal(t.IssuerSignature, tx.IssuerSignature) {
 		return false, nil
 	}
+	if !t.Multisig.equal(tx.Multisig) {
+		return false, nil
+	}
+	if !t.Lock.UnlockAt.Equal(tx.Lock.UnlockAt) || t.Lock.ArbiterAddress != tx.Lock.ArbiterAddress || t.Releases != tx.Releases {
+		return false, nil
+	}
+	if t.Nonce != tx.Nonce || t.Version != tx.Version {
+		return false, nil
+	}
 	if !bytes.Equal(t.Data, tx.Data) {
 		return false, nil
 	}
@@ -250,7 +252,3 @@ func Decode(buf []byte) (Transaction, error) {
 	err := msgpackv2.Unmarshal(buf, &t)
 	return t, err
 }
-
-func addTime(t time.Time) time.Time {
-	return t.AddDate(0, 0, ExpirationTimeInDays)
-}
@@ -1,7 +1,9 @@
 package transaction
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"math"
 	"slices"
 	"testing"
@@ -177,6 +179,68 @@ func BenchmarkCopy(b *testing.B) {
 	}
 }
 
+func TestMultisigTransaction(t *testing.T) {
+	members := make([]wallet.Wallet, 0, 3)
+	addresses := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		w, err := wallet.New()
+		assert.Nil(t, err)
+		members = append(members, w)
+		addresses = append(addresses, w.Address())
+	}
+	outsider, err := wallet.New()
+	assert.Nil(t, err)
+	receiver, err := wallet.New()
+	assert.Nil(t, err)
+
+	address, err := MultisigAddress(2, addresses)
+	assert.Nil(t, err)
+	reversed := slices.Clone(addresses)
+	slices.Reverse(reversed)
+	sameAddress, err := MultisigAddress(2, reversed)
+	assert.Nil(t, err)
+	assert.Equal(t, address, sameAddress)
+	otherAddress, err := MultisigAddress(1, addresses)
+	assert.Nil(t, err)
+	assert.NotEqual(t, address, otherAddress)
+
+	_, err = MultisigAddress(4, addresses)
+	assert.ErrorIs(t, err, ErrMultisigInvalidThreshold)
+	_, err = MultisigAddress(1, []string{addresses[0], addresses[0]})
+	assert.ErrorIs(t, err, ErrMultisigInvalidMembers)
+
+	trx, err := NewMultisig("subject", spice.New(10, 0), []byte{}, receiver.Address(), 2, addresses)
+	assert.Nil(t, err)
+	assert.Equal(t, trx.IssuerAddress, address)
+	assert.True(t, trx.IsMultisig())
+
+	assert.ErrorIs(t, trx.SignMultisig(&outsider), ErrMultisigNotMember)
+	assert.Nil(t, trx.SignMultisig(&members[0]))
+	assert.ErrorIs(t, trx.VerifyIssuer(wallet.Helper{}), ErrMultisigThresholdNotReached)
+	assert.Nil(t, trx.SignMultisig(&members[0]))
+	assert.ErrorIs(t, trx.VerifyIssuer(wallet.Helper{}), ErrMultisigThresholdNotReached)
+	assert.Nil(t, trx.SignMultisig(&members[2]))
+	assert.Nil(t, trx.VerifyIssuer(wallet.Helper{}))
+
+	_, err = trx.Sign(&receiver, wallet.Helper{})
+	assert.Nil(t, err)
+	assert.Nil(t, trx.VerifyIssuerReceiver(wallet.Helper{}))
+
+	tampered := trx
+	tampered.Multisig.Threshold = 1
+	assert.ErrorIs(t, tampered.VerifyIssuer(wallet.Helper{}), ErrMultisigAddressMismatch)
+
+	forged := trx
+	forged.Multisig.Signatures = []MemberSignature{trx.Multisig.Signatures[0], {Address: addresses[1], Signature: trx.Multisig.Signatures[0].Signature}}
+	assert.ErrorIs(t, forged.VerifyIssuer(wallet.Helper{}), ErrMultisigThresholdNotReached)
+
+	buf, err := trx.Encode()
+	assert.Nil(t, err)
+	decoded, err := Decode(buf)
+	assert.Nil(t, err)
+	assert.Nil(t, decoded.VerifyIssuerReceiver(wallet.Helper{}))
+}
+
 func BenchmarkNewTransaction(b *testing.B) {
 	issuer, err := wallet.New()
 	assert.Nil(b, err)
@@ -205,3 +269,176 @@ func BenchmarkSignTransaction(b *testing.B) {
 		trx.Sign(&receiver, wh)
 	}
 }
+
+func TestLockedAndEscrowTransaction(t *testing.T) {
+	issuer, err := wallet.New()
+	assert.Nil(t, err)
+	receiver, err := wallet.New()
+	assert.Nil(t, err)
+	arbiter, err := wallet.New()
+	assert.Nil(t, err)
+
+	_, err = NewLocked("subject", spice.New(10, 0), []byte{}, receiver.Address(), Lock{}, &issuer)
+	assert.ErrorIs(t, err, ErrLockIsEmpty)
+	_, err = NewLocked("subject", spice.New(0, 0), []byte{}, receiver.Address(), Lock{ArbiterAddress: arbiter.Address()}, &issuer)
+	assert.ErrorIs(t, err, ErrSpiceIsEmpty)
+
+	unlockAt := time.Now().Add(time.Hour)
+	lock := Lock{UnlockAt: unlockAt, ArbiterAddress: arbiter.Address()}
+	trx, err := NewLocked("subject", spice.New(10, 0), []byte{}, receiver.Address(), lock, &issuer)
+	assert.Nil(t, err)
+	assert.True(t, trx.IsLocked())
+	assert.Nil(t, trx.VerifyIssuer(wallet.Helper{}))
+
+	assert.False(t, lock.Unlocked(unlockAt.Add(time.Second), ""))
+	assert.False(t, lock.Unlocked(time.Now(), arbiter.Address()))
+	assert.True(t, lock.Unlocked(unlockAt, arbiter.Address()))
+
+	tampered := trx
+	tampered.Lock.UnlockAt = time.Now()
+	assert.NotNil(t, tampered.VerifyIssuer(wallet.Helper{}))
+
+	_, err = trx.Sign(&receiver, wallet.Helper{})
+	assert.Nil(t, err)
+	assert.Nil(t, trx.VerifyIssuerReceiver(wallet.Helper{}))
+
+	buf, err := trx.Encode()
+	assert.Nil(t, err)
+	decoded, err := Decode(buf)
+	assert.Nil(t, err)
+	assert.True(t, decoded.Lock.UnlockAt.Equal(unlockAt))
+	assert.Nil(t, decoded.VerifyIssuerReceiver(wallet.Helper{}))
+
+	_, err = NewEscrowRelease(&trx, &receiver)
+	assert.ErrorIs(t, err, ErrArbiterIsInvalid)
+	release, err := NewEscrowRelease(&trx, &arbiter)
+	assert.Nil(t, err)
+	assert.True(t, release.IsEscrowRelease())
+	assert.False(t, release.IsEmpty())
+	assert.Equal(t, release.Releases, trx.Hash)
+	assert.Nil(t, release.VerifyIssuer(wallet.Helper{}))
+}
+
+func TestTransactionWithNonce(t *testing.T) {
+	issuer, err := wallet.New()
+	assert.Nil(t, err)
+	receiver, err := wallet.New()
+	assert.Nil(t, err)
+
+	_, err = NewWithNonce("subject", spice.New(10, 0), []byte{}, receiver.Address(), 0, &issuer)
+	assert.ErrorIs(t, err, ErrNonceIsZero)
+
+	trx, err := NewWithNonce("subject", spice.New(10, 0), []byte{}, receiver.Address(), 7, &issuer)
+	assert.Nil(t, err)
+	assert.Equal(t, trx.Nonce, uint64(7))
+	assert.Nil(t, trx.VerifyIssuer(wallet.Helper{}))
+
+	tampered := trx
+	tampered.Nonce = 8
+	assert.NotNil(t, tampered.VerifyIssuer(wallet.Helper{}))
+
+	_, err = trx.Sign(&receiver, wallet.Helper{})
+	assert.Nil(t, err)
+
+	buf, err := trx.Encode()
+	assert.Nil(t, err)
+	decoded, err := Decode(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, decoded.Nonce, uint64(7))
+	assert.Nil(t, decoded.VerifyIssuerReceiver(wallet.Helper{}))
+}
+
+func TestTransactionValidity(t *testing.T) {
+	issuer, err := wallet.New()
+	assert.Nil(t, err)
+	receiver, err := wallet.New()
+	assert.Nil(t, err)
+
+	trx, err := New("subject", spice.New(10, 0), []byte{}, receiver.Address(), &issuer)
+	assert.Nil(t, err)
+
+	validity := Validity{Expiration: 60, ClockSkew: 10}
+	assert.Nil(t, validity.Check(&trx, time.Now()))
+	assert.Nil(t, validity.Check(&trx, trx.CreatedAt.Add(-time.Second*5)))
+	assert.ErrorIs(t, validity.Check(&trx, trx.CreatedAt.Add(-time.Second*20)), ErrTransactionHasAFutureTime)
+	assert.Nil(t, validity.Check(&trx, trx.CreatedAt.Add(time.Second*65)))
+	assert.ErrorIs(t, validity.Check(&trx, trx.CreatedAt.Add(time.Second*80)), ErrExpiredTransaction)
+	assert.ErrorIs(t, validity.CheckTime(&trx, trx.CreatedAt.Add(-time.Second*20)), ErrTransactionHasAFutureTime)
+	assert.ErrorIs(t, validity.CheckTime(&trx, trx.CreatedAt.Add(time.Second*80)), ErrExpiredTransaction)
+	assert.True(t, validity.Expired(&trx, trx.CreatedAt.Add(time.Second*80)))
+
+	assert.Nil(t, Validity{}.Check(&trx, trx.CreatedAt.Add(time.Hour*24*(ExpirationTimeInDays-1))))
+	assert.ErrorIs(t, Validity{}.Check(&trx, trx.CreatedAt.Add(time.Hour*24*(ExpirationTimeInDays+1))), ErrExpiredTransaction)
+
+	trx.CreatedAt = time.Now().Add(time.Minute)
+	_, err = trx.SignWithValidity(&receiver, wallet.Helper{}, validity)
+	assert.ErrorIs(t, err, ErrTransactionHasAFutureTime)
+}
+
+func TestTransactionMessageVersion(t *testing.T) {
+	issuer, err := wallet.New()
+	assert.Nil(t, err)
+	receiver, err := wallet.New()
+	assert.Nil(t, err)
+
+	trx, err := New("subject", spice.New(10, 0), []byte("data"), receiver.Address(), &issuer)
+	assert.Nil(t, err)
+	assert.Equal(t, trx.Version, MessageVersion)
+	assert.Nil(t, trx.VerifyIssuer(wallet.Helper{}))
+
+	downgraded := trx
+	downgraded.Version = MessageVersionLegacy
+	assert.NotNil(t, downgraded.VerifyIssuer(wallet.Helper{}))
+
+	unknown := trx
+	unknown.Version = MessageVersion + 1
+	assert.ErrorIs(t, unknown.VerifyIssuer(wallet.Helper{}), ErrUnknownMessageVersion)
+	assert.Nil(t, unknown.GetMessage())
+
+	legacy := trx
+	legacy.Version = MessageVersionLegacy
+	legacy.Hash, legacy.IssuerSignature = issuer.Sign(legacy.GetMessage())
+	assert.Nil(t, legacy.VerifyIssuer(wallet.Helper{}))
+	_, legacy.ReceiverSignature = receiver.Sign(legacy.GetMessage())
+	assert.Nil(t, legacy.VerifyIssuerReceiver(wallet.Helper{}))
+
+	// Legacy message is verified for the ledger transactions but new transactions signed with it are rejected.
+	assert.ErrorIs(t, legacy.CheckNewMessageVersion(), ErrLegacyMessageVersion)
+	assert.ErrorIs(t, Validity{}.Check(&legacy, time.Now()), ErrLegacyMessageVersion)
+	_, err = legacy.Sign(&receiver, wallet.Helper{})
+	assert.ErrorIs(t, err, ErrLegacyMessageVersion)
+	assert.ErrorIs(t, unknown.CheckNewMessageVersion(), ErrUnknownMessageVersion)
+	assert.Nil(t, trx.CheckNewMessageVersion())
+
+	buf, err := trx.Encode()
+	assert.Nil(t, err)
+	decoded, err := Decode(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, decoded.Version, MessageVersion)
+	assert.Nil(t, decoded.VerifyIssuer(wallet.Helper{}))
+}
+
+func TestTransactionMessageFieldsSplit(t *testing.T) {
+	createdAt := time.Now()
+	first := Transaction{Subject: "subject", Data: []byte("data"), IssuerAddress: "issuer", ReceiverAddress: "receiver", CreatedAt: createdAt}
+	second := Transaction{Subject: "subjectd", Data: []byte("ata"), IssuerAddress: "issuer", ReceiverAddress: "receiver", CreatedAt: createdAt}
+
+	assert.Equal(t, first.GetMessage(), second.GetMessage())
+
+	first.Version, second.Version = MessageVersionCanonical, MessageVersionCanonical
+	assert.NotEqual(t, first.GetMessage(), second.GetMessage())
+}
+
+// TestTransactionCanonicalMessageVector shares the expected digest with the C client tests in src_c.
+func TestTransactionCanonicalMessageVector(t *testing.T) {
+	trx := Transaction{
+		Version:         MessageVersionCanonical,
+		Subject:         "subject",
+		Data:            []byte("data"),
+		IssuerAddress:   "issuer",
+		ReceiverAddress: "receiver",
+		CreatedAt:       time.Unix(1700000000, 123456000),
+	}
+	digest := sha256.Sum256(trx.GetMessage())
+	assert.Equal(t, hex.EncodeToString(digest[:]), "d5f9676e3432552e023b8317c1f7a83793e625725b6af1b07b7e6b22c3435614")
+}
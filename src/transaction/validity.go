@@ -0,0 +1,51 @@
+package transaction
+
+import "time"
+
+// Validity describes for how long the transaction is valid from being issued
+// and how much the issuer clock may differ from the clock of the node validating the transaction.
+// Zero value Validity uses ExpirationTimeInDays expiration and no clock skew tolerance.
+type Validity struct {
+	Expiration int `yaml:"expiration"` // Seconds the transaction is valid for from being issued, defaults to ExpirationTimeInDays.
+	ClockSkew  int `yaml:"clock_skew"` // Seconds the issuer clock may differ from the node clock, defaults to zero.
+}
+
+func (v Validity) expiration() time.Duration {
+	if v.Expiration <= 0 {
+		return time.Hour * 24 * ExpirationTimeInDays
+	}
+	return time.Duration(v.Expiration) * time.Second
+}
+
+func (v Validity) clockSkew() time.Duration {
+	if v.ClockSkew <= 0 {
+		return 0
+	}
+	return time.Duration(v.ClockSkew) * time.Second
+}
+
+// Check checks the transaction is signed with the current message version
+// and is neither created in the future nor expired at the given time, tolerating the clock skew.
+func (v Validity) Check(t *Transaction, now time.Time) error {
+	if err := t.CheckNewMessageVersion(); err != nil {
+		return err
+	}
+	return v.CheckTime(t, now)
+}
+
+// CheckTime checks the transaction is neither created in the future nor expired at the given time, tolerating the clock skew.
+// Message version is not checked, so the transactions signed before the message version change can be checked as well.
+func (v Validity) CheckTime(t *Transaction, now time.Time) error {
+	if t.CreatedAt.Unix() > now.Add(v.clockSkew()).Unix() {
+		return ErrTransactionHasAFutureTime
+	}
+	if v.Expired(t, now) {
+		return ErrExpiredTransaction
+	}
+	return nil
+}
+
+// Expired returns true if the transaction expired at the given time, tolerating the clock skew.
+func (v Validity) Expired(t *Transaction, now time.Time) bool {
+	return t.CreatedAt.Add(v.expiration()).Unix() < now.Add(-v.clockSkew()).Unix()
+}
@@ -0,0 +1,31 @@
+package transformers
+
+import (
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/spice"
+)
+
+// BalanceToProtoBalance maps the available and locked spice to protobuf balance.
+func BalanceToProtoBalance(available, locked spice.Melange) *protobufcompiled.Balance {
+	return &protobufcompiled.Balance{
+		Currency:              available.Currency,
+		SupplementaryCurrency: available.SupplementaryCurrency,
+		Locked: &protobufcompiled.Spice{
+			Currency:              locked.Currency,
+			SupplementaryCurrency: locked.SupplementaryCurrency,
+		},
+	}
+}
+
+// ProtoBalanceToBalance maps protobuf balance to the available and locked spice.
+// Balance without locked spice, served by the nodes not reporting it, is mapped to empty locked spice.
+func ProtoBalanceToBalance(b *protobufcompiled.Balance) (available, locked spice.Melange) {
+	if b == nil {
+		return spice.Melange{}, spice.Melange{}
+	}
+	available = spice.New(b.Currency, b.SupplementaryCurrency)
+	if b.Locked != nil {
+		locked = spice.New(b.Locked.Currency, b.Locked.SupplementaryCurrency)
+	}
+	return available, locked
+}
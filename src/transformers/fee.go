@@ -0,0 +1,25 @@
+package transformers
+
+import (
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/spice"
+)
+
+// FeeToProtoFee maps the vertex fee to protobuf, empty fee is mapped to nil.
+func FeeToProtoFee(fee spice.Melange) *protobufcompiled.Spice {
+	if fee.Empty() {
+		return nil
+	}
+	return &protobufcompiled.Spice{
+		Currency:              fee.Currency,
+		SupplementaryCurrency: fee.SupplementaryCurrency,
+	}
+}
+
+// ProtoFeeToFee maps protobuf fee to the vertex fee, nil is mapped to empty fee.
+func ProtoFeeToFee(fee *protobufcompiled.Spice) spice.Melange {
+	if fee == nil {
+		return spice.Melange{}
+	}
+	return spice.New(fee.Currency, fee.SupplementaryCurrency)
+}
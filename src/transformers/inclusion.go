@@ -0,0 +1,73 @@
+package transformers
+
+import (
+	"time"
+
+	"github.com/bartossh/Computantis/src/inclusion"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+)
+
+func ProofToProtoProof(p inclusion.Proof) (*protobufcompiled.InclusionProof, error) {
+	if len(p.Steps) == 0 {
+		return &protobufcompiled.InclusionProof{}, ErrProcessing
+	}
+	steps := make([]*protobufcompiled.InclusionStep, 0, len(p.Steps))
+	for _, s := range p.Steps {
+		steps = append(steps, &protobufcompiled.InclusionStep{
+			SignerPublicAddress: s.SignerPublicAddress,
+			CreatedAt:           uint64(s.CreatedAt.UnixNano()),
+			Signature:           s.Signature,
+			TransactionHash:     s.TrxHash[:],
+			Hash:                s.Hash[:],
+			LeftParentHash:      s.LeftParentHash[:],
+			RightParentHash:     s.RightParentHash[:],
+			Weight:              s.Weight,
+			Fee:                 FeeToProtoFee(s.Fee),
+		})
+	}
+	var batch [][]byte
+	for _, h := range p.Batch {
+		batch = append(batch, h[:])
+	}
+	return &protobufcompiled.InclusionProof{
+		TransactionHash: p.TrxHash[:],
+		Steps:           steps,
+		Batch:           batch,
+	}, nil
+}
+
+func ProtoProofToProof(prProof *protobufcompiled.InclusionProof) (inclusion.Proof, error) {
+	if prProof == nil || len(prProof.TransactionHash) != 32 || len(prProof.Steps) == 0 {
+		return inclusion.Proof{}, ErrProcessing
+	}
+	steps := make([]inclusion.Step, 0, len(prProof.Steps))
+	for _, s := range prProof.Steps {
+		if s == nil || len(s.TransactionHash) != 32 || len(s.Hash) != 32 ||
+			len(s.LeftParentHash) != 32 || len(s.RightParentHash) != 32 {
+			return inclusion.Proof{}, ErrProcessing
+		}
+		steps = append(steps, inclusion.Step{
+			SignerPublicAddress: s.SignerPublicAddress,
+			CreatedAt:           time.Unix(0, int64(s.CreatedAt)),
+			Signature:           s.Signature,
+			TrxHash:             [32]byte(s.TransactionHash),
+			Hash:                [32]byte(s.Hash),
+			LeftParentHash:      [32]byte(s.LeftParentHash),
+			RightParentHash:     [32]byte(s.RightParentHash),
+			Weight:              s.Weight,
+			Fee:                 ProtoFeeToFee(s.Fee),
+		})
+	}
+	var batch [][32]byte
+	for _, h := range prProof.Batch {
+		if len(h) != 32 {
+			return inclusion.Proof{}, ErrProcessing
+		}
+		batch = append(batch, [32]byte(h))
+	}
+	return inclusion.Proof{
+		TrxHash: [32]byte(prProof.TransactionHash),
+		Steps:   steps,
+		Batch:   batch,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package transformers
+
+import "time"
+
+// LockTimeToProto maps the transaction unlock time to protobuf, zero time is mapped to zero.
+func LockTimeToProto(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+// ProtoToLockTime maps protobuf unlock time to the transaction unlock time, zero is mapped to zero time.
+func ProtoToLockTime(nanos uint64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos))
+}
+
+// ReleasesToProto maps the released escrow hash to protobuf, zero hash is mapped to nil.
+func ReleasesToProto(h [32]byte) []byte {
+	if h == [32]byte{} {
+		return nil
+	}
+	return h[:]
+}
+
+// ProtoToReleases maps protobuf released escrow hash to the transaction, hash of invalid length is mapped to zero hash.
+func ProtoToReleases(buf []byte) [32]byte {
+	if len(buf) != 32 {
+		return [32]byte{}
+	}
+	return [32]byte(buf)
+}
@@ -0,0 +1,42 @@
+package transformers
+
+import (
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/transaction"
+)
+
+// MultisigToProtoMultisig maps transaction multisig to protobuf multisig.
+// Returns nil if the transaction is not issued by the multi-signature account.
+func MultisigToProtoMultisig(m transaction.Multisig) *protobufcompiled.Multisig {
+	if len(m.Members) == 0 {
+		return nil
+	}
+	signatures := make([]*protobufcompiled.MemberSignature, 0, len(m.Signatures))
+	for _, s := range m.Signatures {
+		signatures = append(signatures, &protobufcompiled.MemberSignature{Address: s.Address, Signature: s.Signature})
+	}
+	return &protobufcompiled.Multisig{
+		Members:    m.Members,
+		Signatures: signatures,
+		Threshold:  m.Threshold,
+	}
+}
+
+// ProtoMultisigToMultisig maps protobuf multisig to transaction multisig.
+func ProtoMultisigToMultisig(m *protobufcompiled.Multisig) transaction.Multisig {
+	if m == nil || len(m.Members) == 0 {
+		return transaction.Multisig{}
+	}
+	signatures := make([]transaction.MemberSignature, 0, len(m.Signatures))
+	for _, s := range m.Signatures {
+		if s == nil {
+			continue
+		}
+		signatures = append(signatures, transaction.MemberSignature{Address: s.Address, Signature: s.Signature})
+	}
+	return transaction.Multisig{
+		Members:    m.Members,
+		Signatures: signatures,
+		Threshold:  m.Threshold,
+	}
+}
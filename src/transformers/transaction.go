@@ -2,6 +2,7 @@ package transformers
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/bartossh/Computantis/src/protobufcompiled"
@@ -17,9 +18,23 @@ var (
 func TrxToProtoTrx(trx transaction.Transaction) (*protobufcompiled.Transaction, error) {
 	if trx.Subject == "" || trx.IssuerAddress == "" ||
 		trx.ReceiverAddress == "" || len(trx.Hash) == 0 ||
-		trx.CreatedAt.IsZero() || len(trx.IssuerSignature) == 0 {
+		trx.CreatedAt.IsZero() || (len(trx.IssuerSignature) == 0 && !trx.IsMultisig()) {
 		return &protobufcompiled.Transaction{}, ErrProcessing
 	}
+	return TrxToProtoUnchecked(&trx), nil
+}
+
+func ProtoTrxToTrx(prTrx *protobufcompiled.Transaction) (transaction.Transaction, error) {
+	if prTrx == nil || prTrx.Subject == "" || prTrx.IssuerAddress == "" ||
+		prTrx.ReceiverAddress == "" || len(prTrx.Hash) == 0 ||
+		prTrx.CreatedAt == 0 || (len(prTrx.IssuerSignature) == 0 && len(prTrx.GetMultisig().GetMembers()) == 0) {
+		return transaction.Transaction{}, ErrTrxIsEmpty
+	}
+	return ProtoTrxToTrxUnchecked(prTrx), nil
+}
+
+// TrxToProtoUnchecked maps the transaction to protobuf transaction without validating it.
+func TrxToProtoUnchecked(trx *transaction.Transaction) *protobufcompiled.Transaction {
 	return &protobufcompiled.Transaction{
 		CreatedAt:         uint64(trx.CreatedAt.UnixNano()),
 		IssuerAddress:     trx.IssuerAddress,
@@ -33,15 +48,18 @@ func TrxToProtoTrx(trx transaction.Transaction) (*protobufcompiled.Transaction,
 			Currency:              trx.Spice.Currency,
 			SupplementaryCurrency: trx.Spice.SupplementaryCurrency,
 		},
-	}, nil
+		Multisig:       MultisigToProtoMultisig(trx.Multisig),
+		UnlockAt:       LockTimeToProto(trx.Lock.UnlockAt),
+		ArbiterAddress: trx.Lock.ArbiterAddress,
+		Releases:       ReleasesToProto(trx.Releases),
+		Nonce:          trx.Nonce,
+		Version:        uint32(trx.Version),
+	}
 }
 
-func ProtoTrxToTrx(prTrx *protobufcompiled.Transaction) (transaction.Transaction, error) {
-	if prTrx == nil || prTrx.Subject == "" || prTrx.IssuerAddress == "" ||
-		prTrx.ReceiverAddress == "" || len(prTrx.Hash) == 0 ||
-		prTrx.CreatedAt == 0 || len(prTrx.IssuerSignature) == 0 {
-		return transaction.Transaction{}, ErrTrxIsEmpty
-	}
+// ProtoTrxToTrxUnchecked maps protobuf transaction to the transaction without validating it.
+// It is assumed that the protobuf transaction and its spice are not nil and the hash is of valid length.
+func ProtoTrxToTrxUnchecked(prTrx *protobufcompiled.Transaction) transaction.Transaction {
 	return transaction.Transaction{
 		CreatedAt:         time.Unix(0, int64(prTrx.CreatedAt)),
 		IssuerAddress:     prTrx.IssuerAddress,
@@ -55,5 +73,22 @@ func ProtoTrxToTrx(prTrx *protobufcompiled.Transaction) (transaction.Transaction
 			Currency:              prTrx.Spice.Currency,
 			SupplementaryCurrency: prTrx.Spice.SupplementaryCurrency,
 		},
-	}, nil
+		Multisig: ProtoMultisigToMultisig(prTrx.Multisig),
+		Lock: transaction.Lock{
+			UnlockAt:       ProtoToLockTime(prTrx.UnlockAt),
+			ArbiterAddress: prTrx.ArbiterAddress,
+		},
+		Releases: ProtoToReleases(prTrx.Releases),
+		Nonce:    prTrx.Nonce,
+		Version:  ProtoToMessageVersion(prTrx.Version),
+	}
+}
+
+// ProtoToMessageVersion maps protobuf transaction message version to the transaction,
+// version out of the byte range is mapped to the highest one, so it is rejected as unknown instead of being truncated.
+func ProtoToMessageVersion(version uint32) uint8 {
+	if version > math.MaxUint8 {
+		return math.MaxUint8
+	}
+	return uint8(version)
 }
@@ -0,0 +1,20 @@
+// Package vertextransformers maps the accountant vertex and confidence to protobuf and back.
+// It is kept apart from transformers, so the wallet clients do not depend on the accountant.
+package vertextransformers
+
+import (
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+)
+
+// ConfidenceToProtoConfidence maps accountant confidence to protobuf confidence.
+func ConfidenceToProtoConfidence(c accountant.Confidence) protobufcompiled.Confidence {
+	switch c {
+	case accountant.ConfidenceConfirmed:
+		return protobufcompiled.Confidence_CONFIRMED
+	case accountant.ConfidenceFinal:
+		return protobufcompiled.Confidence_FINAL
+	default:
+		return protobufcompiled.Confidence_PENDING
+	}
+}
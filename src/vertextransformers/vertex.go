@@ -0,0 +1,51 @@
+package vertextransformers
+
+import (
+	"time"
+
+	"github.com/bartossh/Computantis/src/accountant"
+	"github.com/bartossh/Computantis/src/protobufcompiled"
+	"github.com/bartossh/Computantis/src/transaction"
+	"github.com/bartossh/Computantis/src/transformers"
+)
+
+// ProtoVrxToVrx maps protobuf vertex to accountant vertex.
+// It is assumed that the protobuf vertex and its transactions are not nil and hashes are of valid length.
+func ProtoVrxToVrx(vg *protobufcompiled.Vertex) accountant.Vertex {
+	var batch []transaction.Transaction
+	for _, trx := range vg.Batch {
+		batch = append(batch, transformers.ProtoTrxToTrxUnchecked(trx))
+	}
+	return accountant.Vertex{
+		SignerPublicAddress: vg.SignerPublicAddress,
+		CreatedAt:           time.Unix(0, int64(vg.CreatedAt)),
+		Signature:           vg.Signature,
+		Transaction:         transformers.ProtoTrxToTrxUnchecked(vg.Transaction),
+		Batch:               batch,
+		Hash:                [32]byte(vg.Hash),
+		LeftParentHash:      [32]byte(vg.LeftParentHash),
+		RightParentHash:     [32]byte(vg.RightParentHash),
+		Weight:              vg.Weight,
+		Fee:                 transformers.ProtoFeeToFee(vg.Fee),
+	}
+}
+
+// VrxToProtoVrx maps accountant vertex to protobuf vertex.
+func VrxToProtoVrx(vrx *accountant.Vertex) *protobufcompiled.Vertex {
+	var batch []*protobufcompiled.Transaction
+	for i := range vrx.Batch {
+		batch = append(batch, transformers.TrxToProtoUnchecked(&vrx.Batch[i]))
+	}
+	return &protobufcompiled.Vertex{
+		SignerPublicAddress: vrx.SignerPublicAddress,
+		CreatedAt:           uint64(vrx.CreatedAt.UnixNano()),
+		Signature:           vrx.Signature,
+		Transaction:         transformers.TrxToProtoUnchecked(&vrx.Transaction),
+		Hash:                vrx.Hash[:],
+		LeftParentHash:      vrx.LeftParentHash[:],
+		RightParentHash:     vrx.RightParentHash[:],
+		Weight:              vrx.Weight,
+		Fee:                 transformers.FeeToProtoFee(vrx.Fee),
+		Batch:               batch,
+	}
+}
@@ -28,7 +28,7 @@ type app struct {
 	protobufcompiled.UnimplementedWalletClientAPIServer
 	log               logger.Logger
 	webhooksURL       string
-	centralNodeClient walletmiddleware.Client
+	centralNodeClient *walletmiddleware.Client
 }
 
 // Run runs the service application that exposes the GRPC API for creating, validating and signing transactions.
@@ -49,7 +49,7 @@ func Run(ctx context.Context, cfg Config, log logger.Logger, fw transaction.Veri
 		log.Info(fmt.Sprintf("error with reading wallet from file: %s", err))
 	}
 
-	s := app{log: log, centralNodeClient: *c, webhooksURL: cfg.WebhooksNodeURL}
+	s := app{log: log, centralNodeClient: c, webhooksURL: cfg.WebhooksNodeURL}
 	defer s.close()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%s", cfg.Port))
@@ -160,6 +160,23 @@ func (a *app) Saved(ctx context.Context, in *protobufcompiled.TrxHash) (*protobu
 	return transformers.TrxToProtoTrx(trx)
 }
 
+// SavedWithConfidence returns saved transaction in the DAG with the confidence of its finality. Shall work on any Computantis node.
+func (a *app) SavedWithConfidence(ctx context.Context, in *protobufcompiled.TrxHash) (*protobufcompiled.SavedTransaction, error) {
+	saved, err := a.centralNodeClient.ReadSavedTransactionWithConfidence(ctx, [32]byte(in.Hash))
+	if err != nil {
+		return nil, err
+	}
+	protoTrx, err := transformers.TrxToProtoTrx(saved.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufcompiled.SavedTransaction{
+		Transaction: protoTrx,
+		Confidence:  saved.Confidence,
+		Approvers:   saved.Approvers,
+	}, nil
+}
+
 // WebHook creates a web-hook on the WebHook Computantis node.
 func (a *app) WebHook(ctx context.Context, in *protobufcompiled.CreateWebHook) (*emptypb.Empty, error) {
 	err := a.centralNodeClient.CreateWebhook(ctx, a.webhooksURL, in.Url)
@@ -170,13 +187,10 @@ func (a *app) WebHook(ctx context.Context, in *protobufcompiled.CreateWebHook) (
 }
 
 // Balance checks the balance of the given address.
-func (a *app) Balance(ctx context.Context, _ *emptypb.Empty) (*protobufcompiled.Spice, error) {
+func (a *app) Balance(ctx context.Context, _ *emptypb.Empty) (*protobufcompiled.Balance, error) {
 	balance, err := a.centralNodeClient.ReadBalance(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &protobufcompiled.Spice{
-		Currency:              balance.Currency,
-		SupplementaryCurrency: balance.SupplementaryCurrency,
-	}, nil
+	return transformers.BalanceToProtoBalance(balance.Available, balance.Locked), nil
 }
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/bartossh/Computantis/src/grpcsecured"
 	"github.com/bartossh/Computantis/src/httpclient"
+	"github.com/bartossh/Computantis/src/inclusion"
 	"github.com/bartossh/Computantis/src/protobufcompiled"
 	"github.com/bartossh/Computantis/src/spice"
 	"github.com/bartossh/Computantis/src/transaction"
@@ -51,6 +54,8 @@ type Client struct {
 	apiRoot       string
 	w             wallet.Wallet
 	ready         bool
+	nonceMux      sync.Mutex
+	nonce         uint64
 }
 
 // NewClient creates a new rest client.
@@ -119,6 +124,7 @@ func (c *Client) NewWallet() error {
 	}
 	c.w = w
 	c.ready = true
+	c.resetNonce()
 
 	return nil
 }
@@ -129,7 +135,12 @@ func (c *Client) ProposeTransaction(ctx context.Context, receiverAddr string, su
 		return ErrWalletNotReady
 	}
 
-	trx, err := transaction.New(subject, spc, data, receiverAddr, &c.w)
+	nonce, err := c.nextNonce(ctx)
+	if err != nil {
+		return err
+	}
+
+	trx, err := transaction.NewWithNonce(subject, spc, data, receiverAddr, nonce, &c.w)
 	if err != nil {
 		return errors.Join(httpclient.ErrSigningFailed, err)
 	}
@@ -144,6 +155,30 @@ func (c *Client) ProposeTransaction(ctx context.Context, receiverAddr string, su
 	return err
 }
 
+// nextNonce returns the nonce of the next transaction issued by the wallet.
+// It is the nonce expected by the notary node unless the client already used it for the transaction
+// that is not yet in the DAG, as the transaction awaiting the receiver signature.
+func (c *Client) nextNonce(ctx context.Context) (uint64, error) {
+	next, err := c.client.NextNonce(ctx, &protobufcompiled.Address{Public: c.w.Address()})
+	if err != nil {
+		return 0, err
+	}
+	if next == nil {
+		return 0, ErrEmptyMessage
+	}
+
+	c.nonceMux.Lock()
+	defer c.nonceMux.Unlock()
+	c.nonce = max(c.nonce+1, next.Nonce)
+	return c.nonce, nil
+}
+
+func (c *Client) resetNonce() {
+	c.nonceMux.Lock()
+	defer c.nonceMux.Unlock()
+	c.nonce = 0
+}
+
 // ConfirmTransaction confirms transaction by signing it with the wallet.
 func (c *Client) ConfirmTransaction(ctx context.Context, notaryNodeURL string, trx *transaction.Transaction) error {
 	if !c.ready {
@@ -290,6 +325,56 @@ func (c *Client) ReadDAGTransactions(ctx context.Context) ([]transaction.Transac
 	return trxs, nil
 }
 
+// ReadTransactionsHistory reads the page of transactions belonging to current wallet, ordered from the oldest.
+// Transactions are read as well after the DAG has been truncated. Offset is a number of transactions to skip
+// and limit is a maximum number of transactions to read, node may return less than limit when it caps the page size.
+func (c *Client) ReadTransactionsHistory(ctx context.Context, offset, limit uint64) ([]transaction.Transaction, error) {
+	if !c.ready {
+		return nil, httpclient.ErrWalletNotReady
+	}
+
+	data, err := c.client.Data(ctx, &protobufcompiled.Address{Public: c.w.Address()})
+	if err != nil {
+		return nil, err
+	}
+
+	digest, signature := c.w.Sign(data.Blob)
+	proto, err := c.client.TransactionsByAddress(ctx, &protobufcompiled.TransactionsPage{
+		SignedHash: &protobufcompiled.SignedHash{
+			Address:   c.w.Address(),
+			Data:      data.Blob,
+			Signature: signature,
+			Hash:      digest[:],
+		},
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trxs := make([]transaction.Transaction, 0, len(proto.Array))
+	for i := range proto.Array {
+		trx, err := transformers.ProtoTrxToTrx(proto.Array[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := trx.VerifyIssuer(c.verifier); err != nil {
+			return nil, err
+		}
+		trxs = append(trxs, trx)
+	}
+
+	return trxs, nil
+}
+
+// SavedTransaction is the transaction saved in the DAG with the confidence of its finality.
+type SavedTransaction struct {
+	Transaction transaction.Transaction
+	Confidence  protobufcompiled.Confidence
+	Approvers   uint64
+}
+
 // ReadSavedTransaction reads saved transaction from connected node.
 func (c *Client) ReadSavedTransaction(ctx context.Context, hash [32]byte) (transaction.Transaction, error) {
 	if !c.ready {
@@ -308,6 +393,40 @@ func (c *Client) ReadSavedTransaction(ctx context.Context, hash [32]byte) (trans
 		return transaction.Transaction{}, err
 	}
 
+	return c.verifySavedTransaction(protoTrx)
+}
+
+// ReadSavedTransactionWithConfidence reads saved transaction from connected node with the confidence of its finality.
+// Returned confidence allows to wait until the transaction is final.
+func (c *Client) ReadSavedTransactionWithConfidence(ctx context.Context, hash [32]byte) (SavedTransaction, error) {
+	if !c.ready {
+		return SavedTransaction{}, httpclient.ErrWalletNotReady
+	}
+
+	digest, signature := c.w.Sign(hash[:])
+
+	saved, err := c.client.SavedWithConfidence(ctx, &protobufcompiled.SignedHash{
+		Address:   c.w.Address(),
+		Data:      hash[:],
+		Signature: signature,
+		Hash:      digest[:],
+	})
+	if err != nil {
+		return SavedTransaction{}, err
+	}
+
+	trx, err := c.verifySavedTransaction(saved.Transaction)
+	if err != nil {
+		return SavedTransaction{}, err
+	}
+	return SavedTransaction{
+		Transaction: trx,
+		Confidence:  saved.Confidence,
+		Approvers:   saved.Approvers,
+	}, nil
+}
+
+func (c *Client) verifySavedTransaction(protoTrx *protobufcompiled.Transaction) (transaction.Transaction, error) {
 	trx, err := transformers.ProtoTrxToTrx(protoTrx)
 	if err != nil {
 		return transaction.Transaction{}, err
@@ -324,10 +443,47 @@ func (c *Client) ReadSavedTransaction(ctx context.Context, hash [32]byte) (trans
 	return trx, nil
 }
 
+// ReadInclusionProof reads the proof of inclusion of the transaction with given hash in the DAG.
+// The proof is verified before it is returned, it is up to the caller to decide if the proof leaf is trusted.
+func (c *Client) ReadInclusionProof(ctx context.Context, hash [32]byte) (inclusion.Proof, error) {
+	if !c.ready {
+		return inclusion.Proof{}, httpclient.ErrWalletNotReady
+	}
+
+	digest, signature := c.w.Sign(hash[:])
+	protoProof, err := c.client.Proof(ctx, &protobufcompiled.SignedHash{
+		Address:   c.w.Address(),
+		Data:      hash[:],
+		Signature: signature,
+		Hash:      digest[:],
+	})
+	if err != nil {
+		return inclusion.Proof{}, err
+	}
+
+	proof, err := transformers.ProtoProofToProof(protoProof)
+	if err != nil {
+		return inclusion.Proof{}, err
+	}
+	if proof.TrxHash != hash {
+		return inclusion.Proof{}, inclusion.ErrTrxNotInVertex
+	}
+	if err := inclusion.Verify(proof, c.verifier); err != nil {
+		return inclusion.Proof{}, err
+	}
+	return proof, nil
+}
+
+// Balance is the account owner balance, spice locked until its unlock time or arbiter release is reported apart from the available spice.
+type Balance struct {
+	Available spice.Melange
+	Locked    spice.Melange
+}
+
 // ReadBalance reads balance of given account owner.
-func (c *Client) ReadBalance(ctx context.Context) (spice.Melange, error) {
+func (c *Client) ReadBalance(ctx context.Context) (Balance, error) {
 	if !c.ready {
-		return spice.Melange{}, httpclient.ErrWalletNotReady
+		return Balance{}, httpclient.ErrWalletNotReady
 	}
 
 	data := []byte(c.w.Address())
@@ -339,10 +495,46 @@ func (c *Client) ReadBalance(ctx context.Context) (spice.Melange, error) {
 		Signature: signature,
 	})
 	if err != nil {
-		return spice.Melange{}, err
+		return Balance{}, err
+	}
+
+	available, locked := transformers.ProtoBalanceToBalance(balance)
+	return Balance{Available: available, Locked: locked}, nil
+}
+
+// ReadBalanceAtVertex reads balance of given account owner as it was when vertex of given hash has been created.
+func (c *Client) ReadBalanceAtVertex(ctx context.Context, vrxHash [32]byte) (Balance, error) {
+	return c.readHistoricalBalance(ctx, vrxHash[:], 0)
+}
+
+// ReadBalanceAtTime reads balance of given account owner as it was at the given time.
+func (c *Client) ReadBalanceAtTime(ctx context.Context, at time.Time) (Balance, error) {
+	return c.readHistoricalBalance(ctx, nil, uint64(at.UnixNano()))
+}
+
+func (c *Client) readHistoricalBalance(ctx context.Context, vrxHash []byte, createdAt uint64) (Balance, error) {
+	if !c.ready {
+		return Balance{}, httpclient.ErrWalletNotReady
+	}
+
+	data := []byte(c.w.Address())
+	digest, signature := c.w.Sign(data)
+	balance, err := c.client.BalanceAt(ctx, &protobufcompiled.HistoricalBalance{
+		SignedHash: &protobufcompiled.SignedHash{
+			Address:   c.w.Address(),
+			Data:      data,
+			Hash:      digest[:],
+			Signature: signature,
+		},
+		VertexHash: vrxHash,
+		CreatedAt:  createdAt,
+	})
+	if err != nil {
+		return Balance{}, err
 	}
 
-	return spice.Melange{Currency: balance.Currency, SupplementaryCurrency: balance.SupplementaryCurrency}, nil
+	available, locked := transformers.ProtoBalanceToBalance(balance)
+	return Balance{Available: available, Locked: locked}, nil
 }
 
 // SaveWalletToFile saves the wallet to the file in the path.
@@ -362,6 +554,7 @@ func (c *Client) ReadWalletFromFile() error {
 	}
 	c.w = w
 	c.ready = true
+	c.resetNonce()
 	return nil
 }
 
@@ -414,4 +607,5 @@ func (c *Client) URL() string {
 func (c *Client) FlushWalletFromMemory() {
 	c.w = wallet.Wallet{}
 	c.ready = false
+	c.resetNonce()
 }
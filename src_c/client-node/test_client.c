@@ -417,6 +417,43 @@ static void test_transaction_new_success()
     free(receiver_address);
 }
 
+static void test_transaction_message_vector()
+{
+    // Prepare
+    // Expected digest is shared with TestTransactionCanonicalMessageVector of the Go transaction package.
+    const char *expected = "d5f9676e3432552e023b8317c1f7a83793e625725b6af1b07b7e6b22c3435614";
+    char subject[] = "subject";
+    unsigned char data[] = "data";
+    char issuer_address[] = "issuer";
+    char receiver_address[] = "receiver";
+    Transaction trx = {
+        .version = TRANSACTION_MESSAGE_VERSION,
+        .created_at = {.tv_sec = 1700000000, .tv_usec = 123456},
+        .issuer_address = issuer_address,
+        .receiver_address = receiver_address,
+        .subject = subject,
+        .data = data,
+    };
+
+    // Test
+    size_t len = 0;
+    unsigned char *message = Transaction_message(&trx, &len);
+    TEST_ASSERT_NOT_NULL(message);
+    TEST_ASSERT_EQUAL_size_t(118, len);
+    TEST_ASSERT_EQUAL_UINT8(TRANSACTION_MESSAGE_VERSION, message[0]);
+
+    unsigned char digest[SHA256_DIGEST_LENGTH];
+    SHA256(message, len, digest);
+    char digest_hex[2*SHA256_DIGEST_LENGTH + 1];
+    for (size_t i = 0; i < SHA256_DIGEST_LENGTH; i++) {
+        sprintf(digest_hex + 2*i, "%02x", digest[i]);
+    }
+    TEST_ASSERT_EQUAL_STRING(expected, digest_hex);
+
+    // Cleanup
+    free(message);
+}
+
 int main(void)
 {
     UnityBegin("test_client.c");
@@ -435,6 +472,7 @@ int main(void)
     RUN_TEST(test_read_config);
     RUN_TEST(test_handle_erroneous_config);
     RUN_TEST(test_transaction_new_success);
+    RUN_TEST(test_transaction_message_vector);
 
     return UnityEnd();
 }
@@ -10,115 +10,142 @@
 #include <sys/time.h>
 #include <unistd.h>
 #include <string.h>
+#include <stdint.h>
 #include <openssl/sha.h>
 #include "transaction.h"
 #include "../signer/signer.h"
 #include "../signature/signature.h"
 #include "../wallet/wallet.h"
 
-static void convertToCharArrayLittleEndian(unsigned char *arr, long long a)
+static size_t put_uint32_little_endian(unsigned char *arr, uint32_t a)
 {
-    for (int i = 0; i < 8; ++i)
+    for (int i = 0; i < 4; ++i)
     {
-        arr[i] = (unsigned char)((((unsigned long long) a) >> (56 - (8*i))) & 0xFFu);
+        arr[i] = (unsigned char)((a >> (8*i)) & 0xFFu);
     }
+    return 4;
 }
 
-Transaction *Transaction_new(const char *subject, const unsigned char *data, const char *receiver_address, Signer *s)
+static size_t put_uint64_little_endian(unsigned char *arr, uint64_t a)
 {
-    if (receiver_address == NULL || strlen(receiver_address) == 0)
+    for (int i = 0; i < 8; ++i)
     {
-        printf("Given receiver address is empty\n");
-        exit(1);
+        arr[i] = (unsigned char)((a >> (8*i)) & 0xFFu);
     }
-    if (subject == NULL || strlen(subject) == 0)
+    return 8;
+}
+
+static size_t put_field(unsigned char *arr, const unsigned char *field, size_t len)
+{
+    size_t p = put_uint32_little_endian(arr, (uint32_t)len);
+    if (len > 0)
     {
-        printf("Given subject is empty\n");
-        exit(1);
+        memcpy(arr + p, field, len);
     }
-    if (data == NULL || strlen((char *)data) == 0)
+    return p + len;
+}
+
+static char *copy_string(const char *str)
+{
+    size_t len = strlen(str);
+    char *cpy = malloc(sizeof(char)*(len + 1));
+    if (cpy == NULL)
     {
-        printf("Given data is empty\n");
+        printf("Failed to allocate [ %li ] bytes\n", len + 1);
         exit(1);
     }
-    // prepare buffer phase
-    struct timeval now;
-    gettimeofday(&now, NULL);
-    RawCryptoKey raw_key = Signer_get_public_key(s);
-    char *issuer_address = encode_address_from_raw(WalletVersion, raw_key.buffer, raw_key.len);
-    if (issuer_address == NULL || strlen(issuer_address) == 0)
+    memcpy(cpy, str, len + 1);
+    return cpy;
+}
+
+unsigned char *Transaction_message(const Transaction *trx, size_t *len)
+{
+    if (trx == NULL || trx->version != TRANSACTION_MESSAGE_VERSION)
     {
-        printf("Failed to read issuer address\n");
+        printf("Transaction message version is not supported\n");
         exit(1);
     }
-    
-    size_t subject_len = strlen(subject);
-    size_t data_len = strlen((char *)data);
-    size_t issuer_len = strlen(issuer_address);
-    size_t receiver_len = strlen(receiver_address);
-    size_t ts_len = 8;
-    size_t buf_len = subject_len + data_len + issuer_len + receiver_len + ts_len; 
-
-    long long ms_time = now.tv_sec + now.tv_usec;
-    unsigned char ts[ts_len];
-    convertToCharArrayLittleEndian(ts, ms_time);
-   
+    size_t subject_len = strlen(trx->subject);
+    size_t data_len = strlen((char *)trx->data);
+    size_t issuer_len = strlen(trx->issuer_address);
+    size_t receiver_len = strlen(trx->receiver_address);
+    size_t buf_len = 1 + 4*5 + 8*5 + SHA256_DIGEST_LENGTH + subject_len + data_len + issuer_len + receiver_len;
+
     unsigned char *buffer = malloc(sizeof(unsigned char) * buf_len);
     if (buffer == NULL)
     {
         printf("Failed to allocate [ %li ] bytes\n", buf_len);
         exit(1);
     }
-    size_t p = 0;
-    strncpy((char *)buffer, subject, subject_len);
-    p = p + subject_len;
-    strncpy((char *)(buffer + p), (char *)data, data_len);
-    p = p + data_len;
-    strncpy((char *)(buffer + p), issuer_address, issuer_len);
-    p = p + issuer_len;
-    strncpy((char *)(buffer + p), receiver_address, receiver_len);
-    p = p + receiver_len;
-    strncpy((char *)(buffer + p), (char *)ts, ts_len);
-
-    // sign phase
-    Signature signature = Signer_sign(s, buffer, buf_len);
 
-    // create transaction phase
+    uint64_t created_at = (uint64_t)trx->created_at.tv_sec * 1000000000ull + (uint64_t)trx->created_at.tv_usec * 1000ull;
 
-    Transaction *trx = malloc(sizeof(Transaction));
-    trx->created_at = now;
+    size_t p = 0;
+    buffer[p++] = trx->version;
+    p += put_field(buffer + p, (const unsigned char *)trx->subject, subject_len);
+    p += put_field(buffer + p, trx->data, data_len);
+    p += put_field(buffer + p, (const unsigned char *)trx->issuer_address, issuer_len);
+    p += put_field(buffer + p, (const unsigned char *)trx->receiver_address, receiver_len);
+    p += put_uint64_little_endian(buffer + p, created_at);
+    // The C client doesn't transfer spice, lock the funds nor use the nonce, so those fields are zero.
+    p += put_uint64_little_endian(buffer + p, 0); // currency
+    p += put_uint64_little_endian(buffer + p, 0); // supplementary currency
+    p += put_uint64_little_endian(buffer + p, 0); // unlock at
+    p += put_field(buffer + p, NULL, 0);          // arbiter address
+    memset(buffer + p, 0, SHA256_DIGEST_LENGTH);  // releases
+    p += SHA256_DIGEST_LENGTH;
+    p += put_uint64_little_endian(buffer + p, 0); // nonce
+
+    *len = p;
+    return buffer;
+}
 
-    trx->issuer_address = malloc(sizeof(char *)*issuer_len);
-    if (trx->issuer_address == NULL)
+Transaction *Transaction_new(const char *subject, const unsigned char *data, const char *receiver_address, Signer *s)
+{
+    if (receiver_address == NULL || strlen(receiver_address) == 0)
     {
-        printf("Failed to allocate [ %li ] bytes\n", issuer_len);
+        printf("Given receiver address is empty\n");
         exit(1);
     }
-    strncpy(trx->issuer_address, issuer_address, issuer_len);
-
-    trx->receiver_address = malloc(sizeof(char *)*issuer_len);
-    if (trx->receiver_address == NULL)
+    if (subject == NULL || strlen(subject) == 0)
     {
-        printf("Failed to allocate [ %li ] bytes\n", receiver_len);
+        printf("Given subject is empty\n");
         exit(1);
     }
-    strncpy(trx->receiver_address, receiver_address, receiver_len);
-
-    trx->subject = malloc(sizeof(char)*subject_len);
-    if (trx->subject == NULL)
+    if (data == NULL || strlen((char *)data) == 0)
     {
-        printf("Failed to allocate [ %li ] bytes\n", subject_len);
+        printf("Given data is empty\n");
+        exit(1);
+    }
+    // prepare transaction phase
+    struct timeval now;
+    gettimeofday(&now, NULL);
+    RawCryptoKey raw_key = Signer_get_public_key(s);
+    char *issuer_address = encode_address_from_raw(WalletVersion, raw_key.buffer, raw_key.len);
+    if (issuer_address == NULL || strlen(issuer_address) == 0)
+    {
+        printf("Failed to read issuer address\n");
         exit(1);
     }
-    strncpy(trx->subject, subject, subject_len);
 
-    trx->data = malloc(sizeof(unsigned char)*data_len);
-    if (trx->data == NULL)
+    Transaction *trx = malloc(sizeof(Transaction));
+    if (trx == NULL)
     {
-        printf("Failed to allocate [ %li ] bytes\n", data_len);
+        printf("Failed to allocate [ %li ] bytes\n", sizeof(Transaction));
         exit(1);
     }
-    strncpy((char*)trx->data, (char*)data, data_len);
+    trx->version = TRANSACTION_MESSAGE_VERSION;
+    trx->created_at = now;
+    trx->issuer_address = copy_string(issuer_address);
+    trx->receiver_address = copy_string(receiver_address);
+    trx->subject = copy_string(subject);
+    trx->data = (unsigned char *)copy_string((char *)data);
+    trx->receiver_signature = NULL;
+
+    // sign phase
+    size_t buf_len = 0;
+    unsigned char *buffer = Transaction_message(trx, &buf_len);
+    Signature signature = Signer_sign(s, buffer, buf_len);
 
     if (signature.signature_len != SIGNATURE_LEN)
     {
@@ -131,9 +158,7 @@ Transaction *Transaction_new(const char *subject, const unsigned char *data, con
         printf("Failed to allocate [ %i ] bytes\n", SIGNATURE_LEN);
         exit(1);
     }
-    strncpy((char *)trx->issuer_signature, (char *)signature.signature_buffer, SIGNATURE_LEN);
-   
-    trx->receiver_signature = NULL;
+    memcpy(trx->issuer_signature, signature.signature_buffer, SIGNATURE_LEN);
 
     if (signature.digest_len != SHA256_DIGEST_LENGTH)
     {
@@ -146,7 +171,7 @@ Transaction *Transaction_new(const char *subject, const unsigned char *data, con
         printf("Failed to allocate [ %i ] bytes\n", SHA256_DIGEST_LENGTH);
         exit(1);
     }
-    strncpy((char *)trx->hash, (char *)signature.digest_buffer, SHA256_DIGEST_LENGTH);
+    memcpy(trx->hash, signature.digest_buffer, SHA256_DIGEST_LENGTH);
 
     // cleanup phase
     free(issuer_address);
@@ -17,10 +17,17 @@
 #include "../address/address.h"
 #include "../signature/signature.h"
 
+///
+/// TRANSACTION_MESSAGE_VERSION is the version of the canonical message the transaction is signed with.
+/// It shall match the transaction.MessageVersion of the Go implementation.
+///
+#define TRANSACTION_MESSAGE_VERSION 1
+
 /// 
 /// Transaction seals the embedded data cryptographically.
 ///
 typedef struct {
+    unsigned char   version;
     struct timeval  created_at;
     char            *issuer_address;
     char            *receiver_address;
@@ -37,6 +44,15 @@ typedef struct {
 ///
 Transaction *Transaction_new(const char *subject, const unsigned char *data, const char *receiver_address, Signer *s);
 
+///
+/// Transaction_message returns the canonical message of the transaction that is signed by the issuer and the receiver.
+/// Variable length fields are prefixed with the uint32 length and all integers are little-endian,
+/// the same way as the transaction.GetMessage of the Go implementation does.
+/// The len is set to the message length.
+/// Function caller is required to free received message.
+///
+unsigned char *Transaction_message(const Transaction *trx, size_t *len);
+
 /// 
 /// Transaction_receiver_sign signs transaction by the receiver only if message digest is correct and issuer signature is valid,
 /// otherwise returns false.